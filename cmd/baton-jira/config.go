@@ -1,17 +1,133 @@
 package main
 
 import (
+	"github.com/conductorone/baton-jira/pkg/client"
 	"github.com/conductorone/baton-sdk/pkg/field"
 )
 
 var (
-	jiraUrlField  = field.StringField("jira-url", field.WithRequired(true), field.WithDescription("Url to Jira service."))
-	emailField    = field.StringField("jira-email", field.WithRequired(true), field.WithDescription("Email for Jira service."))
-	apiTokenField = field.StringField("jira-api-token", field.WithRequired(true), field.WithDescription("API token for Jira service."))
+	jiraUrlField = field.StringField("jira-url", field.WithRequired(true), field.WithDescription("Url to Jira service."))
+
+	additionalUrlsField = field.StringSliceField("jira-additional-urls", field.WithDescription("Urls of other Jira Cloud sites of the same Atlassian organization to sync with the same credentials. Requires --atlassian-org-id and --atlassian-api-token."))
+	emailField          = field.StringField("jira-email", field.WithRequired(true), field.WithDescription("Email for Jira service."))
+	apiTokenField       = field.StringField("jira-api-token", field.WithRequired(true), field.WithDescription("API token for Jira service."))
+
+	deploymentTypeField = field.StringField("jira-deployment-type", field.WithDefaultValue("cloud"), field.WithDescription(`Jira deployment type: "cloud" or "server" (Jira Data Center 9.x and later).`))
+
+	projectKeysField = field.StringSliceField("jira-project-keys", field.WithDescription("Keys of the projects to sync and use for ticketing. Validated instead of user and group access when full sync is skipped."))
+
+	syncAllProjectsField = field.BoolField("sync-all-projects", field.WithDefaultValue(true), field.WithDescription("Sync every project even when --jira-project-keys is set, which then only applies to ticketing. Set to false to only sync the projects of --jira-project-keys."))
+
+	syncConcurrencyField = field.IntField("jira-sync-concurrency", field.WithDefaultValue(1), field.WithDescription("Number of projects to fetch in parallel during sync."))
+
+	warmUpBudgetField = field.IntField("jira-warm-up-budget-seconds", field.WithDefaultValue(10), field.WithDescription("Seconds spent prefetching roles and projects at the start of a sync. 0 skips the warm-up."))
+
+	groupSizeLogThresholdField = field.IntField("group-size-log-threshold", field.WithDescription("Log the synced member count of every group with at least this many members. 0 disables the log."))
+
+	requestsPerSecondField = field.IntField("jira-requests-per-second", field.WithDefaultValue(client.DefaultRequestsPerSecond), field.WithDescription("Maximum number of requests per second sent to Jira. 0 disables the limit."))
+
+	endpointWeightsField = field.StringSliceField("jira-endpoint-weights", field.WithDescription("Requests a call to an endpoint counts as against --jira-requests-per-second, as path fragment=weight, e.g. /group/member=4. Overrides the defaults for group members, permission searches and create metadata."))
+
+	requestJitterMaxField = field.IntField("jira-request-jitter-max-ms", field.WithDescription("Ceiling in milliseconds of a random wait before each request to a weighted endpoint. 0 disables the jitter."))
+
+	syncFiltersField = field.BoolField("sync-filters", field.WithDescription("Sync saved filters and who they are shared with."))
+
+	syncDashboardsFiltersField = field.BoolField("sync-dashboards-filters", field.WithDescription("Sync dashboards and saved filters, and who they are shared with or editable by."))
+
+	ticketSchemaCacheTTLField = field.IntField("ticket-schema-cache-ttl-seconds", field.WithDefaultValue(600), field.WithDescription("Seconds ticket schemas and project statuses are cached. 0 disables the cache."))
+
+	ticketIncludeWatchersField = field.BoolField("ticket-include-watchers", field.WithDescription("Include issue watchers on tickets. Costs one extra request per ticket."))
+
+	ticketDropInvalidFieldsField = field.BoolField("ticket-drop-invalid-fields", field.WithDescription("Create a ticket Jira rejects for some of its custom fields again without them, and list them on the ticket."))
+
+	ticketConsolidateSchemasField = field.BoolField("ticket-consolidate-schemas", field.WithDescription("List one ticket schema for an issue type whose fields are identical in several projects, with a field picking the project, instead of one per project."))
+
+	excludedIssueTypesField = field.StringSliceField("jira-excluded-issue-types", field.WithDefaultValue([]string{"Subtask"}), field.WithDescription("Names of the issue types left out of ticket schemas, ignoring case. Issue types Jira flags as subtasks are always left out."))
+
+	syncPermissionSchemesField = field.BoolField("sync-permission-schemes", field.WithDescription("Sync permission schemes and who holds each permission."))
+
+	sendInvitationOnCreateField = field.BoolField("send-invitation-on-create", field.WithDefaultValue(true), field.WithDescription("Email the invitation to accounts created by the connector on Jira Data Center. Jira Cloud always emails invited users."))
+
+	atlassianOrgIDField    = field.StringField("atlassian-org-id", field.WithDescription("Atlassian organization ID. Enables org directory data when set with --atlassian-api-token."))
+	atlassianAPITokenField = field.StringField("atlassian-api-token", field.WithDescription("Atlassian organization admin API key."))
+
+	syncNotificationSchemesField = field.BoolField("sync-notification-schemes", field.WithDescription("Sync notification schemes and who each event notifies. Costs one extra request per project."))
+
+	syncComponentsField = field.BoolField("sync-components", field.WithDescription("Sync the components of each project and their leads. Costs one extra request per project."))
+
+	syncIssueSecurityField = field.BoolField("sync-issue-security", field.WithDescription("Sync issue security levels and who can see their issues."))
+
+	syncProjectRolesField = field.BoolField("sync-project-roles", field.WithDescription("Sync the roles of each project and who is assigned to them. Also synced by --sync-issue-types, --sync-permission-schemes and the filter syncs, which grant to project roles."))
+
+	syncIssueTypesField = field.BoolField("sync-issue-types", field.WithDescription("Sync issue types and the project roles that can create them."))
+
+	syncUserPropertiesField = field.BoolField("sync-user-properties", field.WithDescription("Attach the entity properties stored on each user. Costs at least one extra request per user."))
+
+	explainParticipantGrantsField = field.BoolField("explain-participant-grants", field.WithDescription("Annotate project participate grants with the permission scheme holder that grants them. Costs one extra request per permission scheme."))
+
+	syncAtlassianRolesField = field.BoolField("sync-atlassian-roles", field.WithDescription("Sync the org role assignments on the organization and the site, e.g. org admins. Requires --atlassian-org-id and --atlassian-api-token."))
+
+	expandNestedGroupsField = field.BoolField("expand-nested-groups", field.WithDescription("Grant group memberships to the nested groups of a group, expanded to their members. Requires --atlassian-org-id and --atlassian-api-token."))
+
+	annotateManagedGroupGrantsField = field.BoolField("annotate-managed-group-grants", field.WithDescription("Annotate the grants of groups synced from an identity provider with the directory that manages them. Requires --atlassian-org-id and --atlassian-api-token."))
+
+	claimStatusFilterField = field.StringSliceField("claim-status-filter", field.WithDescription("Only sync users whose org directory claim status is in the list, e.g. VERIFIED. Requires --atlassian-org-id and --atlassian-api-token."))
+
+	modelDefaultGroupsAsLicensesField = field.BoolField("model-default-groups-as-licenses", field.WithDescription("Sync product access as license grants of application roles instead of member grants of the products' default groups, e.g. jira-software-users."))
+
+	userListTimeoutField     = field.IntField("user-list-timeout-seconds", field.WithDescription("Seconds a single page of users may take. 0 keeps the caller's deadline."))
+	groupListTimeoutField    = field.IntField("group-list-timeout-seconds", field.WithDescription("Seconds a single page of groups or group members may take. 0 keeps the caller's deadline."))
+	ticketCreateTimeoutField = field.IntField("ticket-create-timeout-seconds", field.WithDescription("Seconds the creation of a ticket may take. 0 keeps the caller's deadline."))
+
+	maxRetriesField          = field.IntField("max-retries", field.WithDefaultValue(3), field.WithDescription("Retries of a request Jira throttled or could not serve. 0 disables retries."))
+	retryInitialBackoffField = field.IntField("retry-initial-backoff-seconds", field.WithDefaultValue(1), field.WithDescription("Seconds before the first retry of a request, doubled before each next one, unless Jira sends Retry-After."))
+	retryMaxElapsedField     = field.IntField("retry-max-elapsed-seconds", field.WithDefaultValue(60), field.WithDescription("Seconds a request may take including its retries. 0 means no bound."))
+
+	syncJSMOrganizationsField = field.BoolField("sync-jsm-organizations", field.WithDescription("Sync Jira Service Management organizations and their customers."))
 )
 
 var configurationFields = []field.SchemaField{
 	jiraUrlField,
+	additionalUrlsField,
 	emailField,
 	apiTokenField,
+	deploymentTypeField,
+	projectKeysField,
+	syncAllProjectsField,
+	syncConcurrencyField,
+	warmUpBudgetField,
+	requestsPerSecondField,
+	endpointWeightsField,
+	requestJitterMaxField,
+	groupSizeLogThresholdField,
+	syncFiltersField,
+	syncDashboardsFiltersField,
+	syncJSMOrganizationsField,
+	syncPermissionSchemesField,
+	syncNotificationSchemesField,
+	syncComponentsField,
+	syncProjectRolesField,
+	syncIssueSecurityField,
+	syncIssueTypesField,
+	syncUserPropertiesField,
+	explainParticipantGrantsField,
+	syncAtlassianRolesField,
+	modelDefaultGroupsAsLicensesField,
+	userListTimeoutField,
+	groupListTimeoutField,
+	ticketCreateTimeoutField,
+	maxRetriesField,
+	retryInitialBackoffField,
+	retryMaxElapsedField,
+	ticketIncludeWatchersField,
+	ticketDropInvalidFieldsField,
+	ticketConsolidateSchemasField,
+	excludedIssueTypesField,
+	ticketSchemaCacheTTLField,
+	sendInvitationOnCreateField,
+	atlassianOrgIDField,
+	atlassianAPITokenField,
+	claimStatusFilterField,
+	expandNestedGroupsField,
+	annotateManagedGroupGrantsField,
 }
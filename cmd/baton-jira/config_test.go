@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/conductorone/baton-sdk/pkg/field"
+)
+
+// Defaults that existing deployments rely on, which must not change on upgrade.
+func TestBoolFieldDefaults(t *testing.T) {
+	tests := []struct {
+		name  string
+		field field.SchemaField
+		want  bool
+	}{
+		{name: "sync-all-projects", field: syncAllProjectsField, want: true},
+		{name: "send-invitation-on-create", field: sendInvitationOnCreateField, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.field.Bool()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("default = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
+	"github.com/conductorone/baton-jira/pkg/client"
 	"github.com/conductorone/baton-jira/pkg/connector"
 	configSchema "github.com/conductorone/baton-sdk/pkg/config"
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
@@ -38,20 +40,79 @@ func main() {
 func getConnector(ctx context.Context, v *viper.Viper) (types.ConnectorServer, error) {
 	l := ctxzap.Extract(ctx)
 
+	endpointWeights, err := client.ParseEndpointWeights(v.GetStringSlice("jira-endpoint-weights"))
+	if err != nil {
+		l.Error("error creating connector", zap.Error(err))
+		return nil, err
+	}
+
 	builder := connector.JiraBasicAuthBuilder{
 		Base: &connector.JiraOptions{
-			Url: v.GetString("jira-url"),
+			Url:                          v.GetString("jira-url"),
+			AdditionalUrls:               v.GetStringSlice("jira-additional-urls"),
+			DeploymentType:               v.GetString("jira-deployment-type"),
+			SyncJSMOrganizations:         v.GetBool("sync-jsm-organizations"),
+			SkipFullSync:                 v.GetBool("skip-full-sync"),
+			SyncAllProjects:              v.GetBool("sync-all-projects"),
+			ProjectKeys:                  v.GetStringSlice("jira-project-keys"),
+			SyncConcurrency:              v.GetInt("jira-sync-concurrency"),
+			WarmUpBudget:                 time.Duration(v.GetInt("jira-warm-up-budget-seconds")) * time.Second,
+			SyncFilters:                  v.GetBool("sync-filters") || v.GetBool("sync-dashboards-filters"),
+			SyncDashboards:               v.GetBool("sync-dashboards-filters"),
+			SyncPermissionSchemes:        v.GetBool("sync-permission-schemes"),
+			SyncNotificationSchemes:      v.GetBool("sync-notification-schemes"),
+			SyncComponents:               v.GetBool("sync-components"),
+			SyncProjectRoles:             v.GetBool("sync-project-roles"),
+			SyncIssueSecurity:            v.GetBool("sync-issue-security"),
+			SyncIssueTypes:               v.GetBool("sync-issue-types"),
+			SyncUserProperties:           v.GetBool("sync-user-properties"),
+			ExplainParticipantGrants:     v.GetBool("explain-participant-grants"),
+			GroupSizeLogThreshold:        v.GetInt("group-size-log-threshold"),
+			TicketIncludeWatchers:        v.GetBool("ticket-include-watchers"),
+			TicketDropInvalidFields:      v.GetBool("ticket-drop-invalid-fields"),
+			ExcludedIssueTypes:           v.GetStringSlice("jira-excluded-issue-types"),
+			TicketConsolidateSchemas:     v.GetBool("ticket-consolidate-schemas"),
+			TicketSchemaCacheTTL:         time.Duration(v.GetInt("ticket-schema-cache-ttl-seconds")) * time.Second,
+			SendInvitationOnCreate:       v.GetBool("send-invitation-on-create"),
+			AtlassianOrgID:               v.GetString("atlassian-org-id"),
+			AtlassianAPIToken:            v.GetString("atlassian-api-token"),
+			ClaimStatusFilter:            v.GetStringSlice("claim-status-filter"),
+			SyncAtlassianRoles:           v.GetBool("sync-atlassian-roles"),
+			ExpandNestedGroups:           v.GetBool("expand-nested-groups"),
+			AnnotateManagedGroupGrants:   v.GetBool("annotate-managed-group-grants"),
+			ModelDefaultGroupsAsLicenses: v.GetBool("model-default-groups-as-licenses"),
+			Timeouts: connector.JiraTimeouts{
+				UserList:     time.Duration(v.GetInt("user-list-timeout-seconds")) * time.Second,
+				GroupList:    time.Duration(v.GetInt("group-list-timeout-seconds")) * time.Second,
+				TicketCreate: time.Duration(v.GetInt("ticket-create-timeout-seconds")) * time.Second,
+			},
+			RateLimit: client.RateLimitPolicy{
+				RequestsPerSecond: v.GetInt("jira-requests-per-second"),
+				EndpointWeights:   endpointWeights,
+				JitterMax:         time.Duration(v.GetInt("jira-request-jitter-max-ms")) * time.Millisecond,
+			},
+			Retry: client.RetryPolicy{
+				MaxRetries:     v.GetInt("max-retries"),
+				InitialBackoff: time.Duration(v.GetInt("retry-initial-backoff-seconds")) * time.Second,
+				MaxElapsed:     time.Duration(v.GetInt("retry-max-elapsed-seconds")) * time.Second,
+			},
 		},
 		Username: v.GetString("jira-email"),
 		ApiToken: v.GetString("jira-api-token"),
 	}
 
-	jiraConnector, err := builder.New()
+	jiraConnector, err := builder.New(ctx)
 	if err != nil {
 		l.Error("error creating connector", zap.Error(err))
 		return nil, err
 	}
 
+	// The connector context ends when the connector shuts down.
+	context.AfterFunc(ctx, func() {
+		jiraConnector.LogMetrics(context.WithoutCancel(ctx))
+		jiraConnector.LogPermissionGaps(context.WithoutCancel(ctx))
+	})
+
 	opts := make([]connectorbuilder.Opt, 0)
 	if v.GetBool(field.TicketingField.FieldName) {
 		opts = append(opts, connectorbuilder.WithTicketingEnabled())
@@ -130,6 +130,360 @@ func (x *JCIssueTypeProject) GetProjectKey() string {
 	return ""
 }
 
+type JiraIssueLink struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	TypeId    string `protobuf:"bytes,2,opt,name=type_id,json=typeId,proto3" json:"type_id,omitempty"`
+	TypeName  string `protobuf:"bytes,3,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`
+	Direction string `protobuf:"bytes,4,opt,name=direction,proto3" json:"direction,omitempty"`
+	IssueId   string `protobuf:"bytes,5,opt,name=issue_id,json=issueId,proto3" json:"issue_id,omitempty"`
+	IssueKey  string `protobuf:"bytes,6,opt,name=issue_key,json=issueKey,proto3" json:"issue_key,omitempty"`
+}
+
+func (x *JiraIssueLink) Reset() {
+	*x = JiraIssueLink{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JiraIssueLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JiraIssueLink) ProtoMessage() {}
+
+func (x *JiraIssueLink) ProtoReflect() protoreflect.Message {
+	mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JiraIssueLink.ProtoReflect.Descriptor instead.
+func (*JiraIssueLink) Descriptor() ([]byte, []int) {
+	return file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *JiraIssueLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JiraIssueLink) GetTypeId() string {
+	if x != nil {
+		return x.TypeId
+	}
+	return ""
+}
+
+func (x *JiraIssueLink) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *JiraIssueLink) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *JiraIssueLink) GetIssueId() string {
+	if x != nil {
+		return x.IssueId
+	}
+	return ""
+}
+
+func (x *JiraIssueLink) GetIssueKey() string {
+	if x != nil {
+		return x.IssueKey
+	}
+	return ""
+}
+
+type JiraIssueLinks struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Links []*JiraIssueLink `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+}
+
+func (x *JiraIssueLinks) Reset() {
+	*x = JiraIssueLinks{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JiraIssueLinks) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JiraIssueLinks) ProtoMessage() {}
+
+func (x *JiraIssueLinks) ProtoReflect() protoreflect.Message {
+	mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JiraIssueLinks.ProtoReflect.Descriptor instead.
+func (*JiraIssueLinks) Descriptor() ([]byte, []int) {
+	return file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *JiraIssueLinks) GetLinks() []*JiraIssueLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type JiraIssueWatchers struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	WatchCount int64    `protobuf:"varint,1,opt,name=watch_count,json=watchCount,proto3" json:"watch_count,omitempty"`
+	AccountIds []string `protobuf:"bytes,2,rep,name=account_ids,json=accountIds,proto3" json:"account_ids,omitempty"`
+}
+
+func (x *JiraIssueWatchers) Reset() {
+	*x = JiraIssueWatchers{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JiraIssueWatchers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JiraIssueWatchers) ProtoMessage() {}
+
+func (x *JiraIssueWatchers) ProtoReflect() protoreflect.Message {
+	mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JiraIssueWatchers.ProtoReflect.Descriptor instead.
+func (*JiraIssueWatchers) Descriptor() ([]byte, []int) {
+	return file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *JiraIssueWatchers) GetWatchCount() int64 {
+	if x != nil {
+		return x.WatchCount
+	}
+	return 0
+}
+
+func (x *JiraIssueWatchers) GetAccountIds() []string {
+	if x != nil {
+		return x.AccountIds
+	}
+	return nil
+}
+
+type JiraDroppedFields struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Fields []string `protobuf:"bytes,1,rep,name=fields,proto3" json:"fields,omitempty"`
+}
+
+func (x *JiraDroppedFields) Reset() {
+	*x = JiraDroppedFields{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JiraDroppedFields) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JiraDroppedFields) ProtoMessage() {}
+
+func (x *JiraDroppedFields) ProtoReflect() protoreflect.Message {
+	mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JiraDroppedFields.ProtoReflect.Descriptor instead.
+func (*JiraDroppedFields) Descriptor() ([]byte, []int) {
+	return file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JiraDroppedFields) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+type JiraConsolidatedSchema struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	IssueTypeId string                `protobuf:"bytes,1,opt,name=issue_type_id,json=issueTypeId,proto3" json:"issue_type_id,omitempty"`
+	Projects    []*JCIssueTypeProject `protobuf:"bytes,2,rep,name=projects,proto3" json:"projects,omitempty"`
+}
+
+func (x *JiraConsolidatedSchema) Reset() {
+	*x = JiraConsolidatedSchema{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JiraConsolidatedSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JiraConsolidatedSchema) ProtoMessage() {}
+
+func (x *JiraConsolidatedSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JiraConsolidatedSchema.ProtoReflect.Descriptor instead.
+func (*JiraConsolidatedSchema) Descriptor() ([]byte, []int) {
+	return file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *JiraConsolidatedSchema) GetIssueTypeId() string {
+	if x != nil {
+		return x.IssueTypeId
+	}
+	return ""
+}
+
+func (x *JiraConsolidatedSchema) GetProjects() []*JCIssueTypeProject {
+	if x != nil {
+		return x.Projects
+	}
+	return nil
+}
+
+type JiraActiveSprint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SprintId   int64  `protobuf:"varint,1,opt,name=sprint_id,json=sprintId,proto3" json:"sprint_id,omitempty"`
+	SprintName string `protobuf:"bytes,2,opt,name=sprint_name,json=sprintName,proto3" json:"sprint_name,omitempty"`
+	BoardId    int64  `protobuf:"varint,3,opt,name=board_id,json=boardId,proto3" json:"board_id,omitempty"`
+}
+
+func (x *JiraActiveSprint) Reset() {
+	*x = JiraActiveSprint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JiraActiveSprint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JiraActiveSprint) ProtoMessage() {}
+
+func (x *JiraActiveSprint) ProtoReflect() protoreflect.Message {
+	mi := &file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JiraActiveSprint.ProtoReflect.Descriptor instead.
+func (*JiraActiveSprint) Descriptor() ([]byte, []int) {
+	return file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JiraActiveSprint) GetSprintId() int64 {
+	if x != nil {
+		return x.SprintId
+	}
+	return 0
+}
+
+func (x *JiraActiveSprint) GetSprintName() string {
+	if x != nil {
+		return x.SprintName
+	}
+	return ""
+}
+
+func (x *JiraActiveSprint) GetBoardId() int64 {
+	if x != nil {
+		return x.BoardId
+	}
+	return 0
+}
+
 var File_c1_connector_v2_jira_cloud_external_ticket_proto protoreflect.FileDescriptor
 
 var file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDesc = []byte{
@@ -146,11 +500,50 @@ var file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDesc = []byte{
 	0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
 	0x09, 0x52, 0x0b, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1f,
 	0x0a, 0x0b, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x42,
-	0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f,
-	0x6e, 0x64, 0x75, 0x63, 0x74, 0x6f, 0x72, 0x6f, 0x6e, 0x65, 0x2f, 0x62, 0x61, 0x74, 0x6f, 0x6e,
-	0x2d, 0x6a, 0x69, 0x72, 0x61, 0x2f, 0x70, 0x62, 0x2f, 0x63, 0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x6e,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x32, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x4b, 0x65, 0x79, 0x22,
+	0xab, 0x01, 0x0a, 0x0d, 0x4a, 0x69, 0x72, 0x61, 0x49, 0x73, 0x73, 0x75, 0x65, 0x4c, 0x69, 0x6e,
+	0x6b, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x17, 0x0a, 0x07, 0x74, 0x79, 0x70, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x74, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x74, 0x79,
+	0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x74,
+	0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72, 0x65, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69, 0x72, 0x65,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x19, 0x0a, 0x08, 0x69, 0x73, 0x73, 0x75, 0x65, 0x5f, 0x69,
+	0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69, 0x73, 0x73, 0x75, 0x65, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x69, 0x73, 0x73, 0x75, 0x65, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x73, 0x73, 0x75, 0x65, 0x4b, 0x65, 0x79, 0x22, 0x46, 0x0a,
+	0x0e, 0x4a, 0x69, 0x72, 0x61, 0x49, 0x73, 0x73, 0x75, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x73, 0x12,
+	0x34, 0x0a, 0x05, 0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x63, 0x31, 0x2e, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x32,
+	0x2e, 0x4a, 0x69, 0x72, 0x61, 0x49, 0x73, 0x73, 0x75, 0x65, 0x4c, 0x69, 0x6e, 0x6b, 0x52, 0x05,
+	0x6c, 0x69, 0x6e, 0x6b, 0x73, 0x22, 0x55, 0x0a, 0x11, 0x4a, 0x69, 0x72, 0x61, 0x49, 0x73, 0x73,
+	0x75, 0x65, 0x57, 0x61, 0x74, 0x63, 0x68, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x61,
+	0x74, 0x63, 0x68, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0a, 0x77, 0x61, 0x74, 0x63, 0x68, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0a, 0x61, 0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x49, 0x64, 0x73, 0x22, 0x2b, 0x0a, 0x11,
+	0x4a, 0x69, 0x72, 0x61, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x7d, 0x0a, 0x16, 0x4a, 0x69, 0x72,
+	0x61, 0x43, 0x6f, 0x6e, 0x73, 0x6f, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x64, 0x53, 0x63, 0x68,
+	0x65, 0x6d, 0x61, 0x12, 0x22, 0x0a, 0x0d, 0x69, 0x73, 0x73, 0x75, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x69, 0x73, 0x73, 0x75,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x49, 0x64, 0x12, 0x3f, 0x0a, 0x08, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x63, 0x31, 0x2e, 0x63,
+	0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x76, 0x32, 0x2e, 0x4a, 0x43, 0x49, 0x73,
+	0x73, 0x75, 0x65, 0x54, 0x79, 0x70, 0x65, 0x50, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x52, 0x08,
+	0x70, 0x72, 0x6f, 0x6a, 0x65, 0x63, 0x74, 0x73, 0x22, 0x6b, 0x0a, 0x10, 0x4a, 0x69, 0x72, 0x61,
+	0x41, 0x63, 0x74, 0x69, 0x76, 0x65, 0x53, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09,
+	0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x08, 0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x70, 0x72,
+	0x69, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x73, 0x70, 0x72, 0x69, 0x6e, 0x74, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x62, 0x6f,
+	0x61, 0x72, 0x64, 0x49, 0x64, 0x42, 0x37, 0x5a, 0x35, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x6f, 0x6e, 0x64, 0x75, 0x63, 0x74, 0x6f, 0x72, 0x6f, 0x6e, 0x65,
+	0x2f, 0x62, 0x61, 0x74, 0x6f, 0x6e, 0x2d, 0x6a, 0x69, 0x72, 0x61, 0x2f, 0x70, 0x62, 0x2f, 0x63,
+	0x31, 0x2f, 0x63, 0x6f, 0x6e, 0x6e, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x76, 0x32, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -165,17 +558,25 @@ func file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescGZIP() []byte
 	return file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDescData
 }
 
-var file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
 var file_c1_connector_v2_jira_cloud_external_ticket_proto_goTypes = []interface{}{
-	(*CustomField)(nil),        // 0: c1.connector.v2.CustomField
-	(*JCIssueTypeProject)(nil), // 1: c1.connector.v2.JCIssueTypeProject
+	(*CustomField)(nil),            // 0: c1.connector.v2.CustomField
+	(*JCIssueTypeProject)(nil),     // 1: c1.connector.v2.JCIssueTypeProject
+	(*JiraIssueLink)(nil),          // 2: c1.connector.v2.JiraIssueLink
+	(*JiraIssueLinks)(nil),         // 3: c1.connector.v2.JiraIssueLinks
+	(*JiraIssueWatchers)(nil),      // 4: c1.connector.v2.JiraIssueWatchers
+	(*JiraDroppedFields)(nil),      // 5: c1.connector.v2.JiraDroppedFields
+	(*JiraConsolidatedSchema)(nil), // 6: c1.connector.v2.JiraConsolidatedSchema
+	(*JiraActiveSprint)(nil),       // 7: c1.connector.v2.JiraActiveSprint
 }
 var file_c1_connector_v2_jira_cloud_external_ticket_proto_depIdxs = []int32{
-	0, // [0:0] is the sub-list for method output_type
-	0, // [0:0] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	2, // 0: c1.connector.v2.JiraIssueLinks.links:type_name -> c1.connector.v2.JiraIssueLink
+	1, // 1: c1.connector.v2.JiraConsolidatedSchema.projects:type_name -> c1.connector.v2.JCIssueTypeProject
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
 }
 
 func init() { file_c1_connector_v2_jira_cloud_external_ticket_proto_init() }
@@ -208,6 +609,78 @@ func file_c1_connector_v2_jira_cloud_external_ticket_proto_init() {
 				return nil
 			}
 		}
+		file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JiraIssueLink); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JiraIssueLinks); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JiraIssueWatchers); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JiraDroppedFields); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JiraConsolidatedSchema); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_c1_connector_v2_jira_cloud_external_ticket_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*JiraActiveSprint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -215,7 +688,7 @@ func file_c1_connector_v2_jira_cloud_external_ticket_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_c1_connector_v2_jira_cloud_external_ticket_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   8,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
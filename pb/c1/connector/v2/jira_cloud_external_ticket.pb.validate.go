@@ -243,3 +243,694 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = JCIssueTypeProjectValidationError{}
+
+// Validate checks the field values on JiraIssueLink with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *JiraIssueLink) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JiraIssueLink with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in JiraIssueLinkMultiError, or
+// nil if none found.
+func (m *JiraIssueLink) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JiraIssueLink) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Id
+
+	// no validation rules for TypeId
+
+	// no validation rules for TypeName
+
+	// no validation rules for Direction
+
+	// no validation rules for IssueId
+
+	// no validation rules for IssueKey
+
+	if len(errors) > 0 {
+		return JiraIssueLinkMultiError(errors)
+	}
+
+	return nil
+}
+
+// JiraIssueLinkMultiError is an error wrapping multiple validation errors
+// returned by JiraIssueLink.ValidateAll() if the designated constraints aren't met.
+type JiraIssueLinkMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JiraIssueLinkMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JiraIssueLinkMultiError) AllErrors() []error { return m }
+
+// JiraIssueLinkValidationError is the validation error returned by
+// JiraIssueLink.Validate if the designated constraints aren't met.
+type JiraIssueLinkValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JiraIssueLinkValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JiraIssueLinkValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JiraIssueLinkValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JiraIssueLinkValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JiraIssueLinkValidationError) ErrorName() string { return "JiraIssueLinkValidationError" }
+
+// Error satisfies the builtin error interface
+func (e JiraIssueLinkValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJiraIssueLink.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JiraIssueLinkValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JiraIssueLinkValidationError{}
+
+// Validate checks the field values on JiraIssueLinks with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *JiraIssueLinks) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JiraIssueLinks with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in JiraIssueLinksMultiError,
+// or nil if none found.
+func (m *JiraIssueLinks) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JiraIssueLinks) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetLinks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, JiraIssueLinksValidationError{
+						field:  fmt.Sprintf("Links[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, JiraIssueLinksValidationError{
+						field:  fmt.Sprintf("Links[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return JiraIssueLinksValidationError{
+					field:  fmt.Sprintf("Links[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return JiraIssueLinksMultiError(errors)
+	}
+
+	return nil
+}
+
+// JiraIssueLinksMultiError is an error wrapping multiple validation errors
+// returned by JiraIssueLinks.ValidateAll() if the designated constraints aren't met.
+type JiraIssueLinksMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JiraIssueLinksMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JiraIssueLinksMultiError) AllErrors() []error { return m }
+
+// JiraIssueLinksValidationError is the validation error returned by
+// JiraIssueLinks.Validate if the designated constraints aren't met.
+type JiraIssueLinksValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JiraIssueLinksValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JiraIssueLinksValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JiraIssueLinksValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JiraIssueLinksValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JiraIssueLinksValidationError) ErrorName() string { return "JiraIssueLinksValidationError" }
+
+// Error satisfies the builtin error interface
+func (e JiraIssueLinksValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJiraIssueLinks.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JiraIssueLinksValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JiraIssueLinksValidationError{}
+
+// Validate checks the field values on JiraIssueWatchers with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *JiraIssueWatchers) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JiraIssueWatchers with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// JiraIssueWatchersMultiError, or nil if none found.
+func (m *JiraIssueWatchers) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JiraIssueWatchers) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for WatchCount
+
+	if len(errors) > 0 {
+		return JiraIssueWatchersMultiError(errors)
+	}
+
+	return nil
+}
+
+// JiraIssueWatchersMultiError is an error wrapping multiple validation errors
+// returned by JiraIssueWatchers.ValidateAll() if the designated constraints aren't met.
+type JiraIssueWatchersMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JiraIssueWatchersMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JiraIssueWatchersMultiError) AllErrors() []error { return m }
+
+// JiraIssueWatchersValidationError is the validation error returned by
+// JiraIssueWatchers.Validate if the designated constraints aren't met.
+type JiraIssueWatchersValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JiraIssueWatchersValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JiraIssueWatchersValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JiraIssueWatchersValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JiraIssueWatchersValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JiraIssueWatchersValidationError) ErrorName() string {
+	return "JiraIssueWatchersValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e JiraIssueWatchersValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJiraIssueWatchers.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JiraIssueWatchersValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JiraIssueWatchersValidationError{}
+
+// Validate checks the field values on JiraDroppedFields with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *JiraDroppedFields) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JiraDroppedFields with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// JiraDroppedFieldsMultiError, or nil if none found.
+func (m *JiraDroppedFields) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JiraDroppedFields) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if len(errors) > 0 {
+		return JiraDroppedFieldsMultiError(errors)
+	}
+
+	return nil
+}
+
+// JiraDroppedFieldsMultiError is an error wrapping multiple validation errors
+// returned by JiraDroppedFields.ValidateAll() if the designated constraints aren't met.
+type JiraDroppedFieldsMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JiraDroppedFieldsMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JiraDroppedFieldsMultiError) AllErrors() []error { return m }
+
+// JiraDroppedFieldsValidationError is the validation error returned by
+// JiraDroppedFields.Validate if the designated constraints aren't met.
+type JiraDroppedFieldsValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JiraDroppedFieldsValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JiraDroppedFieldsValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JiraDroppedFieldsValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JiraDroppedFieldsValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JiraDroppedFieldsValidationError) ErrorName() string {
+	return "JiraDroppedFieldsValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e JiraDroppedFieldsValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJiraDroppedFields.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JiraDroppedFieldsValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JiraDroppedFieldsValidationError{}
+
+// Validate checks the field values on JiraConsolidatedSchema with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *JiraConsolidatedSchema) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JiraConsolidatedSchema with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// JiraConsolidatedSchemaMultiError, or nil if none found.
+func (m *JiraConsolidatedSchema) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JiraConsolidatedSchema) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for IssueTypeId
+
+	for idx, item := range m.GetProjects() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, JiraConsolidatedSchemaValidationError{
+						field:  fmt.Sprintf("Projects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, JiraConsolidatedSchemaValidationError{
+						field:  fmt.Sprintf("Projects[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return JiraConsolidatedSchemaValidationError{
+					field:  fmt.Sprintf("Projects[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return JiraConsolidatedSchemaMultiError(errors)
+	}
+
+	return nil
+}
+
+// JiraConsolidatedSchemaMultiError is an error wrapping multiple validation
+// errors returned by JiraConsolidatedSchema.ValidateAll() if the designated
+// constraints aren't met.
+type JiraConsolidatedSchemaMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JiraConsolidatedSchemaMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JiraConsolidatedSchemaMultiError) AllErrors() []error { return m }
+
+// JiraConsolidatedSchemaValidationError is the validation error returned by
+// JiraConsolidatedSchema.Validate if the designated constraints aren't met.
+type JiraConsolidatedSchemaValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JiraConsolidatedSchemaValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JiraConsolidatedSchemaValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JiraConsolidatedSchemaValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JiraConsolidatedSchemaValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JiraConsolidatedSchemaValidationError) ErrorName() string {
+	return "JiraConsolidatedSchemaValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e JiraConsolidatedSchemaValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJiraConsolidatedSchema.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JiraConsolidatedSchemaValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JiraConsolidatedSchemaValidationError{}
+
+// Validate checks the field values on JiraActiveSprint with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *JiraActiveSprint) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on JiraActiveSprint with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// JiraActiveSprintMultiError, or nil if none found.
+func (m *JiraActiveSprint) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *JiraActiveSprint) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for SprintId
+
+	// no validation rules for SprintName
+
+	// no validation rules for BoardId
+
+	if len(errors) > 0 {
+		return JiraActiveSprintMultiError(errors)
+	}
+
+	return nil
+}
+
+// JiraActiveSprintMultiError is an error wrapping multiple validation errors
+// returned by JiraActiveSprint.ValidateAll() if the designated constraints aren't met.
+type JiraActiveSprintMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m JiraActiveSprintMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m JiraActiveSprintMultiError) AllErrors() []error { return m }
+
+// JiraActiveSprintValidationError is the validation error returned by
+// JiraActiveSprint.Validate if the designated constraints aren't met.
+type JiraActiveSprintValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e JiraActiveSprintValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e JiraActiveSprintValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e JiraActiveSprintValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e JiraActiveSprintValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e JiraActiveSprintValidationError) ErrorName() string { return "JiraActiveSprintValidationError" }
+
+// Error satisfies the builtin error interface
+func (e JiraActiveSprintValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sJiraActiveSprint.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = JiraActiveSprintValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = JiraActiveSprintValidationError{}
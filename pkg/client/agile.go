@@ -0,0 +1,84 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// BoardTypeScrum is the type of the Jira Software boards that have sprints.
+const BoardTypeScrum = "scrum"
+
+// Board is a Jira Software board.
+type Board struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+// Sprint is a sprint of a scrum board.
+type Sprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	OriginBoardID int    `json:"originBoardId"`
+}
+
+type boardsResponse struct {
+	pageInfo
+	Values []Board `json:"values"`
+}
+
+type sprintsResponse struct {
+	pageInfo
+	Values []Sprint `json:"values"`
+}
+
+// ListProjectScrumBoards returns a page of the scrum boards of a project, and whether it
+// is the last page. Kanban boards have no sprints, so they are left out.
+func (c *Client) ListProjectScrumBoards(ctx context.Context, projectKeyOrID string, startAt int, maxResults int) ([]Board, bool, error) {
+	query := url.Values{}
+	query.Set("projectKeyOrId", projectKeyOrID)
+	query.Set("type", BoardTypeScrum)
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/agile/1.0/board?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res boardsResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Values, res.lastPage(len(res.Values)), nil
+}
+
+// ListActiveSprints returns a page of the active sprints of a scrum board, and whether it
+// is the last page.
+func (c *Client) ListActiveSprints(ctx context.Context, boardID int, startAt int, maxResults int) ([]Sprint, bool, error) {
+	query := url.Values{}
+	query.Set("state", "active")
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, fmt.Sprintf("rest/agile/1.0/board/%d/sprint?%s", boardID, query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res sprintsResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Values, res.lastPage(len(res.Values)), nil
+}
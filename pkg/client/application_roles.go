@@ -0,0 +1,52 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// ApplicationRole is a Jira product, e.g. Jira Software, and the groups giving access to it.
+type ApplicationRole struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	UserCount int    `json:"userCount"`
+	// DefaultGroups are the names of the groups new users of the product are added to.
+	DefaultGroups        []string `json:"defaultGroups"`
+	DefaultGroupsDetails []struct {
+		Name    string `json:"name"`
+		GroupID string `json:"groupId"`
+	} `json:"defaultGroupsDetails"`
+}
+
+// DefaultGroupIDs returns the IDs of the default groups of the role, matching the group
+// resource IDs: group IDs on Cloud, and names on Data Center, which has no group IDs.
+func (r *ApplicationRole) DefaultGroupIDs() []string {
+	if len(r.DefaultGroupsDetails) == 0 {
+		return r.DefaultGroups
+	}
+
+	rv := make([]string, 0, len(r.DefaultGroupsDetails))
+	for _, group := range r.DefaultGroupsDetails {
+		rv = append(rv, group.GroupID)
+	}
+
+	return rv
+}
+
+// ListApplicationRoles returns every application role of the site. The endpoint is not paginated.
+func (c *Client) ListApplicationRoles(ctx context.Context) ([]ApplicationRole, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("applicationrole"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var roles []ApplicationRole
+	resp, err := c.jira.Do(req, &roles)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return roles, nil
+}
@@ -0,0 +1,412 @@
+package atlassianclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultBaseURL = "https://api.atlassian.com"
+	maxRedirects   = 10
+)
+
+var ErrSiteNotFound = errors.New("site id not found")
+
+// AtlassianClient talks to the Atlassian organization admin API, which knows about
+// the org directory behind a Jira Cloud site: SCIM synced groups, claimed accounts and so on.
+// It authenticates with an org API key, separate from the Jira API token.
+type AtlassianClient struct {
+	httpClient *uhttp.BaseHttpClient
+	orgID      string
+	apiToken   string
+
+	mu sync.Mutex
+	// baseURL starts as api.atlassian.com, and becomes the regional host the org is
+	// redirected to, if any.
+	baseURL string
+}
+
+// New returns a client of the admin API of the org, counting its requests in metrics,
+// which may be nil.
+func New(ctx context.Context, orgID string, apiToken string, metrics *client.Metrics) (*AtlassianClient, error) {
+	httpClient, err := uhttp.NewClient(ctx, uhttp.WithLogger(true, ctxzap.Extract(ctx)))
+	if err != nil {
+		return nil, err
+	}
+	httpClient.CheckRedirect = sameHostRedirects
+
+	wrapper, err := uhttp.NewBaseHttpClientWithContext(ctx, client.NewErrorBodyLimitClient(client.NewMetricsClient(httpClient, metrics)))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AtlassianClient{
+		httpClient: wrapper,
+		orgID:      orgID,
+		apiToken:   apiToken,
+		baseURL:    defaultBaseURL,
+	}, nil
+}
+
+// GetSiteID returns the ID of the org workspace hosted at siteUrl, e.g. https://your-domain.atlassian.net.
+func (c *AtlassianClient) GetSiteID(ctx context.Context, siteUrl string) (string, error) {
+	return c.getSiteID(ctx, siteUrl)
+}
+
+func (c *AtlassianClient) getSiteID(ctx context.Context, siteUrl string) (string, error) {
+	cursor := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing workspaces"); err != nil {
+			return "", err
+		}
+
+		query := url.Values{}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var res WorkspacesResponse
+		err := c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/admin/v2/orgs/%s/workspaces", url.PathEscape(c.orgID)), query, struct{}{}, &res)
+		if err != nil {
+			return "", err
+		}
+
+		for _, workspace := range res.Data {
+			if sameSiteURL(workspace.Attributes.HostUrl, siteUrl) {
+				return workspace.ID, nil
+			}
+		}
+
+		if res.Links.Next == "" {
+			return "", ErrSiteNotFound
+		}
+		cursor = res.Links.Next
+	}
+}
+
+// sameSiteURL compares site URLs regardless of case and trailing slashes, since the
+// configured URL is typed in by hand.
+func sameSiteURL(a, b string) bool {
+	return strings.EqualFold(strings.TrimRight(a, "/"), strings.TrimRight(b, "/"))
+}
+
+// ListGroups returns one page of the groups across every directory of the org,
+// and the cursor of the next page, which is empty on the last page.
+func (c *AtlassianClient) ListGroups(ctx context.Context, cursor string) ([]Group, string, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var res GroupsResponse
+	err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/admin/v2/orgs/%s/directories/-/groups", url.PathEscape(c.orgID)), query, nil, &res)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res.Data, res.Links.Next, nil
+}
+
+// ListDirectories returns one page of the directories of the org, and the cursor of the
+// next page, which is empty on the last page.
+func (c *AtlassianClient) ListDirectories(ctx context.Context, cursor string) ([]Directory, string, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var res DirectoriesResponse
+	err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/admin/v2/orgs/%s/directories", url.PathEscape(c.orgID)), query, nil, &res)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res.Data, res.Links.Next, nil
+}
+
+// ListGroupMemberships returns one page of the direct members of a group of a directory,
+// nested groups included, and the cursor of the next page, which is empty on the last page.
+func (c *AtlassianClient) ListGroupMemberships(ctx context.Context, directoryID string, groupID string, cursor string) ([]GroupMembership, string, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var res GroupMembershipsResponse
+	err := c.doRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("/admin/v2/orgs/%s/directories/%s/groups/%s/memberships", url.PathEscape(c.orgID), url.PathEscape(directoryID), url.PathEscape(groupID)),
+		query,
+		nil,
+		&res,
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res.Data, res.Links.Next, nil
+}
+
+// ListRoleAssignments returns one page of the role assignments of the org, and the cursor
+// of the next page, which is empty on the last page. With siteID set, only the assignments
+// on the org and on that site are kept, so the page can be shorter than Atlassian's.
+func (c *AtlassianClient) ListRoleAssignments(ctx context.Context, siteID string, pageToken string) ([]RoleAssignment, string, error) {
+	query := url.Values{}
+	if pageToken != "" {
+		query.Set("cursor", pageToken)
+	}
+
+	var res RoleAssignmentsResponse
+	err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/admin/v2/orgs/%s/role-assignments", url.PathEscape(c.orgID)), query, nil, &res)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if siteID == "" {
+		return res.Data, res.Links.Next, nil
+	}
+
+	rv := make([]RoleAssignment, 0, len(res.Data))
+	for _, assignment := range res.Data {
+		if assignment.AppliesTo(siteID) {
+			rv = append(rv, assignment)
+		}
+	}
+
+	return rv, res.Links.Next, nil
+}
+
+// GetUserLastActive returns when a user was added to the org and last active in each product.
+func (c *AtlassianClient) GetUserLastActive(ctx context.Context, accountID string) (*UserLastActive, error) {
+	rv := &UserLastActive{}
+	cursor := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing last active dates"); err != nil {
+			return nil, err
+		}
+
+		query := url.Values{}
+		if cursor != "" {
+			query.Set("cursor", cursor)
+		}
+
+		var res UserLastActiveResponse
+		err := c.doRequest(
+			ctx,
+			http.MethodGet,
+			fmt.Sprintf("/admin/v1/orgs/%s/directory/users/%s/last-active-dates", url.PathEscape(c.orgID), url.PathEscape(accountID)),
+			query,
+			nil,
+			&res,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rv.AddedToOrg = res.Data.AddedToOrg
+		rv.ProductAccess = append(rv.ProductAccess, res.Data.ProductAccess...)
+
+		if res.Links.Next == "" {
+			return rv, nil
+		}
+		cursor = res.Links.Next
+	}
+}
+
+// ListUsers returns one page of the users across every directory of the org,
+// and the cursor of the next page, which is empty on the last page.
+func (c *AtlassianClient) ListUsers(ctx context.Context, cursor string) ([]User, string, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var res UsersResponse
+	err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/admin/v2/orgs/%s/directories/-/users", url.PathEscape(c.orgID)), query, nil, &res)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res.Data, res.Links.Next, nil
+}
+
+// ListDomains returns one page of the domains of the org, and the cursor of the next page,
+// which is empty on the last page.
+func (c *AtlassianClient) ListDomains(ctx context.Context, cursor string) ([]Domain, string, error) {
+	query := url.Values{}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	var res DomainsResponse
+	err := c.doRequest(ctx, http.MethodGet, fmt.Sprintf("/admin/v1/orgs/%s/domains", url.PathEscape(c.orgID)), query, nil, &res)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return res.Data, res.Links.Next, nil
+}
+
+// GetUser returns an org directory user.
+func (c *AtlassianClient) GetUser(ctx context.Context, accountID string) (*User, error) {
+	var res User
+	err := c.doRequest(
+		ctx,
+		http.MethodGet,
+		fmt.Sprintf("/admin/v2/orgs/%s/directories/-/users/%s", url.PathEscape(c.orgID), url.PathEscape(accountID)),
+		nil,
+		nil,
+		&res,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DisableUser suspends a managed account, signing it out of every Atlassian product.
+func (c *AtlassianClient) DisableUser(ctx context.Context, accountID string, message string) error {
+	body := struct {
+		Message string `json:"message,omitempty"`
+	}{
+		Message: message,
+	}
+
+	return c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/users/%s/manage/lifecycle/disable", url.PathEscape(accountID)), nil, body, nil)
+}
+
+// EnableUser restores a suspended managed account.
+func (c *AtlassianClient) EnableUser(ctx context.Context, accountID string) error {
+	return c.doRequest(ctx, http.MethodPost, fmt.Sprintf("/users/%s/manage/lifecycle/enable", url.PathEscape(accountID)), nil, struct{}{}, nil)
+}
+
+func (c *AtlassianClient) doRequest(ctx context.Context, method string, path string, query url.Values, body interface{}, res interface{}) error {
+	resp, err := c.doRequestAt(ctx, c.getBaseURL(), method, path, query, body, res)
+	if err == nil {
+		return nil
+	}
+
+	regionalURL, ok := regionalRedirect(resp)
+	if !ok {
+		return err
+	}
+
+	ctxzap.Extract(ctx).Info(
+		"atlassian admin api redirected to a regional host",
+		zap.String("org_id", c.orgID),
+		zap.String("base_url", regionalURL),
+	)
+	c.setBaseURL(regionalURL)
+
+	_, err = c.doRequestAt(ctx, regionalURL, method, path, query, body, res)
+	return err
+}
+
+func (c *AtlassianClient) doRequestAt(
+	ctx context.Context,
+	base string,
+	method string,
+	path string,
+	query url.Values,
+	body interface{},
+	res interface{},
+) (*http.Response, error) {
+	u, err := url.Parse(base + path)
+	if err != nil {
+		return nil, err
+	}
+	u.RawQuery = query.Encode()
+
+	options := []uhttp.RequestOption{
+		uhttp.WithAcceptJSONHeader(),
+		uhttp.WithHeader("Authorization", "Bearer "+c.apiToken),
+	}
+	if body != nil {
+		options = append(options, uhttp.WithJSONBody(body))
+	}
+
+	req, err := c.httpClient.NewRequest(ctx, method, u, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	var doOptions []uhttp.DoOption
+	if res != nil {
+		doOptions = append(doOptions, uhttp.WithJSONResponse(res))
+	}
+
+	resp, err := c.httpClient.Do(req, doOptions...)
+	if resp != nil {
+		defer resp.Body.Close()
+	}
+	if err != nil {
+		return resp, fmt.Errorf("atlassian admin api %s %s: %w", method, path, err)
+	}
+
+	return resp, nil
+}
+
+func (c *AtlassianClient) getBaseURL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.baseURL
+}
+
+func (c *AtlassianClient) setBaseURL(base string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.baseURL = base
+}
+
+// regionalRedirect returns the admin API base URL a 308 or 451 response sends the org
+// to, when it is pinned to a data residency region. Only https hosts under atlassian.com
+// are followed, since the API key goes along.
+func regionalRedirect(resp *http.Response) (string, bool) {
+	if resp == nil || (resp.StatusCode != http.StatusPermanentRedirect && resp.StatusCode != http.StatusUnavailableForLegalReasons) {
+		return "", false
+	}
+
+	if resp.Request == nil {
+		return "", false
+	}
+
+	location, err := resp.Request.URL.Parse(resp.Header.Get("Location"))
+	if err != nil || location.Scheme != "https" || !atlassianHost(location.Hostname()) {
+		return "", false
+	}
+
+	return location.Scheme + "://" + location.Host, true
+}
+
+func atlassianHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "atlassian.com" || strings.HasSuffix(host, ".atlassian.com")
+}
+
+// sameHostRedirects follows redirects on the same host only. Go drops the Authorization
+// header on redirects to another host, so those are handed back to doRequest instead.
+func sameHostRedirects(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %d redirects", maxRedirects)
+	}
+
+	if req.URL.Host != via[0].URL.Host {
+		return http.ErrUseLastResponse
+	}
+
+	return nil
+}
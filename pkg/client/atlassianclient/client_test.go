@@ -0,0 +1,345 @@
+package atlassianclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// newTestAtlassianClient returns a client of org-1 whose requests are answered by
+// transport, following redirects the way New does.
+func newTestAtlassianClient(t *testing.T, transport http.RoundTripper) *AtlassianClient {
+	t.Helper()
+
+	httpClient, err := uhttp.NewBaseHttpClientWithContext(context.Background(), &http.Client{
+		Transport:     transport,
+		CheckRedirect: sameHostRedirects,
+	})
+	if err != nil {
+		t.Fatalf("creating http client: %v", err)
+	}
+
+	return &AtlassianClient{
+		httpClient: httpClient,
+		orgID:      "org-1",
+		apiToken:   "token",
+		baseURL:    defaultBaseURL,
+	}
+}
+
+func TestGetSiteID(t *testing.T) {
+	tests := []struct {
+		name    string
+		siteURL string
+		wantID  string
+		wantErr error
+	}{
+		{name: "exact match", siteURL: "https://myorg.atlassian.net", wantID: "site-2"},
+		{name: "trailing slash", siteURL: "https://myorg.atlassian.net/", wantID: "site-2"},
+		{name: "trailing slashes", siteURL: "https://myorg.atlassian.net//", wantID: "site-2"},
+		{name: "mixed case", siteURL: "https://MyOrg.Atlassian.net", wantID: "site-2"},
+		{name: "mixed case and trailing slash", siteURL: "HTTPS://MYORG.ATLASSIAN.NET/", wantID: "site-2"},
+		{name: "on a later page", siteURL: "https://other.atlassian.net", wantID: "site-3"},
+		{name: "prefix of a site", siteURL: "https://myorg.atlassian", wantErr: ErrSiteNotFound},
+		{name: "unknown site", siteURL: "https://unknown.atlassian.net", wantErr: ErrSiteNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The workspaces span two pages, and the host URL of site-2 ends in a slash.
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/admin/v2/orgs/org-1/workspaces" {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				if r.URL.Query().Get("cursor") == "" {
+					_, _ = w.Write([]byte(`{"data":[` +
+						`{"id":"site-1","attributes":{"hostUrl":"https://myorg-sandbox.atlassian.net"}},` +
+						`{"id":"site-2","attributes":{"hostUrl":"https://myorg.atlassian.net/"}}` +
+						`],"links":{"next":"page-2"}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":[{"id":"site-3","attributes":{"hostUrl":"https://Other.atlassian.net"}}],"links":{}}`))
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := New(context.Background(), "org-1", "token", nil)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			c.baseURL = server.URL
+
+			id, err := c.GetSiteID(context.Background(), tt.siteURL)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetSiteID(%q) error = %v, want %v", tt.siteURL, err, tt.wantErr)
+			}
+			if id != tt.wantID {
+				t.Errorf("GetSiteID(%q) = %q, want %q", tt.siteURL, id, tt.wantID)
+			}
+		})
+	}
+}
+
+// TestRegionalRedirect has api.atlassian.com redirect the org to location, which serves
+// its groups, and checks where the requests of two pages of groups went.
+func TestRegionalRedirect(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		location   string
+		wantErr    bool
+		// wantHosts are the hosts requested, in order.
+		wantHosts []string
+	}{
+		{
+			name:       "permanent redirect",
+			statusCode: http.StatusPermanentRedirect,
+			location:   "https://eu.api.atlassian.com/admin/v2/orgs/org-1/directories/-/groups",
+			wantHosts:  []string{"api.atlassian.com", "eu.api.atlassian.com", "eu.api.atlassian.com"},
+		},
+		{
+			name:       "unavailable for legal reasons",
+			statusCode: http.StatusUnavailableForLegalReasons,
+			location:   "https://eu.api.atlassian.com/admin/v2/orgs/org-1/directories/-/groups",
+			wantHosts:  []string{"api.atlassian.com", "eu.api.atlassian.com", "eu.api.atlassian.com"},
+		},
+		{
+			name:       "host outside atlassian.com",
+			statusCode: http.StatusPermanentRedirect,
+			location:   "https://eu.api.atlassian.com.example.com/admin/v2/orgs/org-1/directories/-/groups",
+			wantErr:    true,
+			wantHosts:  []string{"api.atlassian.com", "api.atlassian.com"},
+		},
+		{
+			name:       "plain http",
+			statusCode: http.StatusPermanentRedirect,
+			location:   "http://eu.api.atlassian.com/admin/v2/orgs/org-1/directories/-/groups",
+			wantErr:    true,
+			wantHosts:  []string{"api.atlassian.com", "api.atlassian.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var hosts []string
+			c := newTestAtlassianClient(t, roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				hosts = append(hosts, req.URL.Host)
+				mu.Unlock()
+
+				if got := req.Header.Get("Authorization"); got != "Bearer token" {
+					t.Errorf("request to %s has authorization %q", req.URL.Host, got)
+				}
+
+				resp := &http.Response{Header: http.Header{}, Request: req, Body: io.NopCloser(strings.NewReader(""))}
+				if req.URL.Host == "api.atlassian.com" {
+					resp.StatusCode = tt.statusCode
+					resp.Header.Set("Location", tt.location)
+					return resp, nil
+				}
+
+				resp.StatusCode = http.StatusOK
+				resp.Header.Set("Content-Type", "application/json")
+				resp.Body = io.NopCloser(strings.NewReader(`{"data":[{"id":"g-1","name":"devs"}],"links":{}}`))
+				return resp, nil
+			}))
+
+			// The pages differ, so the second is not served from the cache of the http client.
+			for _, cursor := range []string{"", "page-2"} {
+				groups, _, err := c.ListGroups(context.Background(), cursor)
+				if tt.wantErr {
+					if err == nil {
+						t.Fatalf("ListGroups() = %v, want an error", groups)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("ListGroups: %v", err)
+				}
+				if len(groups) != 1 || groups[0].ID != "g-1" {
+					t.Errorf("groups = %v, want g-1", groups)
+				}
+			}
+
+			if !slices.Equal(hosts, tt.wantHosts) {
+				t.Errorf("requested hosts %v, want %v", hosts, tt.wantHosts)
+			}
+		})
+	}
+}
+
+func TestListDomainsPaging(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"data":[{"id":"d-1","attributes":{"name":"example.com","claim":{"type":"DNS","status":"VERIFIED"}}}],"links":{"next":"page-2"}}`,
+		"page-2": `{"data":[{"id":"d-2","attributes":{"name":"example.org","claim":{"type":"HTTPS","status":"PENDING"}}}],"links":{"next":"page-3"}}`,
+		"page-3": `{"data":[],"links":{}}`,
+	}
+
+	tests := []struct {
+		name      string
+		pages     map[string]string
+		wantIDs   []string
+		wantPages int
+	}{
+		{name: "several pages", pages: pages, wantIDs: []string{"d-1", "d-2"}, wantPages: 3},
+		{name: "no domains", pages: map[string]string{"": `{"data":[],"links":{}}`}, wantPages: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page, ok := tt.pages[r.URL.Query().Get("cursor")]
+				if r.URL.Path != "/admin/v1/orgs/org-1/domains" || !ok {
+					t.Errorf("unexpected request %s", r.URL)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(page))
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := New(context.Background(), "org-1", "token", nil)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			c.baseURL = server.URL
+
+			var ids []string
+			cursor := ""
+			for requested := 1; ; requested++ {
+				if requested > len(tt.pages) {
+					t.Fatalf("paging did not end after %d pages", requested-1)
+				}
+
+				domains, next, err := c.ListDomains(context.Background(), cursor)
+				if err != nil {
+					t.Fatalf("ListDomains(%q): %v", cursor, err)
+				}
+				for _, domain := range domains {
+					ids = append(ids, domain.ID)
+				}
+
+				if next == "" {
+					if requested != tt.wantPages {
+						t.Errorf("listed %d pages, want %d", requested, tt.wantPages)
+					}
+					break
+				}
+				cursor = next
+			}
+
+			if !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("domains = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+// TestGetSiteIDStopsOnCancel serves endless pages of workspaces without the site, and
+// cancels the lookup while the third page is requested.
+func TestGetSiteIDStopsOnCancel(t *testing.T) {
+	const cancelAt = 3
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		mu.Lock()
+		requests++
+		if requests == cancelAt {
+			cancel()
+		}
+		page := requests
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"data":[{"id":"site-%d","attributes":{"hostUrl":"https://other-%d.atlassian.net"}}],"links":{"next":"page-%d"}}`, page, page, page+1)
+	}))
+	t.Cleanup(server.Close)
+
+	c, err := New(context.Background(), "org-1", "token", nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	c.baseURL = server.URL
+
+	_, err = c.GetSiteID(ctx, "https://myorg.atlassian.net")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("GetSiteID() error = %v, want %v", err, context.Canceled)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if requests < cancelAt || requests > cancelAt+1 {
+		t.Errorf("requests = %d, want %d or %d", requests, cancelAt, cancelAt+1)
+	}
+}
+
+func TestListDirectories(t *testing.T) {
+	pages := map[string]string{
+		"":       `{"data":[{"directoryId":"d-1","name":"Okta"}],"links":{"next":"page-2"}}`,
+		"page-2": `{"data":[{"directoryId":"d-2","name":"Azure AD"},{"directoryId":"d-3","name":"Local"}],"links":{}}`,
+	}
+
+	tests := []struct {
+		name       string
+		cursor     string
+		want       []Directory
+		wantCursor string
+	}{
+		{name: "first page", want: []Directory{{ID: "d-1", Name: "Okta"}}, wantCursor: "page-2"},
+		{name: "last page", cursor: "page-2", want: []Directory{{ID: "d-2", Name: "Azure AD"}, {ID: "d-3", Name: "Local"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				page, ok := pages[r.URL.Query().Get("cursor")]
+				if r.Method != http.MethodGet || r.URL.Path != "/admin/v2/orgs/org-1/directories" || !ok {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(page))
+			}))
+			t.Cleanup(server.Close)
+
+			c, err := New(context.Background(), "org-1", "token", nil)
+			if err != nil {
+				t.Fatalf("New: %v", err)
+			}
+			c.baseURL = server.URL
+
+			directories, next, err := c.ListDirectories(context.Background(), tt.cursor)
+			if err != nil {
+				t.Fatalf("ListDirectories(%q): %v", tt.cursor, err)
+			}
+			if !slices.Equal(directories, tt.want) || next != tt.wantCursor {
+				t.Errorf("ListDirectories(%q) = %v, %q; want %v, %q", tt.cursor, directories, next, tt.want, tt.wantCursor)
+			}
+		})
+	}
+}
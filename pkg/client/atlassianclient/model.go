@@ -0,0 +1,187 @@
+package atlassianclient
+
+import "strings"
+
+// Links carries the cursor of the next page. It is empty on the last page.
+type Links struct {
+	Next string `json:"next"`
+}
+
+type WorkspacesResponse struct {
+	Data  []Workspace `json:"data"`
+	Links Links       `json:"links"`
+}
+
+type Workspace struct {
+	ID         string              `json:"id"`
+	Type       string              `json:"type"`
+	Attributes WorkspaceAttributes `json:"attributes"`
+}
+
+type WorkspaceAttributes struct {
+	Name    string `json:"name"`
+	TypeKey string `json:"typeKey"`
+	HostUrl string `json:"hostUrl"`
+	Status  string `json:"status"`
+}
+
+type GroupsResponse struct {
+	Data  []Group `json:"data"`
+	Links Links   `json:"links"`
+}
+
+type Group struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	DirectoryID string      `json:"directoryId"`
+	Counts      GroupCounts `json:"counts"`
+	// ManagementAccess is READ_ONLY for groups synced from an identity provider.
+	ManagementAccess string `json:"managementAccess"`
+}
+
+type DirectoriesResponse struct {
+	Data  []Directory `json:"data"`
+	Links Links       `json:"links"`
+}
+
+// Directory is a user directory of the org, such as one synced from an identity provider.
+type Directory struct {
+	ID   string `json:"directoryId"`
+	Name string `json:"name"`
+}
+
+type GroupCounts struct {
+	Members int `json:"members"`
+}
+
+type GroupMembershipsResponse struct {
+	Data  []GroupMembership `json:"data"`
+	Links Links             `json:"links"`
+}
+
+// GroupMembershipTypeGroup marks a membership of a nested group, whose members are
+// members of the parent group too.
+const GroupMembershipTypeGroup = "GROUP"
+
+// GroupMembership is a direct member of a group: a user, or a nested group.
+type GroupMembership struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
+// Managed reports whether the group is owned by an external directory (SCIM or AD sync)
+// rather than by Jira.
+func (g *Group) Managed() bool {
+	return g.ManagementAccess == "READ_ONLY"
+}
+
+type UserLastActiveResponse struct {
+	Data  UserLastActive `json:"data"`
+	Links Links          `json:"links"`
+}
+
+type UserLastActive struct {
+	ProductAccess []ProductAccess `json:"product_access"`
+	// AddedToOrg is the date the user was added to the org, e.g. 2021-01-31.
+	AddedToOrg string `json:"added_to_org"`
+}
+
+type ProductAccess struct {
+	ID         string `json:"id"`
+	Key        string `json:"key"`
+	LastActive string `json:"last_active"`
+}
+
+// LastActive returns the most recent last active date across every product, or "" if
+// the user was never active. Dates are ISO 8601, so they compare as strings.
+func (u *UserLastActive) LastActive() string {
+	var rv string
+	for _, product := range u.ProductAccess {
+		if product.LastActive > rv {
+			rv = product.LastActive
+		}
+	}
+
+	return rv
+}
+
+type DomainsResponse struct {
+	Data  []Domain `json:"data"`
+	Links Links    `json:"links"`
+}
+
+// Domain is a domain of the org. Accounts on a verified domain are managed by the org.
+type Domain struct {
+	ID         string           `json:"id"`
+	Type       string           `json:"type"`
+	Attributes DomainAttributes `json:"attributes"`
+}
+
+type DomainAttributes struct {
+	Name  string      `json:"name"`
+	Claim DomainClaim `json:"claim"`
+}
+
+// DomainClaim is how the domain was claimed, e.g. DNS or HTTPS, and the status of the
+// claim, e.g. VERIFIED.
+type DomainClaim struct {
+	Type   string `json:"type"`
+	Status string `json:"status"`
+}
+
+// Verified reports whether the org verified the domain.
+func (d *Domain) Verified() bool {
+	return d.Attributes.Claim.Status == ClaimStatusVerified
+}
+
+type UsersResponse struct {
+	Data  []User `json:"data"`
+	Links Links  `json:"links"`
+}
+
+type User struct {
+	AccountID     string `json:"accountId"`
+	AccountType   string `json:"accountType"`
+	AccountStatus string `json:"accountStatus"`
+	Name          string `json:"name"`
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"emailVerified"`
+	// ClaimStatus is VERIFIED for accounts on a domain the org has verified, which the org manages.
+	ClaimStatus string `json:"claimStatus"`
+}
+
+const (
+	AccountStatusActive = "active"
+
+	ClaimStatusVerified = "VERIFIED"
+)
+
+// Managed reports whether the org manages the account, which lifecycle changes require.
+func (u *User) Managed() bool {
+	return u.ClaimStatus == ClaimStatusVerified
+}
+
+type RoleAssignmentsResponse struct {
+	Data  []RoleAssignment `json:"data"`
+	Links Links            `json:"links"`
+}
+
+// RoleAssignment gives a user or a group a role, e.g. atlassian/org-admin, on a resource,
+// which is the org itself or one of its sites, identified by its ARI.
+type RoleAssignment struct {
+	RoleID        string `json:"roleId"`
+	ResourceID    string `json:"resourceId"`
+	PrincipalID   string `json:"principalId"`
+	PrincipalType string `json:"principalType"`
+}
+
+const (
+	PrincipalTypeUser  = "USER"
+	PrincipalTypeGroup = "GROUP"
+)
+
+// AppliesTo reports whether the assignment is on the org, or on the site with the given ID.
+func (r *RoleAssignment) AppliesTo(siteID string) bool {
+	return strings.Contains(r.ResourceID, "::org/") || (siteID != "" && strings.HasSuffix(r.ResourceID, "::site/"+siteID))
+}
@@ -0,0 +1,55 @@
+package client
+
+import (
+	"fmt"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+type DeploymentType string
+
+const (
+	DeploymentTypeCloud  DeploymentType = "cloud"
+	DeploymentTypeServer DeploymentType = "server"
+)
+
+// ParseDeploymentType defaults to Cloud when no deployment type is configured.
+func ParseDeploymentType(s string) (DeploymentType, error) {
+	switch DeploymentType(s) {
+	case "", DeploymentTypeCloud:
+		return DeploymentTypeCloud, nil
+	case DeploymentTypeServer:
+		return DeploymentTypeServer, nil
+	default:
+		return "", fmt.Errorf("invalid jira deployment type %q, expected %q or %q", s, DeploymentTypeCloud, DeploymentTypeServer)
+	}
+}
+
+// Client wraps go-jira for the endpoints it does not cover, and for the ones whose
+// path or shape differs between Jira Cloud and Jira Data Center (Server).
+type Client struct {
+	jira           *jira.Client
+	deploymentType DeploymentType
+}
+
+func New(jiraClient *jira.Client, deploymentType DeploymentType) *Client {
+	return &Client{
+		jira:           jiraClient,
+		deploymentType: deploymentType,
+	}
+}
+
+func (c *Client) IsServer() bool {
+	return c.deploymentType == DeploymentTypeServer
+}
+
+// apiPath builds a REST API path. Cloud serves v3, Data Center only serves v2.
+// Both versions take the same paths for the endpoints used here.
+func (c *Client) apiPath(format string, args ...interface{}) string {
+	version := 3
+	if c.IsServer() {
+		version = 2
+	}
+
+	return fmt.Sprintf("rest/api/%d/%s", version, fmt.Sprintf(format, args...))
+}
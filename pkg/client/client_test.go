@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// newTestClient returns a client of a fake Jira served by handler.
+func newTestClient(t *testing.T, deploymentType DeploymentType, handler http.Handler) *Client {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	jiraClient, err := jira.NewClient(server.URL, server.Client())
+	if err != nil {
+		t.Fatalf("creating jira client: %v", err)
+	}
+
+	return New(jiraClient, deploymentType)
+}
+
+func TestAPIPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		want           string
+	}{
+		{name: "cloud", deploymentType: DeploymentTypeCloud, want: "rest/api/3/role"},
+		{name: "data center", deploymentType: DeploymentTypeServer, want: "rest/api/2/role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := New(nil, tt.deploymentType)
+			if got := c.apiPath("role"); got != tt.want {
+				t.Errorf("apiPath() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,296 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// seraphLoginReasonHeader is set by Jira's authentication layer on failed logins.
+	seraphLoginReasonHeader = "X-Seraph-LoginReason"
+
+	seraphAuthenticationDenied = "AUTHENTICATION_DENIED"
+	seraphAuthenticatedFailed  = "AUTHENTICATED_FAILED"
+
+	// anonymousUserMessage is the body Jira returns when the credentials were ignored,
+	// which is how a revoked or expired API token shows up.
+	anonymousUserMessage = "client must be authenticated"
+
+	// maxErrorBodySize caps how much of an error response is read. A proxy in front of Jira
+	// can answer with a large HTML page, which must not be buffered in full.
+	maxErrorBodySize = 1 << 20
+
+	truncatedBodySuffix = "... (truncated)"
+)
+
+type AuthFailureReason string
+
+const (
+	AuthFailureInvalidToken AuthFailureReason = "invalid_token"
+	AuthFailureCaptcha      AuthFailureReason = "captcha"
+)
+
+// AuthError is returned for responses showing that Jira did not accept the credentials
+// at all, as opposed to the credentials lacking a permission.
+type AuthError struct {
+	Reason     AuthFailureReason
+	StatusCode int
+}
+
+func (e *AuthError) Error() string {
+	switch e.Reason {
+	case AuthFailureCaptcha:
+		return fmt.Sprintf("jira requires a CAPTCHA for this account after too many failed logins (status %d): "+
+			"log in to Jira in a browser once to clear it", e.StatusCode)
+	default:
+		return fmt.Sprintf("jira did not accept the API token (status %d): "+
+			"the token is likely revoked or expired, create a new one and update the connector configuration", e.StatusCode)
+	}
+}
+
+// classifyAuthResponse returns an AuthError for a 401 or 403 caused by the credentials
+// themselves, and nil otherwise. The response body is left readable.
+func classifyAuthResponse(resp *http.Response) *AuthError {
+	if resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusForbidden {
+		return nil
+	}
+
+	switch resp.Header.Get(seraphLoginReasonHeader) {
+	case seraphAuthenticationDenied:
+		return &AuthError{Reason: AuthFailureCaptcha, StatusCode: resp.StatusCode}
+	case seraphAuthenticatedFailed:
+		return &AuthError{Reason: AuthFailureInvalidToken, StatusCode: resp.StatusCode}
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return nil
+	}
+
+	if strings.Contains(strings.ToLower(string(body)), anonymousUserMessage) {
+		return &AuthError{Reason: AuthFailureInvalidToken, StatusCode: resp.StatusCode}
+	}
+
+	return nil
+}
+
+// limitErrorBody replaces the body of an error response with its first maxErrorBodySize
+// bytes, marked as truncated when it was longer. Successful responses are left as they are.
+func limitErrorBody(resp *http.Response) error {
+	if resp.StatusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize+1))
+	resp.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	if len(body) > maxErrorBodySize {
+		body = append(body[:maxErrorBodySize], truncatedBodySuffix...)
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	resp.ContentLength = int64(len(body))
+
+	return nil
+}
+
+// errorBodyLimitTransport applies limitErrorBody to every response.
+type errorBodyLimitTransport struct {
+	base http.RoundTripper
+}
+
+func (t *errorBodyLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = limitErrorBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// NewErrorBodyLimitClient returns a copy of httpClient that reads at most maxErrorBodySize
+// bytes of an error response, for clients that do not go through NewAuthErrorClient.
+func NewErrorBodyLimitClient(httpClient *http.Client) *http.Client {
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	rv := *httpClient
+	rv.Transport = &errorBodyLimitTransport{
+		base: base,
+	}
+
+	return &rv
+}
+
+// authErrorTransport turns responses rejecting the credentials into an AuthError, so they
+// are not mistaken for a missing permission by callers that only look at the status code.
+// Error bodies are capped first, so reading them for the classification stays bounded.
+type authErrorTransport struct {
+	base http.RoundTripper
+}
+
+func (t *authErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	err = limitErrorBody(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if authErr := classifyAuthResponse(resp); authErr != nil {
+		resp.Body.Close()
+		return nil, authErr
+	}
+
+	return resp, nil
+}
+
+// NewAuthErrorClient returns a copy of httpClient whose requests fail with an AuthError
+// when Jira rejects the credentials.
+func NewAuthErrorClient(httpClient *http.Client) *http.Client {
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	rv := *httpClient
+	rv.Transport = &authErrorTransport{
+		base: base,
+	}
+
+	return &rv
+}
+
+// WrapError adds context to an error. Authentication and permission failures are
+// classified first, so a dead API token is not reported as a missing permission.
+func WrapError(err error, message string) error {
+	return fmt.Errorf("jira-connector: %s: %w", message, ClassifyError(err))
+}
+
+// IsNotFound reports whether err is a Jira 404, or ErrUserNotFound. ClassifyError leaves
+// 404s as they are, since what a missing resource means is up to the caller.
+func IsNotFound(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	return errors.Is(err, ErrUserNotFound) || strings.Contains(err.Error(), fmt.Sprintf("Status code: %d", http.StatusNotFound))
+}
+
+// ClassifyError maps authentication and authorization failures to gRPC statuses with a
+// remediation hint: Unauthenticated when the credentials were rejected, PermissionDenied
+// when they lack a permission. 400s with a Jira error body become
+// InvalidArgument with the field errors attached. Throttling and unavailable gateways
+// become Unavailable, and timed out requests DeadlineExceeded, so callers can tell what is
+// worth retrying from what is not. Other errors are returned unchanged.
+func ClassifyError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return status.Error(codes.Unauthenticated, authErr.Error())
+	}
+
+	var jiraErr *jira.Error
+	if errors.As(err, &jiraErr) {
+		for _, message := range jiraErr.ErrorMessages {
+			if strings.Contains(strings.ToLower(message), anonymousUserMessage) {
+				return status.Error(codes.Unauthenticated, (&AuthError{Reason: AuthFailureInvalidToken, StatusCode: http.StatusUnauthorized}).Error())
+			}
+		}
+
+		if jiraErr.HTTPError != nil && strings.Contains(jiraErr.HTTPError.Error(), fmt.Sprintf("Status code: %d", http.StatusBadRequest)) {
+			return badRequestStatus(jiraErr)
+		}
+	}
+
+	message := err.Error()
+	switch {
+	case strings.Contains(message, fmt.Sprintf("Status code: %d", http.StatusUnauthorized)):
+		return status.Errorf(codes.Unauthenticated, "%s: check the Jira email and API token", message)
+	case strings.Contains(message, fmt.Sprintf("Status code: %d", http.StatusForbidden)):
+		return status.Errorf(codes.PermissionDenied, "%s: the Jira account lacks a permission this request needs", message)
+	case strings.Contains(message, fmt.Sprintf("Status code: %d", http.StatusTooManyRequests)),
+		strings.Contains(message, fmt.Sprintf("Status code: %d", http.StatusBadGateway)),
+		strings.Contains(message, fmt.Sprintf("Status code: %d", http.StatusServiceUnavailable)),
+		strings.Contains(message, fmt.Sprintf("Status code: %d", http.StatusGatewayTimeout)):
+		return status.Error(codes.Unavailable, message)
+	}
+
+	return err
+}
+
+// badRequestStatus keeps Jira's error body as a BadRequest detail, so the field that was
+// rejected is not lost in the message. Field errors are sorted by field, and the general
+// errorMessages are added as violations without a field.
+func badRequestStatus(jiraErr *jira.Error) error {
+	fields := make([]string, 0, len(jiraErr.Errors))
+	for field := range jiraErr.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	badRequest := &errdetails.BadRequest{}
+	messages := make([]string, 0, len(jiraErr.ErrorMessages)+len(fields))
+	for _, message := range jiraErr.ErrorMessages {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Description: message,
+		})
+		messages = append(messages, message)
+	}
+	for _, field := range fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: jiraErr.Errors[field],
+		})
+		messages = append(messages, fmt.Sprintf("%s: %s", field, jiraErr.Errors[field]))
+	}
+
+	message := jiraErr.HTTPError.Error()
+	if len(messages) > 0 {
+		message = fmt.Sprintf("jira rejected the request: %s", strings.Join(messages, "; "))
+	}
+
+	st, err := status.New(codes.InvalidArgument, message).WithDetails(badRequest)
+	if err != nil {
+		return status.Error(codes.InvalidArgument, message)
+	}
+
+	return st.Err()
+}
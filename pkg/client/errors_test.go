@@ -0,0 +1,263 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TestClassifyErrorNotFound checks 404s are left to the caller: ClassifyError keeps them
+// as they are, and IsNotFound tells them apart.
+func TestClassifyErrorNotFound(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		wantCode     codes.Code
+		wantNotFound bool
+	}{
+		{name: "not found", statusCode: http.StatusNotFound, wantCode: codes.Unknown, wantNotFound: true},
+		{name: "forbidden", statusCode: http.StatusForbidden, wantCode: codes.PermissionDenied},
+		{name: "server error", statusCode: http.StatusInternalServerError, wantCode: codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, DeploymentTypeCloud, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(`{"errorMessages":["failed"]}`))
+			}))
+
+			_, err := c.GetProject(context.Background(), "SW", nil)
+			if err == nil {
+				t.Fatal("GetProject() succeeded")
+			}
+			if got := status.Code(ClassifyError(err)); got != tt.wantCode {
+				t.Errorf("ClassifyError() code = %v, want %v", got, tt.wantCode)
+			}
+			if got := IsNotFound(err); got != tt.wantNotFound {
+				t.Errorf("IsNotFound() = %v, want %v", got, tt.wantNotFound)
+			}
+		})
+	}
+
+	if IsNotFound(nil) {
+		t.Error("IsNotFound(nil) = true")
+	}
+	if !IsNotFound(fmt.Errorf("looking up user: %w", ErrUserNotFound)) {
+		t.Error("IsNotFound(ErrUserNotFound) = false")
+	}
+}
+
+// TestClassifyAuthErrors checks canned Jira responses rejecting the credentials are told
+// apart from responses to credentials lacking a permission.
+func TestClassifyAuthErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		loginReason string
+		body        string
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{
+			name:        "revoked token",
+			statusCode:  http.StatusUnauthorized,
+			loginReason: "AUTHENTICATED_FAILED",
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "create a new one",
+		},
+		{
+			name:        "captcha",
+			statusCode:  http.StatusForbidden,
+			loginReason: "AUTHENTICATION_DENIED",
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "CAPTCHA",
+		},
+		{
+			name:        "anonymous user",
+			statusCode:  http.StatusForbidden,
+			body:        `{"errorMessages":["Client must be authenticated to access this resource."],"errors":{}}`,
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "create a new one",
+		},
+		{
+			name:        "missing permission",
+			statusCode:  http.StatusForbidden,
+			body:        `{"errorMessages":["You do not have permission to view this project."],"errors":{}}`,
+			wantCode:    codes.PermissionDenied,
+			wantMessage: "lacks a permission",
+		},
+		{
+			name:        "unauthorized without a reason",
+			statusCode:  http.StatusUnauthorized,
+			body:        `{"errorMessages":["Unauthorized"]}`,
+			wantCode:    codes.Unauthenticated,
+			wantMessage: "check the Jira email and API token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				if tt.loginReason != "" {
+					w.Header().Set("X-Seraph-LoginReason", tt.loginReason)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			t.Cleanup(server.Close)
+
+			jiraClient, err := jira.NewClient(server.URL, NewAuthErrorClient(server.Client()))
+			if err != nil {
+				t.Fatalf("creating jira client: %v", err)
+			}
+
+			_, err = New(jiraClient, DeploymentTypeCloud).GetProject(context.Background(), "SW", nil)
+			if err == nil {
+				t.Fatal("GetProject() succeeded")
+			}
+
+			err = ClassifyError(err)
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v (%v)", got, tt.wantCode, err)
+			}
+			if !strings.Contains(err.Error(), tt.wantMessage) {
+				t.Errorf("error %q does not contain %q", err, tt.wantMessage)
+			}
+			if wrapped := WrapError(err, "failed to get project"); status.Code(errors.Unwrap(wrapped)) != tt.wantCode {
+				t.Errorf("WrapError() = %v, want it to wrap the %v status", wrapped, tt.wantCode)
+			}
+		})
+	}
+}
+
+// TestErrorBodyLimit checks error bodies are cut to maxErrorBodySize bytes and marked as
+// truncated, by both clients that cap them, while successful bodies are read in full.
+func TestErrorBodyLimit(t *testing.T) {
+	oversized := strings.Repeat("x", maxErrorBodySize+10)
+	html := "<html><body><h1>502 Bad Gateway</h1></body></html>"
+
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		want       string
+	}{
+		{name: "small error", statusCode: http.StatusBadRequest, body: `{"errorMessages":["failed"]}`, want: `{"errorMessages":["failed"]}`},
+		{name: "html error", statusCode: http.StatusBadGateway, body: html, want: html},
+		{name: "oversized error", statusCode: http.StatusInternalServerError, body: oversized, want: oversized[:maxErrorBodySize] + truncatedBodySuffix},
+		{name: "oversized success", statusCode: http.StatusOK, body: oversized, want: oversized},
+	}
+
+	clients := map[string]func(*http.Client) *http.Client{
+		"error body limit": NewErrorBodyLimitClient,
+		"auth error":       NewAuthErrorClient,
+	}
+
+	for clientName, newClient := range clients {
+		for _, tt := range tests {
+			t.Run(clientName+"/"+tt.name, func(t *testing.T) {
+				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+					w.WriteHeader(tt.statusCode)
+					_, _ = w.Write([]byte(tt.body))
+				}))
+				t.Cleanup(server.Close)
+
+				resp, err := newClient(server.Client()).Get(server.URL)
+				if err != nil {
+					t.Fatalf("Get: %v", err)
+				}
+				defer resp.Body.Close()
+
+				body, err := io.ReadAll(resp.Body)
+				if err != nil {
+					t.Fatalf("reading body: %v", err)
+				}
+				if string(body) != tt.want {
+					t.Errorf("body is %d bytes ending in %q, want %d bytes ending in %q",
+						len(body), body[max(len(body)-20, 0):], len(tt.want), tt.want[max(len(tt.want)-20, 0):])
+				}
+			})
+		}
+	}
+}
+
+// TestClassifyHTMLErrors checks an HTML error page from a proxy is classified by its
+// status code, rather than failing to decode as a Jira error.
+func TestClassifyHTMLErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		wantCode   codes.Code
+	}{
+		{name: "bad gateway", statusCode: http.StatusBadGateway, wantCode: codes.Unavailable},
+		{name: "forbidden", statusCode: http.StatusForbidden, wantCode: codes.PermissionDenied},
+		{name: "server error", statusCode: http.StatusInternalServerError, wantCode: codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte("<html><body>" + strings.Repeat("proxy error ", maxErrorBodySize/8) + "</body></html>"))
+			}))
+			t.Cleanup(server.Close)
+
+			jiraClient, err := jira.NewClient(server.URL, NewAuthErrorClient(server.Client()))
+			if err != nil {
+				t.Fatalf("creating jira client: %v", err)
+			}
+			c := New(jiraClient, DeploymentTypeCloud)
+
+			_, err = c.GetProject(context.Background(), "SW", nil)
+			if err == nil {
+				t.Fatal("GetProject() succeeded")
+			}
+			if got := status.Code(ClassifyError(err)); got != tt.wantCode {
+				t.Errorf("ClassifyError() code = %v, want %v", got, tt.wantCode)
+			}
+			if !strings.Contains(err.Error(), fmt.Sprintf("Status code: %d", tt.statusCode)) {
+				t.Errorf("error %q does not name the status code", err)
+			}
+		})
+	}
+}
+
+// TestClassifyTransientErrors checks failures worth retrying later are told apart from
+// the ones that fail again however often they are retried.
+func TestClassifyTransientErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		wantCode codes.Code
+	}{
+		{name: "throttled", err: errors.New("request failed. Status code: 429"), wantCode: codes.Unavailable},
+		{name: "bad gateway", err: errors.New("request failed. Status code: 502"), wantCode: codes.Unavailable},
+		{name: "unavailable", err: errors.New("request failed. Status code: 503"), wantCode: codes.Unavailable},
+		{name: "gateway timeout", err: errors.New("request failed. Status code: 504"), wantCode: codes.Unavailable},
+		{name: "deadline", err: fmt.Errorf("creating issue: %w", context.DeadlineExceeded), wantCode: codes.DeadlineExceeded},
+		{name: "forbidden", err: errors.New("request failed. Status code: 403"), wantCode: codes.PermissionDenied},
+		{name: "server error", err: errors.New("request failed. Status code: 500"), wantCode: codes.Unknown},
+		{name: "already classified", err: status.Error(codes.FailedPrecondition, "project archived"), wantCode: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := status.Code(ClassifyError(tt.err)); got != tt.wantCode {
+				t.Errorf("ClassifyError() code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
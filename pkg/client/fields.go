@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// CascadingOption is a cascading select option with its child options.
+type CascadingOption struct {
+	ID       string            `json:"id"`
+	Value    string            `json:"value"`
+	Children []CascadingOption `json:"children,omitempty"`
+}
+
+// CascadingSelectType is Jira's schema type of cascading select custom fields.
+const CascadingSelectType = "option-with-child"
+
+// cascadingSelectField holds the allowed values of a cascading select with their children.
+type cascadingSelectField struct {
+	AllowedValues []CascadingOption `json:"allowedValues,omitempty"`
+}
+
+type createMetaIssueTypeFields struct {
+	pageInfo
+	Fields []json.RawMessage `json:"fields"`
+}
+
+// GetCreateMetaFields returns a page of the create metadata fields of an issue type of a
+// project, the options of its cascading selects by field key, and whether it is the last
+// page. go-jira's GetCreateMetaIssueType drops the paging, and decodes allowed values into
+// Choice, which drops the child options of cascading selects.
+func (c *Client) GetCreateMetaFields(
+	ctx context.Context,
+	projectKey string,
+	issueTypeID string,
+	startAt int,
+	maxResults int,
+) ([]*jira.MetaDataFields, map[string][]CascadingOption, bool, error) {
+	query := url.Values{}
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	path := c.apiPath("issue/createmeta/%s/issuetypes/%s?%s", url.PathEscape(projectKey), url.PathEscape(issueTypeID), query.Encode())
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	var res createMetaIssueTypeFields
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, nil, false, jira.NewJiraError(resp, err)
+	}
+
+	fields := make([]*jira.MetaDataFields, 0, len(res.Fields))
+	cascadingOptions := make(map[string][]CascadingOption)
+	for _, raw := range res.Fields {
+		field := &jira.MetaDataFields{}
+		if err := json.Unmarshal(raw, field); err != nil {
+			return nil, nil, false, err
+		}
+		fields = append(fields, field)
+
+		if field.Schema.Type != CascadingSelectType {
+			continue
+		}
+
+		var cascading cascadingSelectField
+		if err := json.Unmarshal(raw, &cascading); err != nil {
+			return nil, nil, false, err
+		}
+		cascadingOptions[field.Key] = cascading.AllowedValues
+	}
+
+	return fields, cascadingOptions, res.lastPage(len(res.Fields)), nil
+}
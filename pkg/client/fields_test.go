@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func TestGetCreateMetaFields(t *testing.T) {
+	const body = `{"isLast":true,"fields":[` +
+		`{"required":true,"schema":{"type":"option-with-child","custom":"com.atlassian.jira.plugin.system.customfieldtypes:cascadingselect"},"name":"Application","key":"customfield_1","fieldId":"customfield_1",` +
+		`"allowedValues":[{"id":"1","value":"Payroll","children":[{"id":"2","value":"Viewer"}]},{"id":"3","value":"Wiki"}]},` +
+		`{"required":false,"schema":{"type":"option","custom":"com.atlassian.jira.plugin.system.customfieldtypes:select"},"name":"Impact","key":"customfield_2","fieldId":"customfield_2",` +
+		`"allowedValues":[{"id":"4","value":"Low"}]}]}`
+
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		wantPath       string
+	}{
+		{name: "cloud", deploymentType: DeploymentTypeCloud, wantPath: "/rest/api/3/issue/createmeta/SW/issuetypes/10001"},
+		{name: "data center", deploymentType: DeploymentTypeServer, wantPath: "/rest/api/2/issue/createmeta/SW/issuetypes/10001"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			c := newTestClient(t, tt.deploymentType, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(body))
+			}))
+
+			fields, cascadingOptions, lastPage, err := c.GetCreateMetaFields(context.Background(), "SW", "10001", 0, 100)
+			if err != nil {
+				t.Fatalf("GetCreateMetaFields: %v", err)
+			}
+			if requests != 1 || !lastPage {
+				t.Errorf("requests = %d, last page %v; want 1 request and the last page", requests, lastPage)
+			}
+
+			if len(fields) != 2 || fields[0].Key != "customfield_1" || fields[1].AllowedValues[0].Value != "Low" {
+				t.Errorf("fields = %+v, want both fields with their allowed values", fields)
+			}
+
+			want := map[string][]CascadingOption{
+				"customfield_1": {
+					{ID: "1", Value: "Payroll", Children: []CascadingOption{{ID: "2", Value: "Viewer"}}},
+					{ID: "3", Value: "Wiki"},
+				},
+			}
+			if !reflect.DeepEqual(cascadingOptions, want) {
+				t.Errorf("cascading options = %+v, want %+v", cascadingOptions, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serverGroupPickerLimit is the most groups a Data Center group picker request returns.
+// The picker has no offset, so larger sites only get their first groups synced.
+const serverGroupPickerLimit = 1000
+
+type groupPickerResponse struct {
+	Total  int `json:"total"`
+	Groups []struct {
+		Name    string `json:"name"`
+		HTML    string `json:"html"`
+		GroupID string `json:"groupId"`
+	} `json:"groups"`
+}
+
+type groupMembersResponse struct {
+	pageInfo
+	Values []jira.GroupMember `json:"values"`
+}
+
+type groupsBulkResponse struct {
+	pageInfo
+	Values []jira.Group `json:"values"`
+}
+
+// ListGroups returns one page of groups. Data Center groups have no IDs, so their name is used instead.
+// Callers should advance by the number of groups returned, Jira can return fewer than maxResults.
+func (c *Client) ListGroups(ctx context.Context, startAt int, maxResults int) ([]jira.Group, bool, error) {
+	if !c.IsServer() {
+		query := url.Values{}
+		query.Set("startAt", strconv.Itoa(startAt))
+		query.Set("maxResults", strconv.Itoa(maxResults))
+
+		req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("group/bulk?%s", query.Encode()), nil)
+		if err != nil {
+			return nil, false, err
+		}
+
+		var res groupsBulkResponse
+		resp, err := c.jira.Do(req, &res)
+		if err != nil {
+			return nil, false, jira.NewJiraError(resp, err)
+		}
+
+		rv := make([]jira.Group, 0, len(res.Values))
+		for _, group := range res.Values {
+			rv = append(rv, jira.Group{
+				ID:   group.ID,
+				Name: group.Name,
+			})
+		}
+
+		return rv, res.lastPage(len(res.Values)), nil
+	}
+
+	if startAt > 0 {
+		return nil, true, nil
+	}
+
+	groups, err := c.FindGroupsByQuery(ctx, "", nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return groups, true, nil
+}
+
+// FindGroupsByQuery returns up to serverGroupPickerLimit groups whose name contains query,
+// in a single group picker request. Groups whose ID is in excludeIDs are left out.
+// Data Center groups have no IDs, so their name is used instead.
+func (c *Client) FindGroupsByQuery(ctx context.Context, query string, excludeIDs []string) ([]jira.Group, error) {
+	params := url.Values{}
+	params.Set("maxResults", strconv.Itoa(serverGroupPickerLimit))
+	if query != "" {
+		params.Set("query", query)
+	}
+	for _, id := range excludeIDs {
+		if c.IsServer() {
+			params.Add("exclude", id)
+		} else {
+			params.Add("excludeId", id)
+		}
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("groups/picker?%s", params.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res groupPickerResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	rv := make([]jira.Group, 0, len(res.Groups))
+	for _, group := range res.Groups {
+		name := group.Name
+		if name == "" {
+			name = stripPickerMarkup(group.HTML)
+		}
+
+		id := group.GroupID
+		if id == "" {
+			id = name
+		}
+
+		rv = append(rv, jira.Group{
+			ID:   id,
+			Name: name,
+		})
+	}
+
+	return rv, nil
+}
+
+// stripPickerMarkup removes the <b> tags the picker wraps around the part of a name matching the query.
+func stripPickerMarkup(html string) string {
+	return strings.NewReplacer("<b>", "", "</b>", "").Replace(html)
+}
+
+// GetGroupMembers returns one page of a group's members. Cloud looks the group up
+// by ID, Data Center by name. Callers should advance by the number of members returned,
+// Jira can return fewer than maxResults.
+func (c *Client) GetGroupMembers(ctx context.Context, group string, startAt int, maxResults int) ([]jira.GroupMember, bool, error) {
+	query := url.Values{}
+	if c.IsServer() {
+		query.Set("groupname", group)
+	} else {
+		query.Set("groupId", group)
+	}
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("group/member?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res groupMembersResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Values, res.lastPage(len(res.Values)), nil
+}
+
+// CreateGroup creates a group. A group that already exists is reported as AlreadyExists.
+// Data Center groups have no IDs, so the name is used instead.
+func (c *Client) CreateGroup(ctx context.Context, name string) (*jira.Group, error) {
+	body := struct {
+		Name string `json:"name"`
+	}{
+		Name: name,
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodPost, c.apiPath("group"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	group := new(jira.Group)
+	resp, err := c.jira.Do(req, group)
+	if err != nil {
+		jerr := jira.NewJiraError(resp, err)
+		if resp != nil && resp.StatusCode == http.StatusBadRequest && alreadyExists(jerr) {
+			return nil, status.Errorf(codes.AlreadyExists, "group %s already exists: %v", name, jerr)
+		}
+		return nil, jerr
+	}
+
+	if group.ID == "" {
+		group.ID = group.Name
+	}
+
+	return group, nil
+}
+
+// DeleteGroup deletes a group. Cloud looks the group up by ID, Data Center by name.
+func (c *Client) DeleteGroup(ctx context.Context, group string) error {
+	query := url.Values{}
+	if c.IsServer() {
+		query.Set("groupname", group)
+	} else {
+		query.Set("groupId", group)
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodDelete, c.apiPath("group?%s", query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// AddGroupMember adds a user to a group. Cloud looks the group up by ID and the user by
+// account ID, Data Center both by name.
+func (c *Client) AddGroupMember(ctx context.Context, group string, userID string) error {
+	query := url.Values{}
+	body := map[string]string{}
+	if c.IsServer() {
+		query.Set("groupname", group)
+		body["name"] = userID
+	} else {
+		query.Set("groupId", group)
+		body["accountId"] = userID
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodPost, c.apiPath("group/user?%s", query.Encode()), body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// RemoveGroupMember removes a user from a group, looked up like in AddGroupMember.
+func (c *Client) RemoveGroupMember(ctx context.Context, group string, userID string) error {
+	query := url.Values{}
+	if c.IsServer() {
+		query.Set("groupname", group)
+		query.Set("username", userID)
+	} else {
+		query.Set("groupId", group)
+		query.Set("accountId", userID)
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodDelete, c.apiPath("group/user?%s", query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,179 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+func TestGroupMembershipPaths(t *testing.T) {
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		remove         bool
+		wantMethod     string
+		wantPath       string
+		wantQuery      string
+	}{
+		{
+			name:           "cloud add",
+			deploymentType: DeploymentTypeCloud,
+			wantMethod:     http.MethodPost,
+			wantPath:       "/rest/api/3/group/user",
+			wantQuery:      "groupId=g1",
+		},
+		{
+			name:           "data center add",
+			deploymentType: DeploymentTypeServer,
+			wantMethod:     http.MethodPost,
+			wantPath:       "/rest/api/2/group/user",
+			wantQuery:      "groupname=g1",
+		},
+		{
+			name:           "cloud remove",
+			deploymentType: DeploymentTypeCloud,
+			remove:         true,
+			wantMethod:     http.MethodDelete,
+			wantPath:       "/rest/api/3/group/user",
+			wantQuery:      "accountId=u1&groupId=g1",
+		},
+		{
+			name:           "data center remove",
+			deploymentType: DeploymentTypeServer,
+			remove:         true,
+			wantMethod:     http.MethodDelete,
+			wantPath:       "/rest/api/2/group/user",
+			wantQuery:      "groupname=g1&username=u1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotPath, gotQuery string
+			c := newTestClient(t, tt.deploymentType, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotMethod, gotPath, gotQuery = r.Method, r.URL.Path, r.URL.RawQuery
+				w.WriteHeader(http.StatusCreated)
+			}))
+
+			var err error
+			if tt.remove {
+				err = c.RemoveGroupMember(context.Background(), "g1", "u1")
+			} else {
+				err = c.AddGroupMember(context.Background(), "g1", "u1")
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if gotMethod != tt.wantMethod || gotPath != tt.wantPath || gotQuery != tt.wantQuery {
+				t.Errorf("got %s %s?%s, want %s %s?%s", gotMethod, gotPath, gotQuery, tt.wantMethod, tt.wantPath, tt.wantQuery)
+			}
+		})
+	}
+}
+
+func TestListRolesPath(t *testing.T) {
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		wantPath       string
+	}{
+		{name: "cloud", deploymentType: DeploymentTypeCloud, wantPath: "/rest/api/3/role"},
+		{name: "data center", deploymentType: DeploymentTypeServer, wantPath: "/rest/api/2/role"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			c := newTestClient(t, tt.deploymentType, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				_, _ = w.Write([]byte(`[{"id":10002,"name":"Administrators"}]`))
+			}))
+
+			roles, err := c.ListRoles(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotPath != tt.wantPath {
+				t.Errorf("path = %q, want %q", gotPath, tt.wantPath)
+			}
+			if len(roles) != 1 || roles[0].ID != 10002 {
+				t.Errorf("roles = %+v, want the Administrators role", roles)
+			}
+		})
+	}
+}
+
+func TestStripPickerMarkup(t *testing.T) {
+	tests := []struct {
+		html string
+		want string
+	}{
+		{html: "jira-<b>admin</b>s", want: "jira-admins"},
+		{html: "<b>site</b>-<b>admin</b>s", want: "site-admins"},
+		{html: "developers", want: "developers"},
+		{html: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := stripPickerMarkup(tt.html); got != tt.want {
+			t.Errorf("stripPickerMarkup(%q) = %q, want %q", tt.html, got, tt.want)
+		}
+	}
+}
+
+func TestFindGroupsByQuery(t *testing.T) {
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		response       string
+		wantPath       string
+		wantQuery      string
+		wantGroups     []jira.Group
+	}{
+		{
+			name:           "cloud",
+			deploymentType: DeploymentTypeCloud,
+			response:       `{"total":2,"groups":[{"name":"jira-admins","html":"jira-<b>admin</b>s","groupId":"g1"},{"html":"site-<b>admin</b>s","groupId":"g2"}]}`,
+			wantPath:       "/rest/api/3/groups/picker",
+			wantQuery:      "excludeId=g3&excludeId=g4&maxResults=1000&query=admin",
+			wantGroups:     []jira.Group{{ID: "g1", Name: "jira-admins"}, {ID: "g2", Name: "site-admins"}},
+		},
+		{
+			name:           "data center",
+			deploymentType: DeploymentTypeServer,
+			response:       `{"total":1,"groups":[{"name":"jira-administrators","html":"jira-<b>admin</b>istrators"}]}`,
+			wantPath:       "/rest/api/2/groups/picker",
+			wantQuery:      "exclude=g3&exclude=g4&maxResults=1000&query=admin",
+			wantGroups:     []jira.Group{{ID: "jira-administrators", Name: "jira-administrators"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			c := newTestClient(t, tt.deploymentType, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.URL.Path != tt.wantPath || r.URL.RawQuery != tt.wantQuery {
+					t.Errorf("request = %s?%s, want %s?%s", r.URL.Path, r.URL.RawQuery, tt.wantPath, tt.wantQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.response))
+			}))
+
+			groups, err := c.FindGroupsByQuery(context.Background(), "admin", []string{"g3", "g4"})
+			if err != nil {
+				t.Fatalf("FindGroupsByQuery: %v", err)
+			}
+			if requests != 1 {
+				t.Errorf("requests = %d, want 1", requests)
+			}
+			if !reflect.DeepEqual(groups, tt.wantGroups) {
+				t.Errorf("groups = %+v, want %+v", groups, tt.wantGroups)
+			}
+		})
+	}
+}
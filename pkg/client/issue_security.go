@@ -0,0 +1,101 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// IssueSecurityScheme is a set of security levels, which restrict who can see an issue.
+type IssueSecurityScheme struct {
+	ID          int64                `json:"id"`
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Levels      []IssueSecurityLevel `json:"levels"`
+}
+
+type IssueSecurityLevel struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// IssueSecurityLevelMember is who can see the issues of a level. The holder is shaped
+// like a permission holder.
+type IssueSecurityLevelMember struct {
+	ID                   int64            `json:"id"`
+	IssueSecurityLevelID int64            `json:"issueSecurityLevelId"`
+	Holder               PermissionHolder `json:"holder"`
+}
+
+type issueSecuritySchemesResponse struct {
+	IssueSecuritySchemes []IssueSecurityScheme `json:"issueSecuritySchemes"`
+}
+
+type issueSecurityLevelMembersResponse struct {
+	pageInfo
+	Values []IssueSecurityLevelMember `json:"values"`
+}
+
+// ListIssueSecuritySchemes returns every issue security scheme, without their levels.
+// The endpoint is not paginated.
+func (c *Client) ListIssueSecuritySchemes(ctx context.Context) ([]IssueSecurityScheme, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("issuesecurityschemes"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res issueSecuritySchemesResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return res.IssueSecuritySchemes, nil
+}
+
+// GetIssueSecurityScheme returns an issue security scheme with its levels.
+func (c *Client) GetIssueSecurityScheme(ctx context.Context, schemeID string) (*IssueSecurityScheme, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("issuesecurityschemes/%s", url.PathEscape(schemeID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := new(IssueSecurityScheme)
+	resp, err := c.jira.Do(req, scheme)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return scheme, nil
+}
+
+// GetIssueSecurityLevelMembers returns one page of the members of a security level.
+func (c *Client) GetIssueSecurityLevelMembers(
+	ctx context.Context,
+	schemeID string,
+	levelID string,
+	startAt int,
+	maxResults int,
+) ([]IssueSecurityLevelMember, bool, error) {
+	query := url.Values{}
+	query.Set("issueSecurityLevelId", levelID)
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("issuesecurityschemes/%s/members?%s", url.PathEscape(schemeID), query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res issueSecurityLevelMembersResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Values, res.lastPage(len(res.Values)), nil
+}
@@ -0,0 +1,24 @@
+package client
+
+import (
+	"context"
+	"net/http"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// ListIssueTypes returns every issue type the user can see. The endpoint is not paginated.
+func (c *Client) ListIssueTypes(ctx context.Context) ([]jira.IssueType, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("issuetype"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var issueTypes []jira.IssueType
+	resp, err := c.jira.Do(req, &issueTypes)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return issueTypes, nil
+}
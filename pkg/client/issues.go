@@ -0,0 +1,54 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+type IssueWatchers struct {
+	WatchCount int         `json:"watchCount"`
+	IsWatching bool        `json:"isWatching"`
+	Watchers   []jira.User `json:"watchers"`
+}
+
+type issueLinkTypes struct {
+	IssueLinkTypes []jira.IssueLinkType `json:"issueLinkTypes"`
+}
+
+// ListIssueLinkTypes returns the issue link types of the site. go-jira's GetList decodes
+// the response as a bare list, while Jira wraps it in an object, so it always fails.
+func (c *Client) ListIssueLinkTypes(ctx context.Context) ([]jira.IssueLinkType, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("issueLinkType"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res issueLinkTypes
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return res.IssueLinkTypes, nil
+}
+
+// GetIssueWatchers returns the watchers of an issue in a single request.
+// go-jira's GetWatchers looks every watcher up again, one request per user.
+// The response is returned on error too, so callers can tell a hidden watcher list (403) apart.
+func (c *Client) GetIssueWatchers(ctx context.Context, issueKey string) (*IssueWatchers, *jira.Response, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("issue/%s/watchers", url.PathEscape(issueKey)), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	watchers := new(IssueWatchers)
+	resp, err := c.jira.Do(req, watchers)
+	if err != nil {
+		return nil, resp, jira.NewJiraError(resp, err)
+	}
+
+	return watchers, resp, nil
+}
@@ -0,0 +1,208 @@
+package client
+
+import (
+	"net/http"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// Session store caches whose hits and misses are counted.
+const (
+	CacheProjects = "projects"
+	CacheRoles    = "roles"
+)
+
+// idSegmentPattern matches path segments holding an ID rather than a fixed name, e.g.
+// 10001, PROJ-123 or an account or org ID. API versions such as /rest/api/3 or /admin/v1
+// are kept.
+var (
+	idSegmentPattern      = regexp.MustCompile(`\d`)
+	versionSegmentPattern = regexp.MustCompile(`^v\d+$`)
+)
+
+// Metrics counts the requests sent to Jira and the Atlassian admin API, and how the
+// session store served the data builders asked for. It is safe for concurrent use, and
+// a nil Metrics counts nothing.
+type Metrics struct {
+	mu          sync.Mutex
+	calls       map[string]int64
+	rateLimited int64
+	retries     int64
+	cacheHits   map[string]int64
+	cacheMisses map[string]int64
+}
+
+// MetricsSnapshot is a copy of the counters of a Metrics.
+type MetricsSnapshot struct {
+	// Calls is keyed by method and endpoint pattern, e.g. "GET /rest/api/3/project/{id}".
+	Calls       map[string]int64
+	RateLimited int64
+	Retries     int64
+	CacheHits   map[string]int64
+	CacheMisses map[string]int64
+}
+
+func NewMetrics() *Metrics {
+	return &Metrics{
+		calls:       make(map[string]int64),
+		cacheHits:   make(map[string]int64),
+		cacheMisses: make(map[string]int64),
+	}
+}
+
+// endpointPattern replaces the IDs in a path with {id}, so requests for different
+// projects or users count against the same endpoint.
+func endpointPattern(path string) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		if !idSegmentPattern.MatchString(segment) || versionSegmentPattern.MatchString(segment) {
+			continue
+		}
+		if i > 0 && segments[i-1] == "api" {
+			continue
+		}
+		segments[i] = "{id}"
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func (m *Metrics) recordCall(req *http.Request, resp *http.Response) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls[req.Method+" "+endpointPattern(req.URL.Path)]++
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		m.rateLimited++
+	}
+}
+
+func (m *Metrics) recordRetry() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries++
+}
+
+// RecordCacheHit counts a read of cache served without a request.
+func (m *Metrics) RecordCacheHit(cache string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cacheHits[cache]++
+}
+
+// RecordCacheMiss counts a read of cache that needed a request.
+func (m *Metrics) RecordCacheMiss(cache string) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cacheMisses[cache]++
+}
+
+// Snapshot returns a copy of the counters.
+func (m *Metrics) Snapshot() MetricsSnapshot {
+	rv := MetricsSnapshot{
+		Calls:       make(map[string]int64),
+		CacheHits:   make(map[string]int64),
+		CacheMisses: make(map[string]int64),
+	}
+	if m == nil {
+		return rv
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for k, v := range m.calls {
+		rv.Calls[k] = v
+	}
+	for k, v := range m.cacheHits {
+		rv.CacheHits[k] = v
+	}
+	for k, v := range m.cacheMisses {
+		rv.CacheMisses[k] = v
+	}
+	rv.RateLimited = m.rateLimited
+	rv.Retries = m.retries
+
+	return rv
+}
+
+// Fields returns the snapshot as log fields, with the hit ratio of each cache.
+func (s MetricsSnapshot) Fields() []zap.Field {
+	var total int64
+	for _, calls := range s.Calls {
+		total += calls
+	}
+
+	ratios := make(map[string]float64)
+	for _, cache := range []string{CacheProjects, CacheRoles} {
+		if reads := s.CacheHits[cache] + s.CacheMisses[cache]; reads > 0 {
+			ratios[cache] = float64(s.CacheHits[cache]) / float64(reads)
+		}
+	}
+
+	return []zap.Field{
+		zap.Int64("api_calls", total),
+		zap.Any("api_calls_by_endpoint", s.Calls),
+		zap.Int64("rate_limited", s.RateLimited),
+		zap.Int64("retries", s.Retries),
+		zap.Any("cache_hits", s.CacheHits),
+		zap.Any("cache_misses", s.CacheMisses),
+		zap.Any("cache_hit_ratio", ratios),
+	}
+}
+
+// metricsTransport counts every request that reaches Jira, retries included.
+type metricsTransport struct {
+	base    http.RoundTripper
+	metrics *Metrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	t.metrics.recordCall(req, resp)
+
+	return resp, err
+}
+
+// NewMetricsClient returns a copy of httpClient counting its requests in metrics. A nil
+// metrics returns httpClient as is.
+func NewMetricsClient(httpClient *http.Client, metrics *Metrics) *http.Client {
+	if metrics == nil {
+		return httpClient
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	rv := *httpClient
+	rv.Transport = &metricsTransport{
+		base:    base,
+		metrics: metrics,
+	}
+
+	return &rv
+}
@@ -0,0 +1,100 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+type NotificationScheme struct {
+	ID          int64                     `json:"id"`
+	Name        string                    `json:"name"`
+	Description string                    `json:"description"`
+	Events      []NotificationSchemeEvent `json:"notificationSchemeEvents"`
+}
+
+type NotificationSchemeEvent struct {
+	Event struct {
+		ID          int64  `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+	} `json:"event"`
+	Notifications []Notification `json:"notifications"`
+}
+
+// Notification is who is notified of an event. Parameter depends on NotificationType,
+// e.g. a group name, an account ID or a project role ID.
+type Notification struct {
+	ID               int64  `json:"id"`
+	NotificationType string `json:"notificationType"`
+	Parameter        string `json:"parameter"`
+	Group            *struct {
+		Name    string `json:"name"`
+		GroupID string `json:"groupId"`
+	} `json:"group,omitempty"`
+	User *struct {
+		AccountID string `json:"accountId"`
+		Key       string `json:"key"`
+	} `json:"user,omitempty"`
+}
+
+type notificationSchemesResponse struct {
+	pageInfo
+	Values []NotificationScheme `json:"values"`
+}
+
+// ListNotificationSchemes returns one page of notification schemes, with their events.
+func (c *Client) ListNotificationSchemes(ctx context.Context, startAt int, maxResults int) ([]NotificationScheme, bool, error) {
+	query := url.Values{}
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+	query.Set("expand", "all")
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("notificationscheme?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res notificationSchemesResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Values, res.lastPage(len(res.Values)), nil
+}
+
+// GetNotificationScheme returns a notification scheme with its events.
+func (c *Client) GetNotificationScheme(ctx context.Context, schemeID string) (*NotificationScheme, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("notificationscheme/%s?expand=all", url.PathEscape(schemeID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := new(NotificationScheme)
+	resp, err := c.jira.Do(req, scheme)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return scheme, nil
+}
+
+// GetProjectNotificationScheme returns the notification scheme assigned to a project.
+func (c *Client) GetProjectNotificationScheme(ctx context.Context, projectIDOrKey string) (*NotificationScheme, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("project/%s/notificationscheme", url.PathEscape(projectIDOrKey)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := new(NotificationScheme)
+	resp, err := c.jira.Do(req, scheme)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return scheme, nil
+}
@@ -0,0 +1,49 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// PageContextErr returns the error of a canceled or expired ctx, naming the operation. Loops
+// walking pages check it before each request, so a canceled sync stops paging at once.
+func PageContextErr(ctx context.Context, operation string) error {
+	if err := ctx.Err(); err != nil {
+		return WrapError(err, fmt.Sprintf("stopped %s", operation))
+	}
+
+	return nil
+}
+
+// pageInfo is the paging part of Jira's paginated responses. Jira can clamp maxResults
+// below the requested page size, so the last page is decided from what it reports.
+// go-jira only copies it onto jira.Response for a few services, so endpoints whose paging
+// matters are decoded here instead.
+type pageInfo struct {
+	StartAt    int    `json:"startAt"`
+	MaxResults int    `json:"maxResults"`
+	Total      int    `json:"total"`
+	IsLast     *bool  `json:"isLast"`
+	NextPage   string `json:"nextPage"`
+}
+
+// lastPage reports whether a page of count values is the last one: from isLast when Jira
+// sends it, else from the link to the next page, else from the total, else from the page
+// size Jira applied. An empty page is always the last, so a caller advancing by count
+// cannot loop.
+func (p *pageInfo) lastPage(count int) bool {
+	switch {
+	case count == 0:
+		return true
+	case p.IsLast != nil:
+		return *p.IsLast
+	case p.NextPage != "":
+		return false
+	case p.Total > 0:
+		return p.StartAt+count >= p.Total
+	case p.MaxResults > 0:
+		return count < p.MaxResults
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPageContextErr(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+
+	// An expired context is classified as DeadlineExceeded, which replaces the context error.
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantErr  error
+		wantCode codes.Code
+	}{
+		{name: "live", ctx: context.Background(), wantCode: codes.OK},
+		{name: "canceled", ctx: canceled, wantErr: context.Canceled, wantCode: codes.Unknown},
+		{name: "expired", ctx: expired, wantCode: codes.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PageContextErr(tt.ctx, "listing things")
+			if tt.wantCode == codes.OK {
+				if err != nil {
+					t.Fatalf("PageContextErr() = %v, want nil", err)
+				}
+				return
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("PageContextErr() = %v, want %v", err, tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), "stopped listing things") {
+				t.Errorf("PageContextErr() = %q, want it to name the operation", err)
+			}
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("code = %v, want %v", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPageInfoLastPage(t *testing.T) {
+	tests := []struct {
+		name     string
+		envelope string
+		count    int
+		want     bool
+	}{
+		{name: "is last", envelope: `{"startAt":0,"maxResults":50,"total":120,"isLast":true}`, count: 50, want: true},
+		{name: "not last", envelope: `{"startAt":50,"maxResults":50,"isLast":false}`, count: 50, want: false},
+		{name: "is last wins over a short page", envelope: `{"startAt":0,"maxResults":50,"isLast":false}`, count: 10, want: false},
+		{name: "next page link", envelope: `{"startAt":0,"maxResults":50,"nextPage":"https://example.atlassian.net/rest/api/3/statuses/search?startAt=50"}`, count: 10, want: false},
+		{name: "before the total", envelope: `{"startAt":50,"maxResults":50,"total":120}`, count: 50, want: false},
+		{name: "reaches the total", envelope: `{"startAt":100,"maxResults":50,"total":120}`, count: 20, want: true},
+		{name: "clamped full page", envelope: `{"startAt":0,"maxResults":10}`, count: 10, want: false},
+		{name: "short page", envelope: `{"startAt":0,"maxResults":10}`, count: 3, want: true},
+		{name: "no paging", envelope: `{}`, count: 3, want: false},
+		{name: "empty page", envelope: `{"startAt":0,"maxResults":50,"isLast":false}`, count: 0, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var page pageInfo
+			if err := json.Unmarshal([]byte(tt.envelope), &page); err != nil {
+				t.Fatalf("decoding %s: %v", tt.envelope, err)
+			}
+			if got := page.lastPage(tt.count); got != tt.want {
+				t.Errorf("lastPage(%d) of %s = %v, want %v", tt.count, tt.envelope, got, tt.want)
+			}
+		})
+	}
+}
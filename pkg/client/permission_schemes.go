@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+type PermissionScheme struct {
+	ID          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// PermissionHolder is who a permission is granted to. Parameter and Value depend on Type,
+// e.g. a group name and ID, an account ID, or a project role ID.
+type PermissionHolder struct {
+	Type      string `json:"type"`
+	Parameter string `json:"parameter"`
+	Value     string `json:"value"`
+}
+
+type PermissionGrant struct {
+	ID         int64            `json:"id"`
+	Holder     PermissionHolder `json:"holder"`
+	Permission string           `json:"permission"`
+}
+
+type permissionSchemesResponse struct {
+	PermissionSchemes []PermissionScheme `json:"permissionSchemes"`
+}
+
+type permissionGrantsResponse struct {
+	Permissions []PermissionGrant `json:"permissions"`
+}
+
+// ListPermissionSchemes returns every permission scheme. The endpoint is not paginated.
+func (c *Client) ListPermissionSchemes(ctx context.Context) ([]PermissionScheme, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("permissionscheme"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res permissionSchemesResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return res.PermissionSchemes, nil
+}
+
+// GetPermissionSchemeGrants returns every permission grant of a scheme, in one request
+// instead of one per permission.
+func (c *Client) GetPermissionSchemeGrants(ctx context.Context, schemeID string) ([]PermissionGrant, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("permissionscheme/%s/permission", url.PathEscape(schemeID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res permissionGrantsResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return res.Permissions, nil
+}
+
+// GetProjectPermissionScheme returns the permission scheme assigned to a project.
+func (c *Client) GetProjectPermissionScheme(ctx context.Context, projectIDOrKey string) (*PermissionScheme, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("project/%s/permissionscheme", url.PathEscape(projectIDOrKey)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	scheme := new(PermissionScheme)
+	resp, err := c.jira.Do(req, scheme)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return scheme, nil
+}
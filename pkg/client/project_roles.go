@@ -0,0 +1,177 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// projectRoleResponse only decodes the actors. Custom roles of team-managed projects
+// have string IDs, which jira.Role cannot hold.
+type projectRoleResponse struct {
+	Actors []*jira.Actor `json:"actors"`
+}
+
+// ListRoles returns the global roles of the site.
+func (c *Client) ListRoles(ctx context.Context) ([]jira.Role, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("role"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []jira.Role
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return res, nil
+}
+
+// GetProjectRoleActors returns the actors of a role in a project. The project scoped
+// endpoint serves classic, team-managed and custom roles alike.
+func (c *Client) GetProjectRoleActors(ctx context.Context, projectID string, roleID string) ([]*jira.Actor, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("project/%s/role/%s", url.PathEscape(projectID), url.PathEscape(roleID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res projectRoleResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return res.Actors, nil
+}
+
+// GetRoleDefaultActors returns the default actors of a role, which new projects start
+// with in that role.
+func (c *Client) GetRoleDefaultActors(ctx context.Context, roleID string) ([]*jira.Actor, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("role/%s/actors", url.PathEscape(roleID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var res projectRoleResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return res.Actors, nil
+}
+
+// AddRoleDefaultActor adds a user or, with isGroup set, a group to the default actors of
+// a role. Existing projects are not changed.
+func (c *Client) AddRoleDefaultActor(ctx context.Context, roleID string, actorID string, isGroup bool) error {
+	param := "user"
+	if isGroup {
+		param = c.groupActorParam()
+	}
+
+	body := map[string][]string{
+		param: {actorID},
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodPost, c.apiPath("role/%s/actors", url.PathEscape(roleID)), body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+
+	return nil
+}
+
+// RemoveRoleDefaultActor removes a user or, with isGroup set, a group from the default
+// actors of a role.
+func (c *Client) RemoveRoleDefaultActor(ctx context.Context, roleID string, actorID string, isGroup bool) error {
+	param := "user"
+	if isGroup {
+		param = c.groupActorParam()
+	}
+
+	query := url.Values{}
+	query.Set(param, actorID)
+
+	req, err := c.jira.NewRequest(ctx, http.MethodDelete, c.apiPath("role/%s/actors?%s", url.PathEscape(roleID), query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+
+	return nil
+}
+
+// groupActorParam is how groups are named when adding or removing role actors.
+// Data Center groups have no IDs, so their name is used there.
+func (c *Client) groupActorParam() string {
+	if c.IsServer() {
+		return "group"
+	}
+
+	return "groupId"
+}
+
+// AddProjectRoleActor adds a user or, with isGroup set, a group to a role in a project.
+// Users are account IDs on Cloud and usernames on Data Center.
+func (c *Client) AddProjectRoleActor(ctx context.Context, projectID string, roleID string, actorID string, isGroup bool) error {
+	param := "user"
+	if isGroup {
+		param = c.groupActorParam()
+	}
+
+	body := map[string][]string{
+		param: {actorID},
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodPost, c.apiPath("project/%s/role/%s", url.PathEscape(projectID), url.PathEscape(roleID)), body)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+
+	return nil
+}
+
+// RemoveProjectRoleActor removes a user or, with isGroup set, a group from a role in a project.
+func (c *Client) RemoveProjectRoleActor(ctx context.Context, projectID string, roleID string, actorID string, isGroup bool) error {
+	param := "user"
+	if isGroup {
+		param = c.groupActorParam()
+	}
+
+	query := url.Values{}
+	query.Set(param, actorID)
+
+	req, err := c.jira.NewRequest(
+		ctx,
+		http.MethodDelete,
+		c.apiPath("project/%s/role/%s?%s", url.PathEscape(projectID), url.PathEscape(roleID), query.Encode()),
+		nil,
+	)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+
+	return nil
+}
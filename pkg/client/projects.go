@@ -0,0 +1,203 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// MaxProjectKeysPerSearch is how many keys are sent in one project search,
+// which keeps the query string well under Jira's URL length limits.
+const MaxProjectKeysPerSearch = 50
+
+// MaxProjectKeysPerExpandedSearch is how many keys are sent in one project search with an
+// expand. Jira silently drops the expand of long queries, well before their length fails them.
+const MaxProjectKeysPerExpandedSearch = 25
+
+type FindProjectsOptions struct {
+	StartAt    int
+	MaxResults int
+	Keys       []string
+	Expand     []string
+	// Archived searches the archived projects instead of the live ones.
+	Archived bool
+}
+
+// serverProject is a Data Center project, which is flagged when archived.
+type serverProject struct {
+	jira.Project
+	Archived bool `json:"archived"`
+}
+
+type findProjectsResponse struct {
+	pageInfo
+	Values []jira.Project `json:"values"`
+}
+
+// FindProjects searches projects like go-jira's Project.Find, but can filter by key
+// and reports whether this was the last page. go-jira discards both.
+func (c *Client) FindProjects(ctx context.Context, opts FindProjectsOptions) ([]jira.Project, bool, error) {
+	if c.IsServer() {
+		return c.findServerProjects(ctx, opts)
+	}
+
+	query := url.Values{}
+	query.Set("startAt", strconv.Itoa(opts.StartAt))
+	if opts.MaxResults > 0 {
+		query.Set("maxResults", strconv.Itoa(opts.MaxResults))
+	}
+	for _, key := range opts.Keys {
+		query.Add("keys", key)
+	}
+	if len(opts.Expand) > 0 {
+		query.Set("expand", strings.Join(opts.Expand, ","))
+	}
+	if opts.Archived {
+		query.Set("status", "archived")
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("project/search?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res findProjectsResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Values, res.lastPage(len(res.Values)), nil
+}
+
+// GetProject returns a single project, with the given expansions such as "issueTypes".
+func (c *Client) GetProject(ctx context.Context, projectIDOrKey string, expand []string) (*jira.Project, error) {
+	query := url.Values{}
+	if len(expand) > 0 {
+		query.Set("expand", strings.Join(expand, ","))
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("project/%s?%s", url.PathEscape(projectIDOrKey), query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	project := new(jira.Project)
+	resp, err := c.jira.Do(req, project)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return project, nil
+}
+
+// ListProjectComponents returns every component of a project. The endpoint is not paged.
+func (c *Client) ListProjectComponents(ctx context.Context, projectIDOrKey string) ([]jira.ProjectComponent, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("project/%s/components", url.PathEscape(projectIDOrKey)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var components []jira.ProjectComponent
+	resp, err := c.jira.Do(req, &components)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return components, nil
+}
+
+// Data Center has no project search, only GET /rest/api/2/project returning every
+// project at once, so the keys filter and the paging are applied here.
+func (c *Client) findServerProjects(ctx context.Context, opts FindProjectsOptions) ([]jira.Project, bool, error) {
+	query := url.Values{}
+	if len(opts.Expand) > 0 {
+		query.Set("expand", strings.Join(opts.Expand, ","))
+	}
+	if opts.Archived {
+		query.Set("includeArchived", "true")
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("project?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var serverProjects []serverProject
+	resp, err := c.jira.Do(req, &serverProjects)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	keys := make(map[string]bool, len(opts.Keys))
+	for _, key := range opts.Keys {
+		keys[key] = true
+	}
+
+	// includeArchived lists the live projects too, which are left out like those of other keys.
+	projects := make([]jira.Project, 0, len(serverProjects))
+	for _, project := range serverProjects {
+		if project.Archived != opts.Archived {
+			continue
+		}
+		if len(keys) > 0 && !keys[project.Key] {
+			continue
+		}
+		projects = append(projects, project.Project)
+	}
+
+	if opts.StartAt >= len(projects) {
+		return nil, true, nil
+	}
+
+	end := len(projects)
+	if opts.MaxResults > 0 && opts.StartAt+opts.MaxResults < end {
+		end = opts.StartAt + opts.MaxResults
+	}
+
+	return projects[opts.StartAt:end], end == len(projects), nil
+}
+
+// BatchProjectKeys splits keys into batches of at most batchSize.
+func BatchProjectKeys(keys []string, batchSize int) [][]string {
+	var rv [][]string
+	for len(keys) > batchSize {
+		rv = append(rv, keys[:batchSize])
+		keys = keys[batchSize:]
+	}
+	if len(keys) > 0 {
+		rv = append(rv, keys)
+	}
+
+	return rv
+}
+
+// SetProjectArchived archives or restores a project. Cloud takes a POST, Data Center a PUT.
+func (c *Client) SetProjectArchived(ctx context.Context, projectIDOrKey string, archived bool) (*jira.Response, error) {
+	action := "restore"
+	if archived {
+		action = "archive"
+	}
+
+	method := http.MethodPost
+	if c.IsServer() {
+		method = http.MethodPut
+	}
+
+	req, err := c.jira.NewRequest(ctx, method, c.apiPath("project/%s/%s", url.PathEscape(projectIDOrKey), action), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return resp, jira.NewJiraError(resp, err)
+	}
+	defer resp.Body.Close()
+
+	return resp, nil
+}
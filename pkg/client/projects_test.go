@@ -0,0 +1,89 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+)
+
+func TestFindArchivedProjects(t *testing.T) {
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		archived       bool
+		keys           []string
+		body           string
+		wantQuery      string
+		wantKeys       []string
+	}{
+		{
+			name:           "cloud live",
+			deploymentType: DeploymentTypeCloud,
+			body:           `{"isLast":true,"values":[{"id":"1","key":"LIVE"}]}`,
+			wantQuery:      "startAt=0",
+			wantKeys:       []string{"LIVE"},
+		},
+		{
+			name:           "cloud archived",
+			deploymentType: DeploymentTypeCloud,
+			archived:       true,
+			body:           `{"isLast":true,"values":[{"id":"2","key":"OLD"}]}`,
+			wantQuery:      "startAt=0&status=archived",
+			wantKeys:       []string{"OLD"},
+		},
+		{
+			name:           "data center live",
+			deploymentType: DeploymentTypeServer,
+			body:           `[{"id":"1","key":"LIVE"},{"id":"2","key":"OLD","archived":true}]`,
+			wantKeys:       []string{"LIVE"},
+		},
+		{
+			name:           "data center archived",
+			deploymentType: DeploymentTypeServer,
+			archived:       true,
+			body:           `[{"id":"1","key":"LIVE"},{"id":"2","key":"OLD","archived":true},{"id":"3","key":"GONE","archived":true}]`,
+			wantQuery:      "includeArchived=true",
+			wantKeys:       []string{"OLD", "GONE"},
+		},
+		{
+			name:           "data center archived with keys",
+			deploymentType: DeploymentTypeServer,
+			archived:       true,
+			keys:           []string{"GONE"},
+			body:           `[{"id":"1","key":"LIVE"},{"id":"2","key":"OLD","archived":true},{"id":"3","key":"GONE","archived":true}]`,
+			wantQuery:      "includeArchived=true",
+			wantKeys:       []string{"GONE"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery string
+			c := newTestClient(t, tt.deploymentType, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotQuery = r.URL.RawQuery
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			projects, lastPage, err := c.FindProjects(context.Background(), FindProjectsOptions{Keys: tt.keys, Archived: tt.archived})
+			if err != nil {
+				t.Fatalf("FindProjects() error = %v", err)
+			}
+			if !lastPage {
+				t.Errorf("FindProjects() lastPage = false, want true")
+			}
+			if gotQuery != tt.wantQuery {
+				t.Errorf("query = %q, want %q", gotQuery, tt.wantQuery)
+			}
+
+			var keys []string
+			for _, project := range projects {
+				keys = append(keys, project.Key)
+			}
+			if !slices.Equal(keys, tt.wantKeys) {
+				t.Errorf("project keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
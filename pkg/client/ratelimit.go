@@ -0,0 +1,187 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// DefaultRequestsPerSecond keeps a sync under the rate Atlassian recommends for a single
+// app, so the endpoint weights apply without any configuration.
+const DefaultRequestsPerSecond = 10
+
+// DefaultEndpointWeights are the tokens a request to the heaviest endpoints takes from the
+// rate limit, keyed by a fragment of their path. Atlassian documents stricter concurrency
+// limits for group members, and permission searches and create metadata are costly on
+// large sites. Any other request takes one token.
+var DefaultEndpointWeights = map[string]int{
+	"/group/member":           3,
+	"/permissions/project":    2,
+	"/user/permission/search": 2,
+	"/issue/createmeta":       2,
+}
+
+// RateLimitPolicy shares one request budget between every caller of the client.
+type RateLimitPolicy struct {
+	// RequestsPerSecond caps the tokens taken per second. Zero disables the limit.
+	RequestsPerSecond int
+	// EndpointWeights overrides DefaultEndpointWeights, per path fragment. A weight below 1
+	// restores the default of one token.
+	EndpointWeights map[string]int
+	// JitterMax is the ceiling of a random wait before each request to a weighted
+	// endpoint, so the pages of parallel group syncs do not arrive in bursts. Zero
+	// disables the jitter.
+	JitterMax time.Duration
+}
+
+// ParseEndpointWeights parses "fragment=weight" entries, e.g. "/group/member=4", into
+// RateLimitPolicy.EndpointWeights.
+func ParseEndpointWeights(entries []string) (map[string]int, error) {
+	rv := make(map[string]int, len(entries))
+	for _, entry := range entries {
+		fragment, value, ok := strings.Cut(entry, "=")
+		if !ok || strings.TrimSpace(fragment) == "" {
+			return nil, fmt.Errorf("invalid endpoint weight %q, expected e.g. '/group/member=4'", entry)
+		}
+
+		weight, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid endpoint weight %q: %w", entry, err)
+		}
+
+		rv[strings.TrimSpace(fragment)] = weight
+	}
+
+	return rv, nil
+}
+
+// weights merges the configured weights over the defaults.
+func (p RateLimitPolicy) weights() map[string]int {
+	rv := make(map[string]int, len(DefaultEndpointWeights)+len(p.EndpointWeights))
+	for fragment, weight := range DefaultEndpointWeights {
+		rv[fragment] = weight
+	}
+	for fragment, weight := range p.EndpointWeights {
+		if weight < 1 {
+			delete(rv, fragment)
+			continue
+		}
+		rv[fragment] = weight
+	}
+
+	return rv
+}
+
+// rateLimitedTransport makes every request wait for its tokens, so concurrent callers
+// share one request budget instead of running into Jira's 429s.
+type rateLimitedTransport struct {
+	base      http.RoundTripper
+	limiter   *rate.Limiter
+	weights   map[string]int
+	jitterMax time.Duration
+
+	// now and sleep are the clock of the limiter, replaced in tests.
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+// sleepContext waits for d, or returns the error of ctx if it is done first.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// weight returns the tokens a request takes, the heaviest of the fragments its path has.
+func (t *rateLimitedTransport) weight(req *http.Request) int {
+	rv := 1
+	for fragment, weight := range t.weights {
+		if weight > rv && strings.Contains(req.URL.Path, fragment) {
+			rv = weight
+		}
+	}
+
+	return rv
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	weight := t.weight(req)
+
+	var delay time.Duration
+	var reservation *rate.Reservation
+	if t.limiter != nil {
+		now := t.now()
+		reservation = t.limiter.ReserveN(now, weight)
+		delay = reservation.DelayFrom(now)
+	}
+	if weight > 1 && t.jitterMax > 0 {
+		delay += time.Duration(rand.Int63n(int64(t.jitterMax))) //nolint:gosec // Jitter needs no cryptographic randomness.
+	}
+
+	if delay > 0 {
+		ctxzap.Extract(ctx).Debug(
+			"waiting for jira rate limiter",
+			zap.Duration("wait", delay),
+			zap.Int("weight", weight),
+			zap.String("path", req.URL.Path),
+		)
+
+		if err := t.sleep(ctx, delay); err != nil {
+			if reservation != nil {
+				reservation.CancelAt(t.now())
+			}
+			return nil, err
+		}
+	}
+
+	return t.base.RoundTrip(req)
+}
+
+// NewRateLimitedClient returns a copy of httpClient sending requests within policy. A
+// policy without a limit or jitter returns httpClient as is.
+func NewRateLimitedClient(httpClient *http.Client, policy RateLimitPolicy) *http.Client {
+	if policy.RequestsPerSecond < 1 && policy.JitterMax <= 0 {
+		return httpClient
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	transport := &rateLimitedTransport{
+		base:      base,
+		weights:   policy.weights(),
+		jitterMax: policy.JitterMax,
+		now:       time.Now,
+		sleep:     sleepContext,
+	}
+	// The burst holds the heaviest request, so weighted requests can always be served.
+	if policy.RequestsPerSecond > 0 {
+		burst := policy.RequestsPerSecond
+		for _, weight := range transport.weights {
+			burst = max(burst, weight)
+		}
+		transport.limiter = rate.NewLimiter(rate.Limit(policy.RequestsPerSecond), burst)
+	}
+
+	rv := *httpClient
+	rv.Transport = transport
+
+	return &rv
+}
@@ -0,0 +1,159 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// fakeClock stands in for the clock of the limiter. Sleeping moves it forward, unless
+// failSleep is set.
+type fakeClock struct {
+	now       time.Time
+	waits     []time.Duration
+	failSleep error
+}
+
+func (c *fakeClock) sleep(_ context.Context, d time.Duration) error {
+	c.waits = append(c.waits, d)
+	if c.failSleep != nil {
+		return c.failSleep
+	}
+	c.now = c.now.Add(d)
+	return nil
+}
+
+// newFakeClockTransport returns the transport of policy on a fake clock, answering every
+// request with 200.
+func newFakeClockTransport(t *testing.T, policy RateLimitPolicy) (*rateLimitedTransport, *fakeClock) {
+	t.Helper()
+
+	httpClient := NewRateLimitedClient(&http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+		}),
+	}, policy)
+
+	transport, ok := httpClient.Transport.(*rateLimitedTransport)
+	if !ok {
+		t.Fatalf("transport = %T, want *rateLimitedTransport", httpClient.Transport)
+	}
+
+	clock := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	transport.now = func() time.Time { return clock.now }
+	transport.sleep = clock.sleep
+
+	return transport, clock
+}
+
+func roundTripPath(t *testing.T, transport http.RoundTripper, path string) error {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.atlassian.net"+path, nil)
+	if err != nil {
+		t.Fatalf("creating request: %v", err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+func TestRateLimitedTransportWeights(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    RateLimitPolicy
+		paths     []string
+		wantWaits []time.Duration
+	}{
+		{
+			name:      "unweighted requests take one token",
+			policy:    RateLimitPolicy{RequestsPerSecond: 2},
+			paths:     []string{"/rest/api/3/role", "/rest/api/3/role", "/rest/api/3/role", "/rest/api/3/role"},
+			wantWaits: []time.Duration{500 * time.Millisecond},
+		},
+		{
+			name:   "heavy endpoints take their weight",
+			policy: RateLimitPolicy{RequestsPerSecond: 2},
+			paths:  []string{"/rest/api/3/group/member", "/rest/api/3/role", "/rest/api/3/issue/createmeta/SW/issuetypes/1"},
+			// The burst holds the heaviest weight, 3, so the group page is served at once.
+			wantWaits: []time.Duration{500 * time.Millisecond, time.Second},
+		},
+		{
+			name:      "configured weights override the defaults",
+			policy:    RateLimitPolicy{RequestsPerSecond: 1, EndpointWeights: map[string]int{"/group/member": 0, "/role": 4}},
+			paths:     []string{"/rest/api/3/group/member", "/rest/api/3/role"},
+			wantWaits: []time.Duration{time.Second},
+		},
+		{
+			name:      "default rate",
+			policy:    RateLimitPolicy{RequestsPerSecond: DefaultRequestsPerSecond},
+			paths:     []string{"/rest/api/3/group/member", "/rest/api/3/group/member", "/rest/api/3/group/member", "/rest/api/3/group/member", "/rest/api/3/group/member"},
+			wantWaits: []time.Duration{200 * time.Millisecond, 300 * time.Millisecond},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport, clock := newFakeClockTransport(t, tt.policy)
+
+			for _, path := range tt.paths {
+				if err := roundTripPath(t, transport, path); err != nil {
+					t.Fatalf("RoundTrip(%s): %v", path, err)
+				}
+			}
+
+			if len(clock.waits) != len(tt.wantWaits) {
+				t.Fatalf("waits = %v, want %v", clock.waits, tt.wantWaits)
+			}
+			for i, want := range tt.wantWaits {
+				if clock.waits[i] != want {
+					t.Errorf("waits = %v, want %v", clock.waits, tt.wantWaits)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestRateLimitedTransportCancel(t *testing.T) {
+	transport, clock := newFakeClockTransport(t, RateLimitPolicy{RequestsPerSecond: 1})
+
+	// The burst holds three tokens, the weight of a group member page.
+	if err := roundTripPath(t, transport, "/rest/api/3/group/member"); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+
+	clock.failSleep = context.Canceled
+	if err := roundTripPath(t, transport, "/rest/api/3/role"); !errors.Is(err, context.Canceled) {
+		t.Fatalf("RoundTrip error = %v, want %v", err, context.Canceled)
+	}
+
+	// The canceled request gives its token back, so the next one waits as long.
+	clock.failSleep = nil
+	if err := roundTripPath(t, transport, "/rest/api/3/role"); err != nil {
+		t.Fatalf("RoundTrip: %v", err)
+	}
+	if want := []time.Duration{time.Second, time.Second}; len(clock.waits) != 2 || clock.waits[0] != want[0] || clock.waits[1] != want[1] {
+		t.Errorf("waits = %v, want %v", clock.waits, want)
+	}
+}
+
+func TestNewRateLimitedClientDisabled(t *testing.T) {
+	httpClient := &http.Client{}
+	if got := NewRateLimitedClient(httpClient, RateLimitPolicy{}); got != httpClient {
+		t.Errorf("NewRateLimitedClient() without a limit wrapped the client")
+	}
+}
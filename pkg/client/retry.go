@@ -0,0 +1,180 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RetryPolicy bounds the retries of requests Jira throttled or could not serve.
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// InitialBackoff is the wait before the first retry, doubled before each next one.
+	// A Retry-After header sent by Jira takes precedence.
+	InitialBackoff time.Duration
+	// MaxElapsed bounds the time spent on a request including its retries. Zero means no bound.
+	MaxElapsed time.Duration
+}
+
+// ErrRetryBudgetExhausted matches, with errors.Is, the RetryBudgetExhaustedError of a
+// request that still failed after its retries.
+var ErrRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// RetryBudgetExhaustedError is returned for a request that was still throttled or
+// unavailable after the retries of the RetryPolicy, so callers can tell it from a request
+// that failed at once. It is reported as Unavailable.
+type RetryBudgetExhaustedError struct {
+	Method         string
+	Path           string
+	Attempts       int
+	LastStatusCode int
+}
+
+func (e *RetryBudgetExhaustedError) Error() string {
+	return fmt.Sprintf("%s: %s %s failed after %d attempts, last status %d", ErrRetryBudgetExhausted, e.Method, e.Path, e.Attempts, e.LastStatusCode)
+}
+
+func (e *RetryBudgetExhaustedError) Is(target error) bool {
+	return target == ErrRetryBudgetExhausted
+}
+
+func (e *RetryBudgetExhaustedError) GRPCStatus() *status.Status {
+	return status.New(codes.Unavailable, e.Error())
+}
+
+// retryTransport retries requests answered with a 429, and idempotent requests answered
+// with a 502, 503 or 504.
+type retryTransport struct {
+	base    http.RoundTripper
+	policy  RetryPolicy
+	metrics *Metrics
+}
+
+func retryable(req *http.Request, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+			return true
+		}
+	}
+
+	return false
+}
+
+// retryAfter returns the wait asked for by the Retry-After header, in seconds, if any.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0, false
+	}
+
+	return time.Duration(seconds) * time.Second, true
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	start := time.Now()
+	backoff := t.policy.InitialBackoff
+
+	for attempt := 1; ; attempt++ {
+		resp, err := t.base.RoundTrip(req)
+		if err != nil || !retryable(req, resp.StatusCode) {
+			return resp, err
+		}
+
+		wait := backoff
+		if d, ok := retryAfter(resp); ok {
+			wait = d
+		}
+
+		// Requests whose body cannot be replayed are not retried.
+		outOfBudget := attempt > t.policy.MaxRetries ||
+			(t.policy.MaxElapsed > 0 && time.Since(start)+wait > t.policy.MaxElapsed) ||
+			(req.Body != nil && req.GetBody == nil)
+		if outOfBudget {
+			if attempt == 1 {
+				return resp, nil
+			}
+
+			resp.Body.Close()
+			err := &RetryBudgetExhaustedError{
+				Method:         req.Method,
+				Path:           req.URL.Path,
+				Attempts:       attempt,
+				LastStatusCode: resp.StatusCode,
+			}
+			ctxzap.Extract(ctx).Warn(
+				"jira request exhausted its retries",
+				zap.String("method", req.Method),
+				zap.String("path", req.URL.Path),
+				zap.Int("attempts", attempt),
+				zap.Int("last_status_code", resp.StatusCode),
+			)
+
+			return nil, err
+		}
+
+		resp.Body.Close()
+		ctxzap.Extract(ctx).Debug(
+			"retrying jira request",
+			zap.String("path", req.URL.Path),
+			zap.Int("status_code", resp.StatusCode),
+			zap.Duration("wait", wait),
+		)
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			req = req.Clone(ctx)
+			req.Body = body
+		}
+
+		t.metrics.recordRetry()
+		backoff *= 2
+	}
+}
+
+// NewRetryClient returns a copy of httpClient retrying requests according to policy, and
+// counting the retries in metrics, which may be nil. A policy without retries returns
+// httpClient as is.
+func NewRetryClient(httpClient *http.Client, policy RetryPolicy, metrics *Metrics) *http.Client {
+	if policy.MaxRetries < 1 {
+		return httpClient
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	rv := *httpClient
+	rv.Transport = &retryTransport{
+		base:    base,
+		policy:  policy,
+		metrics: metrics,
+	}
+
+	return &rv
+}
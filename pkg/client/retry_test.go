@@ -0,0 +1,155 @@
+package client
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		policy     RetryPolicy
+		retryAfter string
+		// statuses are the status codes of each attempt, the last one repeated.
+		statuses      []int
+		wantAttempts  int
+		wantStatus    int
+		wantExhausted bool
+		wantRetries   int64
+	}{
+		{
+			name:         "success",
+			method:       http.MethodGet,
+			policy:       RetryPolicy{MaxRetries: 3},
+			statuses:     []int{http.StatusOK},
+			wantAttempts: 1,
+			wantStatus:   http.StatusOK,
+		},
+		{
+			name:         "throttled then served",
+			method:       http.MethodGet,
+			policy:       RetryPolicy{MaxRetries: 3},
+			statuses:     []int{http.StatusTooManyRequests, http.StatusTooManyRequests, http.StatusOK},
+			wantAttempts: 3,
+			wantStatus:   http.StatusOK,
+			wantRetries:  2,
+		},
+		{
+			name:          "throttled past the retries",
+			method:        http.MethodPost,
+			policy:        RetryPolicy{MaxRetries: 2},
+			statuses:      []int{http.StatusTooManyRequests},
+			wantAttempts:  3,
+			wantExhausted: true,
+			wantRetries:   2,
+		},
+		{
+			name:          "unavailable past the retries",
+			method:        http.MethodGet,
+			policy:        RetryPolicy{MaxRetries: 1},
+			statuses:      []int{http.StatusServiceUnavailable},
+			wantAttempts:  2,
+			wantExhausted: true,
+			wantRetries:   1,
+		},
+		{
+			name:         "unavailable create is not retried",
+			method:       http.MethodPost,
+			policy:       RetryPolicy{MaxRetries: 3},
+			statuses:     []int{http.StatusServiceUnavailable},
+			wantAttempts: 1,
+			wantStatus:   http.StatusServiceUnavailable,
+		},
+		{
+			name:         "client error is not retried",
+			method:       http.MethodGet,
+			policy:       RetryPolicy{MaxRetries: 3},
+			statuses:     []int{http.StatusBadRequest},
+			wantAttempts: 1,
+			wantStatus:   http.StatusBadRequest,
+		},
+		{
+			// A failure that was never retried is returned as is, not as an exhausted budget.
+			name:         "retry after past the elapsed budget",
+			method:       http.MethodGet,
+			policy:       RetryPolicy{MaxRetries: 3, MaxElapsed: time.Second},
+			retryAfter:   "120",
+			statuses:     []int{http.StatusTooManyRequests},
+			wantAttempts: 1,
+			wantStatus:   http.StatusTooManyRequests,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			metrics := NewMetrics()
+			httpClient := NewRetryClient(&http.Client{
+				Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+					statusCode := tt.statuses[min(attempts, len(tt.statuses)-1)]
+					attempts++
+
+					header := http.Header{}
+					if tt.retryAfter != "" {
+						header.Set("Retry-After", tt.retryAfter)
+					}
+					return &http.Response{StatusCode: statusCode, Header: header, Body: io.NopCloser(strings.NewReader("{}")), Request: req}, nil
+				}),
+			}, tt.policy, metrics)
+
+			req, err := http.NewRequest(tt.method, "https://example.atlassian.net/rest/api/3/myself", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			resp, err := httpClient.Do(req)
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if got := metrics.Snapshot().Retries; got != tt.wantRetries {
+				t.Errorf("retries = %d, want %d", got, tt.wantRetries)
+			}
+
+			if tt.wantExhausted {
+				var exhausted *RetryBudgetExhaustedError
+				if !errors.As(err, &exhausted) {
+					t.Fatalf("err = %v, want a RetryBudgetExhaustedError", err)
+				}
+				if exhausted.Attempts != tt.wantAttempts || exhausted.LastStatusCode != tt.statuses[len(tt.statuses)-1] {
+					t.Errorf("exhausted after %d attempts with status %d, want %d attempts with status %d",
+						exhausted.Attempts, exhausted.LastStatusCode, tt.wantAttempts, tt.statuses[len(tt.statuses)-1])
+				}
+				if !errors.Is(err, ErrRetryBudgetExhausted) {
+					t.Errorf("errors.Is(%v, ErrRetryBudgetExhausted) = false", err)
+				}
+				if got := status.Code(ClassifyError(err)); got != codes.Unavailable {
+					t.Errorf("ClassifyError() code = %v, want %v", got, codes.Unavailable)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Do: %v", err)
+			}
+			defer resp.Body.Close()
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNewRetryClientDisabled(t *testing.T) {
+	httpClient := &http.Client{}
+	if got := NewRetryClient(httpClient, RetryPolicy{}, nil); got != httpClient {
+		t.Error("NewRetryClient() without retries wrapped the client")
+	}
+}
@@ -0,0 +1,85 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// ServiceDeskClient covers the Jira Service Management endpoints that go-jira does not implement.
+// It reuses the authenticated go-jira client, so no extra credentials are needed.
+type ServiceDeskClient struct {
+	client *jira.Client
+}
+
+type Organization struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type Customer struct {
+	AccountID    string `json:"accountId"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+	Active       bool   `json:"active"`
+	TimeZone     string `json:"timeZone"`
+}
+
+// pagedResponse is the envelope servicedeskapi uses for every list endpoint.
+type pagedResponse[T any] struct {
+	Size       int  `json:"size"`
+	Start      int  `json:"start"`
+	Limit      int  `json:"limit"`
+	IsLastPage bool `json:"isLastPage"`
+	Values     []T  `json:"values"`
+}
+
+func NewServiceDeskClient(client *jira.Client) *ServiceDeskClient {
+	return &ServiceDeskClient{
+		client: client,
+	}
+}
+
+// ListOrganizations returns one page of organizations and whether it was the last one.
+func (c *ServiceDeskClient) ListOrganizations(ctx context.Context, start int, limit int) ([]Organization, bool, error) {
+	var res pagedResponse[Organization]
+	err := c.get(ctx, "rest/servicedeskapi/organization", start, limit, &res)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return res.Values, res.IsLastPage, nil
+}
+
+// ListOrganizationUsers returns one page of the customers in an organization and whether it was the last one.
+func (c *ServiceDeskClient) ListOrganizationUsers(ctx context.Context, organizationID string, start int, limit int) ([]Customer, bool, error) {
+	var res pagedResponse[Customer]
+	err := c.get(ctx, fmt.Sprintf("rest/servicedeskapi/organization/%s/user", url.PathEscape(organizationID)), start, limit, &res)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return res.Values, res.IsLastPage, nil
+}
+
+func (c *ServiceDeskClient) get(ctx context.Context, path string, start int, limit int, v interface{}) error {
+	query := url.Values{}
+	query.Set("start", strconv.Itoa(start))
+	query.Set("limit", strconv.Itoa(limit))
+
+	req, err := c.client.NewRequest(ctx, http.MethodGet, fmt.Sprintf("%s?%s", path, query.Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req, v)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+
+	return nil
+}
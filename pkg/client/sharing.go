@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// Share types covering every user of the site, or anyone, rather than a principal.
+const (
+	ShareTypeGlobal        = "global"
+	ShareTypeLoggedIn      = "loggedin"
+	ShareTypeAuthenticated = "authenticated"
+	ShareTypePublic        = "public"
+)
+
+// SharePermission is who a filter or dashboard is shared with, or who can edit it.
+// Type is e.g. "user", "group", "project", "projectRole", "global" or "loggedin".
+type SharePermission struct {
+	ID    int64  `json:"id"`
+	Type  string `json:"type"`
+	Group *struct {
+		GroupID string `json:"groupId"`
+		Name    string `json:"name"`
+	} `json:"group,omitempty"`
+	Project *struct {
+		ID   string `json:"id"`
+		Key  string `json:"key"`
+		Name string `json:"name"`
+	} `json:"project,omitempty"`
+	Role *struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	} `json:"role,omitempty"`
+	User *struct {
+		AccountID string `json:"accountId"`
+	} `json:"user,omitempty"`
+}
+
+// Filter is a saved filter with its share and edit permissions, which go-jira leaves out.
+type Filter struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Jql              string            `json:"jql"`
+	Owner            jira.User         `json:"owner"`
+	SharePermissions []SharePermission `json:"sharePermissions"`
+	EditPermissions  []SharePermission `json:"editPermissions"`
+}
+
+// GetFilter returns a filter with its share and edit permissions.
+func (c *Client) GetFilter(ctx context.Context, filterID string) (*Filter, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("filter/%s?expand=sharePermissions,editPermissions", url.PathEscape(filterID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := new(Filter)
+	resp, err := c.jira.Do(req, filter)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return filter, nil
+}
+
+// Dashboard is a dashboard with its share and edit permissions.
+type Dashboard struct {
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Description      string            `json:"description"`
+	Owner            *jira.User        `json:"owner,omitempty"`
+	SharePermissions []SharePermission `json:"sharePermissions"`
+	EditPermissions  []SharePermission `json:"editPermissions"`
+}
+
+type dashboardsResponse struct {
+	pageInfo
+	Dashboards []Dashboard `json:"dashboards"`
+}
+
+// ListDashboards returns one page of the dashboards visible to the connector's user.
+func (c *Client) ListDashboards(ctx context.Context, startAt int, maxResults int) ([]Dashboard, bool, error) {
+	query := url.Values{}
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("dashboard?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res dashboardsResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Dashboards, res.lastPage(len(res.Dashboards)), nil
+}
+
+// GetDashboard returns a dashboard with its share and edit permissions.
+func (c *Client) GetDashboard(ctx context.Context, dashboardID string) (*Dashboard, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("dashboard/%s", url.PathEscape(dashboardID)), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboard := new(Dashboard)
+	resp, err := c.jira.Do(req, dashboard)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return dashboard, nil
+}
@@ -0,0 +1,39 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+type searchStatusesResponse struct {
+	pageInfo
+	Values []jira.JiraStatus `json:"values"`
+}
+
+// SearchProjectStatuses returns a page of the statuses of a project in a status category,
+// e.g. DONE, and whether it is the last page. go-jira's SearchStatusesPaginated drops the
+// paging, so callers cannot tell when to stop.
+func (c *Client) SearchProjectStatuses(ctx context.Context, projectID string, statusCategory string, startAt int, maxResults int) ([]jira.JiraStatus, bool, error) {
+	query := url.Values{}
+	query.Set("projectId", projectID)
+	query.Set("statusCategory", statusCategory)
+	query.Set("startAt", strconv.Itoa(startAt))
+	query.Set("maxResults", strconv.Itoa(maxResults))
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("statuses/search?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var res searchStatusesResponse
+	resp, err := c.jira.Do(req, &res)
+	if err != nil {
+		return nil, false, jira.NewJiraError(resp, err)
+	}
+
+	return res.Values, res.lastPage(len(res.Values)), nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSearchProjectStatuses(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		wantStatuses int
+		wantLastPage bool
+	}{
+		{
+			name:         "last page",
+			body:         `{"startAt":0,"maxResults":2,"total":2,"isLast":true,"values":[{"id":"1","name":"Done"},{"id":"2","name":"Closed"}]}`,
+			wantStatuses: 2,
+			wantLastPage: true,
+		},
+		{
+			name:         "more pages",
+			body:         `{"startAt":0,"maxResults":2,"total":3,"isLast":false,"values":[{"id":"1","name":"Done"},{"id":"2","name":"Closed"}]}`,
+			wantStatuses: 2,
+		},
+		{
+			name:         "next page link",
+			body:         `{"startAt":0,"maxResults":2,"nextPage":"https://example.atlassian.net/rest/api/3/statuses/search?startAt=2","values":[{"id":"1","name":"Done"}]}`,
+			wantStatuses: 1,
+		},
+		{
+			name:         "empty",
+			body:         `{"startAt":0,"maxResults":2,"total":0,"values":[]}`,
+			wantLastPage: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, DeploymentTypeCloud, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				const wantQuery = "maxResults=2&projectId=10000&startAt=0&statusCategory=DONE"
+				if r.URL.Path != "/rest/api/3/statuses/search" || r.URL.RawQuery != wantQuery {
+					t.Errorf("request %s, want /rest/api/3/statuses/search?%s", r.URL, wantQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			statuses, lastPage, err := c.SearchProjectStatuses(context.Background(), "10000", "DONE", 0, 2)
+			if err != nil {
+				t.Fatalf("SearchProjectStatuses: %v", err)
+			}
+			if len(statuses) != tt.wantStatuses || lastPage != tt.wantLastPage {
+				t.Errorf("got %d statuses, last page %v; want %d, %v", len(statuses), lastPage, tt.wantStatuses, tt.wantLastPage)
+			}
+		})
+	}
+}
@@ -0,0 +1,245 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrUserNotFound is returned when Jira answers 404 for a user.
+var ErrUserNotFound = errors.New("user not found")
+
+// alreadyExistsMessage is part of the 400 Jira answers when creating a user or a group that already exists.
+const alreadyExistsMessage = "already exists"
+
+// CreateUserBody is the request body of POST /rest/api/{2,3}/user.
+// go-jira's User has no products field, which Jira Cloud requires.
+// Data Center takes a username instead of products.
+type CreateUserBody struct {
+	EmailAddress string   `json:"emailAddress"`
+	Products     []string `json:"products,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	// DisplayName is used by Data Center. Cloud ignores it, the Atlassian account owns the name there.
+	DisplayName string `json:"displayName,omitempty"`
+	// Notification emails the invitation on Data Center. Cloud always emails invited users.
+	Notification bool `json:"notification,omitempty"`
+}
+
+// CreateUser invites a user to the site. The returned user can be minimal,
+// e.g. without an account type, so callers should not rely on every field being set.
+// A user that already exists is reported as AlreadyExists.
+func (c *Client) CreateUser(ctx context.Context, body *CreateUserBody) (*jira.User, error) {
+	if c.IsServer() {
+		if body.Name == "" {
+			body.Name = body.EmailAddress
+		}
+		body.Products = nil
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodPost, c.apiPath("user"), body)
+	if err != nil {
+		return nil, err
+	}
+
+	user := new(jira.User)
+	resp, err := c.jira.Do(req, user)
+	if err != nil {
+		jerr := jira.NewJiraError(resp, err)
+		if resp != nil && resp.StatusCode == http.StatusBadRequest && alreadyExists(jerr) {
+			return nil, status.Errorf(codes.AlreadyExists, "user %s already exists: %v", body.EmailAddress, jerr)
+		}
+		return nil, jerr
+	}
+
+	return user, nil
+}
+
+// alreadyExists reports whether a create error says the user or group is already there.
+func alreadyExists(err error) bool {
+	var jiraErr *jira.Error
+	if !errors.As(err, &jiraErr) {
+		return false
+	}
+
+	for _, message := range jiraErr.ErrorMessages {
+		if strings.Contains(strings.ToLower(message), alreadyExistsMessage) {
+			return true
+		}
+	}
+	for _, message := range jiraErr.Errors {
+		if strings.Contains(strings.ToLower(message), alreadyExistsMessage) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetUser returns the full user, with the given expansions such as "groups" or "applicationRoles".
+func (c *Client) GetUser(ctx context.Context, accountID string, expand []string) (*jira.User, error) {
+	query := c.userLookupQuery(accountID)
+	if len(expand) > 0 {
+		query.Set("expand", strings.Join(expand, ","))
+	}
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("user?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user := new(jira.User)
+	resp, err := c.jira.Do(req, user)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, ErrUserNotFound
+		}
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return user, nil
+}
+
+// GetCurrentUser returns the user the client authenticates as. go-jira's only calls the
+// Cloud endpoint.
+func (c *Client) GetCurrentUser(ctx context.Context) (*jira.User, error) {
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("myself"), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	user := new(jira.User)
+	resp, err := c.jira.Do(req, user)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	return user, nil
+}
+
+// DeleteUser removes a user from the site. Jira answers 204 on success and 404 when
+// the user does not exist, which is returned as ErrUserNotFound.
+func (c *Client) DeleteUser(ctx context.Context, accountID string) error {
+	req, err := c.jira.NewRequest(ctx, http.MethodDelete, c.apiPath("user?%s", c.userLookupQuery(accountID).Encode()), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return ErrUserNotFound
+		}
+		return jira.NewJiraError(resp, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// FindUsers returns one page of users. Data Center needs a username pattern,
+// where "." matches every user.
+func (c *Client) FindUsers(ctx context.Context, startAt int, maxResults int) ([]jira.User, error) {
+	if c.IsServer() {
+		users, _, err := c.jira.User.Find(ctx, "", jira.WithUsername("."), jira.WithStartAt(startAt), jira.WithMaxResults(maxResults))
+		return users, err
+	}
+
+	users, _, err := c.jira.User.Find(ctx, "", jira.WithStartAt(startAt), jira.WithMaxResults(maxResults))
+	return users, err
+}
+
+type userPropertyKeysResponse struct {
+	Keys []struct {
+		Key string `json:"key"`
+	} `json:"keys"`
+}
+
+type userPropertyResponse struct {
+	Key   string      `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+// GetUserProperties returns every entity property stored on a user, keyed by property key.
+// Jira only lists the keys, so each value costs one more request.
+func (c *Client) GetUserProperties(ctx context.Context, accountID string) (map[string]interface{}, error) {
+	query := c.userQuery(accountID)
+
+	req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("user/properties?%s", query.Encode()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var keys userPropertyKeysResponse
+	resp, err := c.jira.Do(req, &keys)
+	if err != nil {
+		return nil, jira.NewJiraError(resp, err)
+	}
+
+	rv := make(map[string]interface{}, len(keys.Keys))
+	for _, key := range keys.Keys {
+		req, err := c.jira.NewRequest(ctx, http.MethodGet, c.apiPath("user/properties/%s?%s", url.PathEscape(key.Key), query.Encode()), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var property userPropertyResponse
+		resp, err := c.jira.Do(req, &property)
+		if err != nil {
+			return nil, jira.NewJiraError(resp, err)
+		}
+
+		rv[key.Key] = property.Value
+	}
+
+	return rv, nil
+}
+
+// SetUserProperty creates or replaces an entity property on a user. value must marshal to JSON.
+func (c *Client) SetUserProperty(ctx context.Context, accountID string, key string, value interface{}) error {
+	query := c.userQuery(accountID)
+
+	req, err := c.jira.NewRequest(ctx, http.MethodPut, c.apiPath("user/properties/%s?%s", url.PathEscape(key), query.Encode()), value)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.jira.Do(req, nil)
+	if err != nil {
+		return jira.NewJiraError(resp, err)
+	}
+	defer resp.Body.Close()
+
+	return nil
+}
+
+// userQuery identifies a user by account ID, or by key on Data Center, for the user
+// properties endpoints.
+func (c *Client) userQuery(accountID string) url.Values {
+	query := url.Values{}
+	if c.IsServer() {
+		query.Set("userKey", accountID)
+	} else {
+		query.Set("accountId", accountID)
+	}
+
+	return query
+}
+
+// userLookupQuery identifies a user by account ID for GET and DELETE /user. Data Center
+// takes the key there as "key", not "userKey".
+func (c *Client) userLookupQuery(accountID string) url.Values {
+	query := url.Values{}
+	if c.IsServer() {
+		query.Set("key", accountID)
+	} else {
+		query.Set("accountId", accountID)
+	}
+
+	return query
+}
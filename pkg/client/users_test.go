@@ -0,0 +1,197 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateUserBody(t *testing.T) {
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		wantPath       string
+		want           map[string]interface{}
+	}{
+		{
+			name:           "cloud",
+			deploymentType: DeploymentTypeCloud,
+			wantPath:       "/rest/api/3/user",
+			want: map[string]interface{}{
+				"emailAddress": "new@example.com",
+				"products":     []interface{}{"jira-software"},
+				"notification": true,
+			},
+		},
+		{
+			name:           "data center",
+			deploymentType: DeploymentTypeServer,
+			wantPath:       "/rest/api/2/user",
+			want: map[string]interface{}{
+				"emailAddress": "new@example.com",
+				"name":         "new@example.com",
+				"notification": true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got map[string]interface{}
+			c := newTestClient(t, tt.deploymentType, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != tt.wantPath {
+					t.Errorf("request = %s %s, want POST %s", r.Method, r.URL.Path, tt.wantPath)
+				}
+				if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+					t.Errorf("decoding body: %v", err)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"accountId":"a1"}`))
+			}))
+
+			_, err := c.CreateUser(context.Background(), &CreateUserBody{
+				EmailAddress: "new@example.com",
+				Products:     []string{"jira-software"},
+				Notification: true,
+			})
+			if err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(tt.want)
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("body = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestCreateUserResponses(t *testing.T) {
+	tests := []struct {
+		name        string
+		statusCode  int
+		body        string
+		wantAccount string
+		wantCode    codes.Code
+	}{
+		{
+			name:        "created",
+			statusCode:  http.StatusCreated,
+			body:        `{"accountId":"a1","emailAddress":"new@example.com"}`,
+			wantAccount: "a1",
+			wantCode:    codes.OK,
+		},
+		{
+			name:       "existing user",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errorMessages":[],"errors":{"email":"A user with that email address already exists."}}`,
+			wantCode:   codes.AlreadyExists,
+		},
+		{
+			name:       "invalid request",
+			statusCode: http.StatusBadRequest,
+			body:       `{"errorMessages":["Email address is not valid."]}`,
+			wantCode:   codes.InvalidArgument,
+		},
+		{
+			name:       "forbidden",
+			statusCode: http.StatusForbidden,
+			body:       `{"errorMessages":["You do not have permission to create users."]}`,
+			wantCode:   codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestClient(t, DeploymentTypeCloud, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			user, err := c.CreateUser(context.Background(), &CreateUserBody{EmailAddress: "new@example.com"})
+			if got := status.Code(WrapError(err, "failed to create user")); err != nil && got != tt.wantCode {
+				t.Errorf("WrapError(CreateUser()) code = %v, want %v", got, tt.wantCode)
+			}
+			if tt.wantCode != codes.OK {
+				if err == nil {
+					t.Fatalf("CreateUser() = %+v, want an error", user)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("CreateUser: %v", err)
+			}
+			if user == nil || user.AccountID != tt.wantAccount {
+				t.Errorf("CreateUser() = %+v, want account %s", user, tt.wantAccount)
+			}
+		})
+	}
+}
+
+func TestGetAndDeleteUser(t *testing.T) {
+	tests := []struct {
+		name           string
+		deploymentType DeploymentType
+		statusCode     int
+		wantPath       string
+		wantQuery      string
+		wantErr        error
+	}{
+		{name: "cloud", deploymentType: DeploymentTypeCloud, statusCode: http.StatusOK, wantPath: "/rest/api/3/user", wantQuery: "accountId=a-1"},
+		{name: "data center", deploymentType: DeploymentTypeServer, statusCode: http.StatusOK, wantPath: "/rest/api/2/user", wantQuery: "key=a-1"},
+		{name: "cloud not found", deploymentType: DeploymentTypeCloud, statusCode: http.StatusNotFound, wantPath: "/rest/api/3/user", wantQuery: "accountId=a-1", wantErr: ErrUserNotFound},
+		{name: "data center not found", deploymentType: DeploymentTypeServer, statusCode: http.StatusNotFound, wantPath: "/rest/api/2/user", wantQuery: "key=a-1", wantErr: ErrUserNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var methods []string
+			c := newTestClient(t, tt.deploymentType, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				methods = append(methods, r.Method)
+				query := r.URL.Query()
+				query.Del("expand")
+				if r.URL.Path != tt.wantPath || query.Encode() != tt.wantQuery {
+					t.Errorf("request = %s?%s, want %s?%s", r.URL.Path, query.Encode(), tt.wantPath, tt.wantQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case tt.statusCode != http.StatusOK:
+					w.WriteHeader(tt.statusCode)
+					_, _ = w.Write([]byte(`{"errorMessages":["The user does not exist"]}`))
+				case r.Method == http.MethodDelete:
+					w.WriteHeader(http.StatusNoContent)
+				default:
+					if got := r.URL.Query().Get("expand"); got != "groups,applicationRoles" {
+						t.Errorf("expand = %q, want %q", got, "groups,applicationRoles")
+					}
+					_, _ = w.Write([]byte(`{"accountId":"a-1","key":"a-1","displayName":"Alice"}`))
+				}
+			}))
+
+			user, err := c.GetUser(context.Background(), "a-1", []string{"groups", "applicationRoles"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GetUser() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && user.DisplayName != "Alice" {
+				t.Errorf("GetUser() = %+v, want Alice", user)
+			}
+
+			if err := c.DeleteUser(context.Background(), "a-1"); !errors.Is(err, tt.wantErr) {
+				t.Errorf("DeleteUser() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if want := []string{http.MethodGet, http.MethodDelete}; !slices.Equal(methods, want) {
+				t.Errorf("methods = %v, want %v", methods, want)
+			}
+		})
+	}
+}
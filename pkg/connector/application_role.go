@@ -0,0 +1,162 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var resourceTypeApplicationRole = &v2.ResourceType{
+	Id:          "application-role",
+	DisplayName: "Application Role",
+}
+
+// applicationRoleResourceType models product access as a license entitlement, granted to the
+// members of the product's default groups. Those groups then have no member grants, see
+// groupResourceType.modelDefaultGroupsAsLicenses.
+type applicationRoleResourceType struct {
+	resourceType *v2.ResourceType
+	apiClient    *client.Client
+	session      *sessionStore
+}
+
+func applicationRoleResource(role *client.ApplicationRole) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		role.Name,
+		resourceTypeApplicationRole,
+		role.Key,
+		rs.WithDescription(fmt.Sprintf("%d users", role.UserCount)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (a *applicationRoleResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return a.resourceType
+}
+
+func applicationRoleBuilder(apiClient *client.Client, session *sessionStore) *applicationRoleResourceType {
+	return &applicationRoleResourceType{
+		resourceType: resourceTypeApplicationRole,
+		apiClient:    apiClient,
+		session:      session,
+	}
+}
+
+func (a *applicationRoleResourceType) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	ctx, source := withSyncSource(ctx)
+	roles, err := a.session.getApplicationRoles(ctx, a.apiClient)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list application roles")
+	}
+
+	var resources []*v2.Resource
+	for i := range roles {
+		resource, err := applicationRoleResource(&roles[i])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+	source.annotate(resources)
+
+	return resources, "", nil, nil
+}
+
+func (a *applicationRoleResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	options := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Has a %s license", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s %s", resource.DisplayName, licenseEntitlement)),
+	}
+
+	return []*v2.Entitlement{ent.NewPermissionEntitlement(resource, licenseEntitlement, options...)}, "", nil, nil
+}
+
+// Grants pages through the members of each default group of the role in turn. A user in
+// several default groups gets the same grant ID from each, so it is only granted once.
+func (a *applicationRoleResourceType) Grants(ctx context.Context, resource *v2.Resource, p *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	bag := &pagination.Bag{}
+	err := bag.Unmarshal(p.Token)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if bag.Current() == nil {
+		roles, err := a.session.getApplicationRoles(ctx, a.apiClient)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, "failed to list application roles")
+		}
+
+		var groupIDs []string
+		for i := range roles {
+			if roles[i].Key == resource.Id.Resource {
+				groupIDs = roles[i].DefaultGroupIDs()
+			}
+		}
+
+		// Pushed in reverse, so the groups are paged through in order.
+		for i := len(groupIDs) - 1; i >= 0; i-- {
+			bag.Push(pagination.PageState{
+				ResourceTypeID: resourceTypeGroup.Id,
+				ResourceID:     groupIDs[i],
+			})
+		}
+
+		if bag.Current() == nil {
+			return nil, "", nil, nil
+		}
+	}
+
+	offset, err := getOffsetFromPageToken(bag.PageToken())
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	groupMembers, lastPage, err := a.apiClient.GetGroupMembers(ctx, bag.Current().ResourceID, int(offset), resourcePageSize)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get default group members")
+	}
+
+	var rv []*v2.Grant
+	for i := range groupMembers {
+		user, err := userResource(ctx, userFromGroupMember(&groupMembers[i]))
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		rv = append(rv, grant.NewGrant(resource, licenseEntitlement, user.Id))
+	}
+
+	if !lastPage {
+		nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(groupMembers)))
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		return rv, nextPage, nil, nil
+	}
+
+	bag.Pop()
+	if bag.Current() == nil {
+		return rv, "", nil, nil
+	}
+
+	nextPage, err := bag.Marshal()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return rv, nextPage, nil, nil
+}
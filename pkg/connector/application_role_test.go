@@ -0,0 +1,140 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// applicationRoleHandler serves one application role whose default group is g-default,
+// and the members of g-default and g-other.
+func applicationRoleHandler(t *testing.T, rolesStatus int) http.Handler {
+	members := map[string][]string{
+		"g-default": {"a-1", "a-2"},
+		"g-other":   {"a-2", "a-3"},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/applicationrole":
+			w.WriteHeader(rolesStatus)
+			if rolesStatus == http.StatusOK {
+				_, _ = w.Write([]byte(`[{"key":"jira-software","name":"Jira Software","defaultGroupsDetails":[{"name":"default","groupId":"g-default"}]}]`))
+			}
+		case "/rest/api/3/group/member":
+			var values []string
+			for _, accountID := range members[r.URL.Query().Get("groupId")] {
+				values = append(values, fmt.Sprintf(`{"accountId":%q,"displayName":%q,"active":true}`, accountID, accountID))
+			}
+			_, _ = fmt.Fprintf(w, `{"isLast":true,"startAt":0,"maxResults":50,"values":[%s]}`, strings.Join(values, ","))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func grantPrincipals(grants []*v2.Grant) []string {
+	rv := make([]string, 0, len(grants))
+	for _, g := range grants {
+		rv = append(rv, g.GetPrincipal().GetId().GetResource())
+	}
+	sort.Strings(rv)
+	return rv
+}
+
+// TestDefaultGroupsAsLicenses checks a default group member gets product access through
+// either the license or the group membership, never both and never neither.
+func TestDefaultGroupsAsLicenses(t *testing.T) {
+	tests := []struct {
+		name        string
+		rolesStatus int
+		wantMembers map[string][]string
+		wantLicense []string
+	}{
+		{
+			name:        "default group members are licensed",
+			rolesStatus: http.StatusOK,
+			wantMembers: map[string][]string{
+				"g-default": {},
+				"g-other":   {"a-2", "a-3"},
+			},
+			wantLicense: []string{"a-1", "a-2"},
+		},
+		{
+			name:        "application roles unavailable",
+			rolesStatus: http.StatusInternalServerError,
+			wantMembers: map[string][]string{
+				"g-default": {"a-1", "a-2"},
+				"g-other":   {"a-2", "a-3"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			j := newTestJira(t, applicationRoleHandler(t, tt.rolesStatus))
+			groups := &groupResourceType{
+				resourceType:                 resourceTypeGroup,
+				client:                       j.client,
+				apiClient:                    j.apiClient,
+				session:                      j.session,
+				modelDefaultGroupsAsLicenses: true,
+			}
+
+			memberGrants := make(map[string][]string)
+			for groupID := range tt.wantMembers {
+				resource, err := groupResource(ctx, &jira.Group{ID: groupID, Name: groupID}, nil)
+				if err != nil {
+					t.Fatalf("groupResource: %v", err)
+				}
+				grants, _, _, err := groups.Grants(ctx, resource, &pagination.Token{})
+				if err != nil {
+					t.Fatalf("group Grants(%s): %v", groupID, err)
+				}
+				memberGrants[groupID] = grantPrincipals(grants)
+				if got, want := memberGrants[groupID], tt.wantMembers[groupID]; strings.Join(got, ",") != strings.Join(want, ",") {
+					t.Errorf("member grants of %s = %v, want %v", groupID, got, want)
+				}
+			}
+
+			var licensed []string
+			roles := applicationRoleBuilder(j.apiClient, j.session)
+			role, err := applicationRoleResource(&client.ApplicationRole{Key: "jira-software", Name: "Jira Software"})
+			if err != nil {
+				t.Fatalf("applicationRoleResource: %v", err)
+			}
+			grants, _, _, err := roles.Grants(ctx, role, &pagination.Token{})
+			if tt.rolesStatus == http.StatusOK {
+				if err != nil {
+					t.Fatalf("application role Grants: %v", err)
+				}
+				licensed = grantPrincipals(grants)
+			} else if err == nil {
+				t.Errorf("application role Grants succeeded without the application roles")
+			}
+			if strings.Join(licensed, ",") != strings.Join(tt.wantLicense, ",") {
+				t.Errorf("license grants = %v, want %v", licensed, tt.wantLicense)
+			}
+
+			for _, accountID := range []string{"a-1", "a-2"} {
+				viaLicense := slices.Contains(licensed, accountID)
+				viaGroup := slices.Contains(memberGrants["g-default"], accountID)
+				if viaLicense == viaGroup {
+					t.Errorf("%s: license %v, g-default membership %v; want exactly one", accountID, viaLicense, viaGroup)
+				}
+			}
+		})
+	}
+}
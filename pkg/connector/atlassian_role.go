@@ -0,0 +1,158 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+var resourceTypeAtlassianRole = &v2.ResourceType{
+	Id:          "atlassian-role",
+	DisplayName: "Atlassian Role",
+	Traits: []v2.ResourceType_Trait{
+		v2.ResourceType_TRAIT_ROLE,
+	},
+}
+
+// atlassianRoleResourceType is an org level role, e.g. atlassian/org-admin, with the
+// assignments on the org and on the site. Roles are only known through their assignments,
+// so roles nobody holds are not listed.
+type atlassianRoleResourceType struct {
+	resourceType    *v2.ResourceType
+	atlassianClient *atlassianclient.AtlassianClient
+	session         *sessionStore
+	siteID          string
+}
+
+func atlassianRoleResource(roleID string) (*v2.Resource, error) {
+	resource, err := rs.NewRoleResource(roleID, resourceTypeAtlassianRole, roleID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (a *atlassianRoleResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return a.resourceType
+}
+
+func atlassianRoleBuilder(atlassianClient *atlassianclient.AtlassianClient, session *sessionStore, siteID string) *atlassianRoleResourceType {
+	return &atlassianRoleResourceType{
+		resourceType:    resourceTypeAtlassianRole,
+		atlassianClient: atlassianClient,
+		session:         session,
+		siteID:          siteID,
+	}
+}
+
+func (a *atlassianRoleResourceType) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	ctx, source := withSyncSource(ctx)
+	assignments, err := a.session.getRoleAssignments(ctx, a.atlassianClient, a.siteID)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list org role assignments")
+	}
+
+	roles := make(map[string]bool)
+	for _, assignment := range assignments {
+		roles[assignment.RoleID] = true
+	}
+
+	roleIDs := make([]string, 0, len(roles))
+	for roleID := range roles {
+		roleIDs = append(roleIDs, roleID)
+	}
+	sort.Strings(roleIDs)
+
+	var resources []*v2.Resource
+	for _, roleID := range roleIDs {
+		resource, err := atlassianRoleResource(roleID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+	source.annotate(resources)
+
+	return resources, "", nil, nil
+}
+
+func (a *atlassianRoleResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup),
+		ent.WithDescription(fmt.Sprintf("Assigned %s Atlassian role", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s role %s", resource.DisplayName, assignedEntitlement)),
+	}
+
+	return []*v2.Entitlement{
+		ent.NewAssignmentEntitlement(resource, assignedEntitlement, assigmentOptions...),
+	}, "", nil, nil
+}
+
+// Grants has a grant per assignment of the role. Group IDs of the org directory are the
+// Jira group IDs, so group grants expand through the group resource.
+func (a *atlassianRoleResourceType) Grants(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	assignments, err := a.session.getRoleAssignments(ctx, a.atlassianClient, a.siteID)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list org role assignments")
+	}
+
+	// A principal holding the role on both the org and the site is granted once.
+	seen := make(map[string]bool)
+
+	var rv []*v2.Grant
+	for _, assignment := range assignments {
+		if assignment.RoleID != resource.Id.Resource {
+			continue
+		}
+
+		key := assignment.PrincipalType + "/" + assignment.PrincipalID
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		switch assignment.PrincipalType {
+		case atlassianclient.PrincipalTypeUser:
+			user, err := userResource(ctx, userFromAccountID(assignment.PrincipalID))
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(resource, assignedEntitlement, user.Id))
+		case atlassianclient.PrincipalTypeGroup:
+			group, err := groupResource(ctx, &jira.Group{
+				ID: assignment.PrincipalID,
+			}, nil)
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				assignedEntitlement,
+				group.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("group:%s:%s", group.Id.Resource, memberEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		}
+	}
+
+	return rv, "", nil, nil
+}
@@ -0,0 +1,137 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+var resourceTypeComponent = &v2.ResourceType{
+	Id:          "component",
+	DisplayName: "Component",
+	Traits: []v2.ResourceType_Trait{
+		v2.ResourceType_TRAIT_ROLE,
+	},
+}
+
+// componentResourceType lists the components of a project as its children, with the
+// component lead.
+type componentResourceType struct {
+	resourceType *v2.ResourceType
+	apiClient    *client.Client
+}
+
+func componentResource(component *jira.ProjectComponent, projectID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"name":          component.Name,
+		"description":   component.Description,
+		"assignee_type": component.AssigneeType,
+	}
+	if leadID := componentLeadID(&component.Lead); leadID != "" {
+		profile["lead_id"] = leadID
+	}
+
+	roleTraitOptions := []rs.RoleTraitOption{
+		rs.WithRoleProfile(profile),
+	}
+
+	resource, err := rs.NewRoleResource(
+		component.Name,
+		resourceTypeComponent,
+		component.ID,
+		roleTraitOptions,
+		rs.WithParentResourceID(projectID),
+		rs.WithDescription(component.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// componentLeadID returns the user resource ID of a component lead. Data Center leads
+// have no account ID, only a key and, on older versions, just a name.
+func componentLeadID(lead *jira.User) string {
+	switch {
+	case lead.AccountID != "":
+		return lead.AccountID
+	case lead.Key != "":
+		return lead.Key
+	default:
+		return lead.Name
+	}
+}
+
+func (c *componentResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return c.resourceType
+}
+
+func componentBuilder(apiClient *client.Client) *componentResourceType {
+	return &componentResourceType{
+		resourceType: resourceTypeComponent,
+		apiClient:    apiClient,
+	}
+}
+
+// List returns the components of the parent project. Components only exist within a
+// project, so there is nothing to list without one.
+func (c *componentResourceType) List(ctx context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID == nil || parentResourceID.ResourceType != resourceTypeProject.Id {
+		return nil, "", nil, nil
+	}
+
+	components, err := c.apiClient.ListProjectComponents(ctx, parentResourceID.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list project components")
+	}
+
+	var resources []*v2.Resource
+	for i := range components {
+		resource, err := componentResource(&components[i], parentResourceID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, "", nil, nil
+}
+
+func (c *componentResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Lead of %s component", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s component %s", resource.DisplayName, leadEntitlement)),
+	}
+
+	return []*v2.Entitlement{
+		ent.NewAssignmentEntitlement(resource, leadEntitlement, assigmentOptions...),
+	}, "", nil, nil
+}
+
+// Grants returns the component lead, which List kept in the profile, so components
+// take no request of their own.
+func (c *componentResourceType) Grants(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	roleTrait, err := rs.GetRoleTrait(resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	leadID, ok := rs.GetProfileStringValue(roleTrait.GetProfile(), "lead_id")
+	if !ok || leadID == "" {
+		return nil, "", nil, nil
+	}
+
+	lead := &v2.ResourceId{ResourceType: resourceTypeUser.Id, Resource: leadID}
+	return []*v2.Grant{grant.NewGrant(resource, leadEntitlement, lead)}, "", nil, nil
+}
@@ -0,0 +1,63 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+// TestComponentLeadGrants checks component leads are granted from the listed components,
+// without a request per component.
+func TestComponentLeadGrants(t *testing.T) {
+	tests := []struct {
+		name      string
+		lead      string
+		wantLeads []string
+	}{
+		{name: "cloud lead", lead: `{"accountId":"a-1","displayName":"Alice"}`, wantLeads: []string{"a-1"}},
+		{name: "data center lead", lead: `{"key":"JIRAUSER10100","name":"alice","displayName":"Alice"}`, wantLeads: []string{"JIRAUSER10100"}},
+		{name: "data center lead without a key", lead: `{"name":"alice","displayName":"Alice"}`, wantLeads: []string{"alice"}},
+		{name: "no lead", lead: `{}`, wantLeads: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/project/10000/components") {
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`[{"id":"20000","name":"Backend","lead":` + tt.lead + `}]`))
+			}))
+			c := componentBuilder(j.apiClient)
+			ctx := context.Background()
+
+			resources, _, _, err := c.List(ctx, &v2.ResourceId{ResourceType: resourceTypeProject.Id, Resource: "10000"}, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(resources) != 1 {
+				t.Fatalf("List() = %d components, want 1", len(resources))
+			}
+
+			grants, _, _, err := c.Grants(ctx, resources[0], &pagination.Token{})
+			if err != nil {
+				t.Fatalf("Grants: %v", err)
+			}
+			if got := grantPrincipals(grants); strings.Join(got, ",") != strings.Join(tt.wantLeads, ",") {
+				t.Errorf("leads = %v, want %v", got, tt.wantLeads)
+			}
+			for _, g := range grants {
+				if got := g.GetPrincipal().GetId().GetResourceType(); got != resourceTypeUser.Id {
+					t.Errorf("principal type = %s, want %s", got, resourceTypeUser.Id)
+				}
+			}
+		})
+	}
+}
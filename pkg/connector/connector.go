@@ -2,24 +2,212 @@ package connector
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// authModeBasic is an email and API token sent with basic auth.
+const authModeBasic = "basic"
+
 type (
 	Jira struct {
-		client *jira.Client
+		client            *jira.Client
+		apiClient         *client.Client
+		serviceDeskClient *client.ServiceDeskClient
+		session           *sessionStore
+
+		// baseURL and authMode describe how Jira is reached, for logs and the sync summary.
+		baseURL  string
+		authMode string
+
+		// atlassianClient is only set when an org ID and org API key are configured.
+		atlassianClient *atlassianclient.AtlassianClient
+		siteID          string
+
+		// additionalSites are the other sites of the org synced alongside this one. Their
+		// resource IDs are prefixed with their site ID, see multiSiteSyncer.
+		additionalSites []*Jira
+
+		syncJSMOrganizations       bool
+		skipFullSync               bool
+		projectKeys                []string
+		syncConcurrency            int
+		syncFilters                bool
+		syncDashboards             bool
+		syncPermissionSchemes      bool
+		syncNotificationSchemes    bool
+		syncComponents             bool
+		syncProjectRoles           bool
+		syncIssueSecurity          bool
+		syncAtlassianRoles         bool
+		expandNestedGroups         bool
+		annotateManagedGroupGrants bool
+		// syncDomains is only set on the primary site, as domains belong to the org.
+		syncDomains              bool
+		ticketIncludeWatchers    bool
+		ticketDropInvalidFields  bool
+		excludedIssueTypes       []string
+		ticketConsolidateSchemas bool
+		sendInvitationOnCreate   bool
+		syncAllProjects          bool
+		syncIssueTypes           bool
+		syncUserProperties       bool
+		explainParticipantGrants bool
+		claimStatusFilter        []string
+		groupSizeLogThreshold    int
+
+		modelDefaultGroupsAsLicenses bool
+
+		timeouts JiraTimeouts
+
+		// metrics is shared by every site, so it covers the whole sync.
+		metrics *client.Metrics
+		// permissionGaps is shared by every site, like metrics.
+		permissionGaps *permissionGaps
+	}
+
+	// JiraTimeouts bound single Jira requests of a category, for sites where e.g. a page of
+	// users takes longer than the caller's deadline allows. Zero keeps the caller's deadline.
+	JiraTimeouts struct {
+		// UserList bounds each page of users.
+		UserList time.Duration
+		// GroupList bounds each page of groups and of group members.
+		GroupList time.Duration
+		// TicketCreate bounds the creation of an issue.
+		TicketCreate time.Duration
 	}
 
 	JiraBuilder interface {
-		New() (*Jira, error)
+		New(ctx context.Context) (*Jira, error)
 	}
 
 	JiraOptions struct {
 		Url string
+
+		// AdditionalUrls are other Jira Cloud sites of the same org, synced with the same
+		// credentials. They need the org admin API to resolve their site IDs.
+		AdditionalUrls []string
+
+		// DeploymentType is "cloud" or "server" (Jira Data Center). Empty means cloud.
+		DeploymentType string
+
+		SyncJSMOrganizations bool
+
+		// SkipFullSync is set for ticketing only deployments, whose token can only
+		// browse and create issues in ProjectKeys.
+		SkipFullSync bool
+		ProjectKeys  []string
+
+		// SyncAllProjects keeps syncing every project when ProjectKeys is set, so the keys
+		// only apply to ticketing. The command line defaults it to true, so that setting
+		// the keys does not narrow the sync of existing deployments.
+		SyncAllProjects bool
+
+		// SyncConcurrency bounds how many projects, or pages of users, are fetched at once.
+		SyncConcurrency int
+
+		// WarmUpBudget caps the cache warm-up at the start of a sync. Zero skips it.
+		WarmUpBudget time.Duration
+
+		SyncFilters bool
+
+		// SyncDashboards syncs dashboards and who they are shared with or editable by.
+		SyncDashboards bool
+
+		SyncPermissionSchemes bool
+
+		// SyncNotificationSchemes syncs notification schemes, and tags each project with its scheme.
+		SyncNotificationSchemes bool
+
+		// SyncComponents syncs the components of each project, as children of the project.
+		SyncComponents bool
+
+		// SyncProjectRoles syncs the roles of each project and their actors.
+		SyncProjectRoles bool
+
+		// SyncIssueSecurity syncs issue security levels and who can see their issues.
+		SyncIssueSecurity bool
+
+		SyncIssueTypes bool
+
+		// SyncUserProperties attaches each user's entity properties, at one or more requests per user.
+		SyncUserProperties bool
+
+		// ExplainParticipantGrants annotates participate grants with the permission scheme holder behind them.
+		ExplainParticipantGrants bool
+
+		// RateLimit caps the requests sent to Jira, weighing the heaviest endpoints more.
+		RateLimit client.RateLimitPolicy
+
+		// Retry bounds the retries of requests Jira throttled or could not serve.
+		Retry client.RetryPolicy
+
+		// GroupSizeLogThreshold logs the synced member count of groups at least this large. Zero disables it.
+		GroupSizeLogThreshold int
+
+		// TicketIncludeWatchers adds the issue watchers to tickets, at one extra request per ticket.
+		TicketIncludeWatchers bool
+
+		// TicketDropInvalidFields creates a ticket Jira rejects for some of its custom fields
+		// again without them.
+		TicketDropInvalidFields bool
+
+		// ExcludedIssueTypes are the names of the issue types left out of ticket schemas,
+		// ignoring case, on top of those Jira flags as subtasks.
+		ExcludedIssueTypes []string
+
+		// TicketConsolidateSchemas lists one ticket schema for an issue type whose schemas
+		// are identical in several projects, with a custom field picking the project.
+		TicketConsolidateSchemas bool
+
+		// TicketSchemaCacheTTL is how long ticket schemas and project statuses are cached. Zero disables the cache.
+		TicketSchemaCacheTTL time.Duration
+
+		// AtlassianOrgID and AtlassianAPIToken enable the Atlassian org admin API,
+		// which enriches users and groups with org directory data.
+		AtlassianOrgID    string
+		AtlassianAPIToken string
+
+		// SyncAtlassianRoles syncs the org role assignments, e.g. org admins. It needs the org admin API.
+		SyncAtlassianRoles bool
+
+		// ExpandNestedGroups grants group memberships to nested groups, expanded to their
+		// members. It needs the org admin API.
+		ExpandNestedGroups bool
+
+		// AnnotateManagedGroupGrants adds the owning directory to the grants of groups synced
+		// from an identity provider. It needs the org admin API.
+		AnnotateManagedGroupGrants bool
+
+		// ClaimStatusFilter limits the synced users, and the group, site and role grants to
+		// users, to these org directory claim statuses. It needs the org admin API.
+		ClaimStatusFilter []string
+
+		// SendInvitationOnCreate emails the invitation to accounts created by CreateAccount on
+		// Data Center. Cloud always emails invited users.
+		SendInvitationOnCreate bool
+
+		// ModelDefaultGroupsAsLicenses syncs product access as license grants of application roles,
+		// instead of member grants of the products' default groups.
+		ModelDefaultGroupsAsLicenses bool
+
+		Timeouts JiraTimeouts
 	}
 
 	JiraBasicAuthBuilder struct {
@@ -30,48 +218,416 @@ type (
 	}
 )
 
-func (b *JiraBasicAuthBuilder) New() (*Jira, error) {
+func (b *JiraBasicAuthBuilder) New(ctx context.Context) (*Jira, error) {
 	transport := jira.BasicAuthTransport{
 		Username: b.Username,
 		APIToken: b.ApiToken,
 	}
 
-	client, err := jira.NewClient(b.Base.Url, transport.Client())
+	deploymentType, err := client.ParseDeploymentType(b.Base.DeploymentType)
 	if err != nil {
-		return nil, wrapError(err, "error creating jira client")
+		return nil, client.WrapError(err, "invalid configuration")
 	}
 
-	return &Jira{
-		client: client,
-	}, nil
+	syncConcurrency := b.Base.SyncConcurrency
+	if syncConcurrency < 1 {
+		syncConcurrency = 1
+	}
+
+	metrics := client.NewMetrics()
+
+	// Retries go through the rate limiter, so they count against the same budget.
+	httpClient := client.NewRetryClient(
+		client.NewRateLimitedClient(client.NewMetricsClient(client.NewAuthErrorClient(transport.Client()), metrics), b.Base.RateLimit),
+		b.Base.Retry,
+		metrics,
+	)
+
+	jiraClient, err := jira.NewClient(b.Base.Url, httpClient)
+	if err != nil {
+		return nil, client.WrapError(err, "error creating jira client")
+	}
+
+	var atlassianClient *atlassianclient.AtlassianClient
+	var siteID string
+	if b.Base.AtlassianOrgID != "" && b.Base.AtlassianAPIToken != "" {
+		atlassianClient, err = atlassianclient.New(ctx, b.Base.AtlassianOrgID, b.Base.AtlassianAPIToken, metrics)
+		if err != nil {
+			return nil, client.WrapError(err, "error creating atlassian client")
+		}
+
+		siteID, err = atlassianClient.GetSiteID(ctx, b.Base.Url)
+		if err != nil {
+			return nil, client.WrapError(err, "failed to find the jira site in the atlassian organization")
+		}
+	}
+
+	if len(b.Base.ClaimStatusFilter) > 0 && atlassianClient == nil {
+		return nil, status.Error(codes.InvalidArgument, "baton-jira: the claim status filter needs the atlassian org ID and API token")
+	}
+
+	if b.Base.SyncAtlassianRoles && atlassianClient == nil {
+		return nil, status.Error(codes.InvalidArgument, "baton-jira: syncing atlassian roles needs the atlassian org ID and API token")
+	}
+
+	if b.Base.ExpandNestedGroups && atlassianClient == nil {
+		return nil, status.Error(codes.InvalidArgument, "baton-jira: expanding nested groups needs the atlassian org ID and API token")
+	}
+
+	if b.Base.AnnotateManagedGroupGrants && atlassianClient == nil {
+		return nil, status.Error(codes.InvalidArgument, "baton-jira: annotating managed group grants needs the atlassian org ID and API token")
+	}
+
+	j := &Jira{
+		client:                       jiraClient,
+		apiClient:                    client.New(jiraClient, deploymentType),
+		serviceDeskClient:            client.NewServiceDeskClient(jiraClient),
+		session:                      newSessionStore(b.Base.WarmUpBudget, b.Base.TicketSchemaCacheTTL, metrics),
+		atlassianClient:              atlassianClient,
+		siteID:                       siteID,
+		syncJSMOrganizations:         b.Base.SyncJSMOrganizations,
+		skipFullSync:                 b.Base.SkipFullSync,
+		projectKeys:                  b.Base.ProjectKeys,
+		syncConcurrency:              syncConcurrency,
+		syncFilters:                  b.Base.SyncFilters,
+		syncDashboards:               b.Base.SyncDashboards,
+		syncPermissionSchemes:        b.Base.SyncPermissionSchemes,
+		syncNotificationSchemes:      b.Base.SyncNotificationSchemes,
+		syncComponents:               b.Base.SyncComponents,
+		syncProjectRoles:             b.Base.SyncProjectRoles,
+		syncAtlassianRoles:           b.Base.SyncAtlassianRoles,
+		expandNestedGroups:           b.Base.ExpandNestedGroups,
+		annotateManagedGroupGrants:   b.Base.AnnotateManagedGroupGrants,
+		syncDomains:                  atlassianClient != nil,
+		syncIssueSecurity:            b.Base.SyncIssueSecurity,
+		ticketIncludeWatchers:        b.Base.TicketIncludeWatchers,
+		ticketDropInvalidFields:      b.Base.TicketDropInvalidFields,
+		excludedIssueTypes:           b.Base.ExcludedIssueTypes,
+		ticketConsolidateSchemas:     b.Base.TicketConsolidateSchemas,
+		sendInvitationOnCreate:       b.Base.SendInvitationOnCreate,
+		syncAllProjects:              b.Base.SyncAllProjects,
+		syncIssueTypes:               b.Base.SyncIssueTypes,
+		syncUserProperties:           b.Base.SyncUserProperties,
+		explainParticipantGrants:     b.Base.ExplainParticipantGrants,
+		claimStatusFilter:            b.Base.ClaimStatusFilter,
+		groupSizeLogThreshold:        b.Base.GroupSizeLogThreshold,
+		modelDefaultGroupsAsLicenses: b.Base.ModelDefaultGroupsAsLicenses,
+		timeouts:                     b.Base.Timeouts,
+		metrics:                      metrics,
+		permissionGaps:               newPermissionGaps(),
+		baseURL:                      effectiveBaseURL(b.Base.Url),
+		authMode:                     authModeBasic,
+	}
+
+	if len(b.Base.AdditionalUrls) > 0 {
+		if atlassianClient == nil || deploymentType != client.DeploymentTypeCloud {
+			return nil, status.Error(codes.InvalidArgument, "baton-jira: additional sites need jira cloud and the atlassian org ID and API token")
+		}
+
+		for _, siteURL := range b.Base.AdditionalUrls {
+			site, err := j.newAdditionalSite(ctx, siteURL, httpClient, b.Base.WarmUpBudget)
+			if err != nil {
+				return nil, err
+			}
+			j.additionalSites = append(j.additionalSites, site)
+		}
+	}
+
+	ctxzap.Extract(ctx).Info("jira connector configured", zap.Any("connection", j.connectionSummary()))
+
+	return j, nil
+}
+
+// newAdditionalSite copies the connector's settings onto another site of the org.
+// Ticketing stays on the primary site.
+func (j *Jira) newAdditionalSite(ctx context.Context, siteURL string, httpClient *http.Client, warmUpBudget time.Duration) (*Jira, error) {
+	jiraClient, err := jira.NewClient(siteURL, httpClient)
+	if err != nil {
+		return nil, client.WrapError(err, fmt.Sprintf("error creating jira client for %s", siteURL))
+	}
+
+	siteID, err := j.atlassianClient.GetSiteID(ctx, siteURL)
+	if err != nil {
+		return nil, client.WrapError(err, fmt.Sprintf("failed to find the jira site %s in the atlassian organization", siteURL))
+	}
+
+	site := *j
+	site.client = jiraClient
+	site.apiClient = client.New(jiraClient, client.DeploymentTypeCloud)
+	site.serviceDeskClient = client.NewServiceDeskClient(jiraClient)
+	site.session = newSessionStore(warmUpBudget, j.session.ticketSchemaTTL, j.metrics)
+	site.siteID = siteID
+	site.baseURL = effectiveBaseURL(siteURL)
+	site.additionalSites = nil
+	// Org roles are the same for every site, so they are only synced with the primary one.
+	site.syncAtlassianRoles = false
+	site.syncDomains = false
+
+	return &site, nil
+}
+
+// connectionSummary is the one place describing how the connector reaches Jira. It is
+// logged at startup and returned in the connector metadata, so it must never hold
+// credentials or emails.
+func (j *Jira) connectionSummary() map[string]interface{} {
+	siteIDs := len(j.additionalSites)
+	if j.siteID != "" {
+		siteIDs++
+	}
+
+	return map[string]interface{}{
+		"base_url":             j.baseURL,
+		"auth_mode":            j.authMode,
+		"admin_client_enabled": j.atlassianClient != nil,
+		"site_ids":             siteIDs,
+	}
+}
+
+// Metrics returns the API call, retry and cache counters of the sync so far.
+func (j *Jira) Metrics() client.MetricsSnapshot {
+	return j.metrics.Snapshot()
+}
+
+// LogMetrics logs the counters of the sync so far, e.g. when the connector shuts down.
+func (j *Jira) LogMetrics(ctx context.Context) {
+	logMetrics(ctx, j.metrics, "jira sync metrics")
+}
+
+func logMetrics(ctx context.Context, metrics *client.Metrics, msg string, fields ...zap.Field) {
+	ctxzap.Extract(ctx).Info(msg, append(fields, metrics.Snapshot().Fields()...)...)
+}
+
+// siteName is the host of the configured Jira URL, e.g. your-domain.atlassian.net.
+func (j *Jira) siteName() string {
+	return j.client.BaseURL.Hostname()
+}
+
+// effectiveBaseURL keeps the scheme and host of the Jira URL, plus the cloud ID of
+// api.atlassian.com/ex/jira/<cloud id> URLs, and drops anything else.
+func effectiveBaseURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return ""
+	}
+
+	base := fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) >= 3 && parts[0] == "ex" && parts[1] == "jira" {
+		base = fmt.Sprintf("%s/ex/jira/%s", base, parts[2])
+	}
+
+	return base
 }
 
 func (j *Jira) Validate(ctx context.Context) (annotations.Annotations, error) {
-	_, _, err := j.client.User.Find(ctx, "")
+	if j.skipFullSync {
+		return nil, j.validateTicketing(ctx)
+	}
+
+	users, err := j.apiClient.FindUsers(ctx, 0, 1)
 	if err != nil {
-		return nil, wrapError(err, "failed to get users")
+		return nil, client.WrapError(err, "failed to get users")
+	}
+
+	// Links are always built from the configured URL, but a mismatch is worth flagging.
+	if len(users) > 0 && selfLinkHostMismatch(j.client.BaseURL, users[0].Self) {
+		ctxzap.Extract(ctx).Warn(
+			"jira returned links to another host than the configured url, the site may be a clone of another site or the url an alias",
+			zap.String("configured_host", j.client.BaseURL.Hostname()),
+			zap.String("self_link", users[0].Self),
+		)
 	}
 
 	_, _, err = j.client.Project.GetAll(ctx, nil)
 	if err != nil {
-		return nil, wrapError(err, "failed to get projects")
+		return nil, client.WrapError(err, "failed to get projects")
+	}
+
+	for _, site := range j.additionalSites {
+		_, err = site.Validate(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("site %s: %w", site.siteName(), err)
+		}
 	}
 
 	return nil, nil
 }
 
+// validateTicketing only probes what creating and reading issues needs, so a token
+// restricted to a few projects passes without user and group permissions.
+func (j *Jira) validateTicketing(ctx context.Context) error {
+	if len(j.projectKeys) == 0 {
+		projects, _, err := j.client.Project.Find(ctx, jira.WithMaxResults(1))
+		if err != nil {
+			return client.WrapError(err, "failed to get projects")
+		}
+
+		if len(projects) == 0 {
+			return client.WrapError(errors.New("no projects are visible"), "failed to validate ticketing")
+		}
+
+		return nil
+	}
+
+	for _, projectKey := range j.projectKeys {
+		project, _, err := j.client.Project.Get(ctx, projectKey)
+		if err != nil {
+			return client.WrapError(err, fmt.Sprintf("failed to get project %s", projectKey))
+		}
+
+		if len(project.IssueTypes) == 0 {
+			return client.WrapError(errors.New("no issue types available"), fmt.Sprintf("failed to validate project %s", projectKey))
+		}
+
+		_, _, err = j.client.Issue.GetCreateMetaIssueType(ctx, projectKey, project.IssueTypes[0].ID, &jira.GetQueryIssueTypeOptions{MaxResults: 1})
+		if err != nil {
+			return client.WrapError(err, fmt.Sprintf("failed to get create metadata for project %s", projectKey))
+		}
+	}
+
+	return nil
+}
+
 func (o *Jira) ResourceSyncers(ctx context.Context) []connectorbuilder.ResourceSyncer {
-	return []connectorbuilder.ResourceSyncer{
-		userBuilder(o.client),
-		groupBuilder(o.client),
-		projectBuilder(o.client),
-		roleBuilder(o.client),
+	syncers := o.siteResourceSyncers(ctx)
+
+	// Every syncer is wrapped, even with a single site, so that each resource type logs
+	// the sync metrics once it is listed.
+	if len(o.additionalSites) == 0 {
+		rv := make([]connectorbuilder.ResourceSyncer, 0, len(syncers))
+		for _, syncer := range syncers {
+			rv = append(rv, newMultiSiteSyncer(ctx, []siteSyncer{{syncer: syncer}}, o.metrics))
+		}
+		return rv
+	}
+
+	// Every site has the same settings, so it has a syncer for every resource type.
+	siteSyncersByType := make([]map[string]connectorbuilder.ResourceSyncer, len(o.additionalSites))
+	for i, site := range o.additionalSites {
+		siteSyncersByType[i] = make(map[string]connectorbuilder.ResourceSyncer)
+		for _, syncer := range site.siteResourceSyncers(ctx) {
+			siteSyncersByType[i][syncer.ResourceType(ctx).Id] = syncer
+		}
+	}
+
+	rv := make([]connectorbuilder.ResourceSyncer, 0, len(syncers))
+	for _, syncer := range syncers {
+		sites := []siteSyncer{{syncer: syncer}}
+		for i, site := range o.additionalSites {
+			siteSyncer := siteSyncer{
+				syncer:   siteSyncersByType[i][syncer.ResourceType(ctx).Id],
+				siteID:   site.siteID,
+				siteName: site.siteName(),
+			}
+			if siteSyncer.syncer != nil {
+				sites = append(sites, siteSyncer)
+			}
+		}
+
+		rv = append(rv, newMultiSiteSyncer(ctx, sites, o.metrics))
+	}
+
+	return rv
+}
+
+// projectRolesSynced reports whether project roles are synced. Issue types, permission
+// schemes, filters and dashboards grant to project roles, so they are synced along with them.
+func (o *Jira) projectRolesSynced() bool {
+	return o.syncProjectRoles || o.syncIssueTypes || o.syncPermissionSchemes || o.syncFilters || o.syncDashboards
+}
+
+// siteResourceSyncers returns the syncers of this site alone.
+func (o *Jira) siteResourceSyncers(ctx context.Context) []connectorbuilder.ResourceSyncer {
+	syncedProjectKeys := o.projectKeys
+	if o.syncAllProjects {
+		syncedProjectKeys = nil
+	}
+
+	claimStatusFilter := newClaimStatusFilter(o.claimStatusFilter, o.atlassianClient, o.session)
+
+	syncers := []connectorbuilder.ResourceSyncer{
+		userBuilder(o.client, o.apiClient, o.sendInvitationOnCreate, o.syncUserProperties, o.atlassianClient, o.session, claimStatusFilter, o.timeouts.UserList),
+		groupBuilder(o.client, o.apiClient, o.atlassianClient, o.session, o.siteID, o.groupSizeLogThreshold, o.modelDefaultGroupsAsLicenses, o.timeouts.GroupList, o.expandNestedGroups, o.annotateManagedGroupGrants, claimStatusFilter),
+		projectBuilder(o.client, o.apiClient, o.session, o.syncConcurrency, syncedProjectKeys, o.explainParticipantGrants, o.syncNotificationSchemes, o.syncComponents, o.projectRolesSynced(), o.permissionGaps),
+		roleBuilder(o.client, o.apiClient, claimStatusFilter),
+	}
+
+	if o.projectRolesSynced() {
+		syncers = append(syncers, projectRoleBuilder(o.client, o.apiClient, o.session, o.syncConcurrency, syncedProjectKeys))
+	}
+
+	if o.modelDefaultGroupsAsLicenses {
+		syncers = append(syncers, applicationRoleBuilder(o.apiClient, o.session))
+	}
+
+	if o.syncJSMOrganizations {
+		syncers = append(syncers, jsmOrganizationBuilder(o.serviceDeskClient))
+	}
+
+	// Sites can have a very large number of filters, so they are opt-in.
+	if o.syncFilters {
+		syncers = append(syncers, filterBuilder(o.client, o.apiClient))
+	}
+
+	if o.syncDashboards {
+		syncers = append(syncers, dashboardBuilder(o.apiClient))
+	}
+
+	if o.syncPermissionSchemes {
+		syncers = append(syncers, permissionSchemeBuilder(o.client, o.apiClient, o.session, syncedProjectKeys))
 	}
+
+	if o.syncNotificationSchemes {
+		syncers = append(syncers, notificationSchemeBuilder(o.apiClient))
+	}
+
+	if o.syncComponents {
+		syncers = append(syncers, componentBuilder(o.apiClient))
+	}
+
+	if o.syncIssueSecurity {
+		syncers = append(syncers, issueSecurityLevelBuilder(o.apiClient))
+	}
+
+	if o.atlassianClient != nil {
+		syncers = append(syncers, siteBuilder(o.atlassianClient, o.siteID, o.siteName(), claimStatusFilter))
+	}
+
+	if o.syncAtlassianRoles {
+		syncers = append(syncers, atlassianRoleBuilder(o.atlassianClient, o.session, o.siteID))
+	}
+
+	if o.syncDomains {
+		syncers = append(syncers, domainBuilder(o.atlassianClient))
+	}
+
+	// Issue type grants need every project's permission scheme, so they are opt-in.
+	if o.syncIssueTypes {
+		syncers = append(syncers, issueTypeBuilder(o.client, o.apiClient, o.session, syncedProjectKeys))
+	}
+
+	return syncers
 }
 
 func (o *Jira) Metadata(ctx context.Context) (*v2.ConnectorMetadata, error) {
+	summary := o.connectionSummary()
+	// The SDK has no account creation schema yet, so the products CreateAccount accepts are
+	// listed here for requesters.
+	products := make([]interface{}, 0, len(knownProducts))
+	for _, product := range knownProducts {
+		products = append(products, product)
+	}
+	summary["account_creation_products"] = products
+
+	profile, err := structpb.NewStruct(summary)
+	if err != nil {
+		return nil, client.WrapError(err, "failed to build connection summary")
+	}
+
 	return &v2.ConnectorMetadata{
 		DisplayName: "Jira",
 		Description: "Connector syncing Jira users and their groups and projects to Baton.",
+		Profile:     profile,
 	}, nil
 }
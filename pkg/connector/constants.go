@@ -3,6 +3,9 @@ package connector
 var (
 	resourcePageSize = 50
 
+	// participantPageSize is the most users the user search returns at once.
+	participantPageSize = 1000
+
 	memberEntitlement = "member"
 
 	participateEntitlement = "participate"
@@ -10,4 +13,36 @@ var (
 	leadEntitlement = "lead"
 
 	appointedEntitlement = "appointed"
+
+	assignedEntitlement = "assigned"
+
+	archivedEntitlement = "archived"
+
+	ownerEntitlement = "owner"
+
+	viewerEntitlement = "viewer"
+
+	editorEntitlement = "editor"
+
+	canCreateEntitlement = "can-create"
+
+	activeMemberEntitlement = "active-member"
+
+	licenseEntitlement = "license"
+)
+
+// Sources of a JiraGrantSource annotation.
+const (
+	grantSourceDirect          = "direct"
+	grantSourceProjectRole     = "project_role"
+	grantSourceApplicationRole = "application_role"
+	grantSourceAnyone          = "anyone"
 )
+
+// Sources of a JiraSyncSource annotation.
+const (
+	syncSourceCache   = "cache"
+	syncSourceLiveAPI = "live_api"
+)
+
+const browseProjectsPermission = "BROWSE_PROJECTS"
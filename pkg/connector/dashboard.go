@@ -0,0 +1,144 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var resourceTypeDashboard = &v2.ResourceType{
+	Id:          "dashboard",
+	DisplayName: "Dashboard",
+}
+
+type dashboardResourceType struct {
+	resourceType *v2.ResourceType
+	apiClient    *client.Client
+}
+
+func dashboardResource(dashboard *client.Dashboard) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		dashboard.Name,
+		resourceTypeDashboard,
+		dashboard.ID,
+		rs.WithDescription(dashboard.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (d *dashboardResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return d.resourceType
+}
+
+func dashboardBuilder(apiClient *client.Client) *dashboardResourceType {
+	return &dashboardResourceType{
+		resourceType: resourceTypeDashboard,
+		apiClient:    apiClient,
+	}
+}
+
+func (d *dashboardResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeDashboard.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	dashboards, lastPage, err := d.apiClient.ListDashboards(ctx, int(offset), resourcePageSize)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list dashboards")
+	}
+
+	var resources []*v2.Resource
+	for i := range dashboards {
+		resource, err := dashboardResource(&dashboards[i])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if lastPage {
+		return resources, "", nil, nil
+	}
+
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(dashboards)))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return resources, nextPage, nil, nil
+}
+
+func (d *dashboardResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Owner of %s dashboard", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s dashboard %s", resource.DisplayName, ownerEntitlement)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, ownerEntitlement, assigmentOptions...))
+
+	assigmentOptions = []ent.EntitlementOption{
+		ent.WithGrantableTo(sharePrincipalTypes...),
+		ent.WithDescription(fmt.Sprintf("Can view %s dashboard", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s dashboard %s", resource.DisplayName, viewerEntitlement)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, viewerEntitlement, assigmentOptions...))
+
+	assigmentOptions = []ent.EntitlementOption{
+		ent.WithGrantableTo(sharePrincipalTypes...),
+		ent.WithDescription(fmt.Sprintf("Can edit %s dashboard", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s dashboard %s", resource.DisplayName, editorEntitlement)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, editorEntitlement, assigmentOptions...))
+
+	return rv, "", nil, nil
+}
+
+// Grants covers the owner, and who the dashboard is shared with or editable by, like
+// filterResourceType.Grants.
+func (d *dashboardResourceType) Grants(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	dashboard, err := d.apiClient.GetDashboard(ctx, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get dashboard")
+	}
+
+	var rv []*v2.Grant
+
+	if dashboard.Owner != nil && dashboard.Owner.AccountID != "" {
+		owner, err := userResource(ctx, dashboard.Owner)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		rv = append(rv, grant.NewGrant(resource, ownerEntitlement, owner.Id))
+	}
+
+	viewers, annos, err := sharePermissionGrants(ctx, resource, viewerEntitlement, dashboard.SharePermissions)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	rv = append(rv, viewers...)
+
+	editors, editorAnnos, err := sharePermissionGrants(ctx, resource, editorEntitlement, dashboard.EditPermissions)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	rv = append(rv, editors...)
+	annos = append(annos, editorAnnos...)
+
+	return rv, "", annos, nil
+}
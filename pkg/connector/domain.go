@@ -0,0 +1,85 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var resourceTypeDomain = &v2.ResourceType{
+	Id:          "domain",
+	DisplayName: "Domain",
+}
+
+// domainResourceType lists the domains of the org with the status of their claim. It is
+// informational, so it has no entitlements.
+type domainResourceType struct {
+	resourceType    *v2.ResourceType
+	atlassianClient *atlassianclient.AtlassianClient
+}
+
+func domainDescription(domain *atlassianclient.Domain) string {
+	if domain.Verified() {
+		return fmt.Sprintf("Verified through %s, its accounts are managed by the org", domain.Attributes.Claim.Type)
+	}
+
+	return fmt.Sprintf("Claim status: %s", domain.Attributes.Claim.Status)
+}
+
+func domainResource(domain *atlassianclient.Domain) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		domain.Attributes.Name,
+		resourceTypeDomain,
+		domain.ID,
+		rs.WithDescription(domainDescription(domain)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (d *domainResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return d.resourceType
+}
+
+func domainBuilder(atlassianClient *atlassianclient.AtlassianClient) *domainResourceType {
+	return &domainResourceType{
+		resourceType:    resourceTypeDomain,
+		atlassianClient: atlassianClient,
+	}
+}
+
+func (d *domainResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	domains, cursor, err := d.atlassianClient.ListDomains(ctx, p.Token)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list domains")
+	}
+
+	var resources []*v2.Resource
+	for i := range domains {
+		resource, err := domainResource(&domains[i])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, cursor, nil, nil
+}
+
+func (d *domainResourceType) Entitlements(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+func (d *domainResourceType) Grants(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
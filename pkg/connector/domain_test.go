@@ -0,0 +1,110 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+func TestDomainResource(t *testing.T) {
+	tests := []struct {
+		name            string
+		domain          atlassianclient.Domain
+		wantDescription string
+	}{
+		{
+			name:            "verified",
+			domain:          atlassianclient.Domain{ID: "d-1", Attributes: atlassianclient.DomainAttributes{Name: "example.com", Claim: atlassianclient.DomainClaim{Type: "DNS", Status: "VERIFIED"}}},
+			wantDescription: "Verified through DNS, its accounts are managed by the org",
+		},
+		{
+			name:            "pending",
+			domain:          atlassianclient.Domain{ID: "d-2", Attributes: atlassianclient.DomainAttributes{Name: "example.org", Claim: atlassianclient.DomainClaim{Type: "HTTPS", Status: "PENDING"}}},
+			wantDescription: "Claim status: PENDING",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := domainResource(&tt.domain)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if resource.GetId().GetResourceType() != resourceTypeDomain.Id || resource.GetId().GetResource() != tt.domain.ID {
+				t.Errorf("resource ID = %v, want %s %s", resource.GetId(), resourceTypeDomain.Id, tt.domain.ID)
+			}
+			if resource.GetDisplayName() != tt.domain.Attributes.Name {
+				t.Errorf("display name = %q, want %q", resource.GetDisplayName(), tt.domain.Attributes.Name)
+			}
+			if resource.GetDescription() != tt.wantDescription {
+				t.Errorf("description = %q, want %q", resource.GetDescription(), tt.wantDescription)
+			}
+		})
+	}
+}
+
+func TestUserManagedProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		orgUser     *atlassianclient.User
+		wantManaged bool
+		wantField   bool
+	}{
+		{name: "verified domain", orgUser: &atlassianclient.User{AccountID: "a-1", ClaimStatus: "VERIFIED"}, wantManaged: true, wantField: true},
+		{name: "unverified domain", orgUser: &atlassianclient.User{AccountID: "a-1", ClaimStatus: "UNVERIFIED"}, wantField: true},
+		{name: "no claim status", orgUser: &atlassianclient.User{AccountID: "a-1"}},
+		{name: "not in the org directory"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := userResource(context.Background(), &jira.User{AccountID: "a-1", DisplayName: "Alice"}, withOrgUser(tt.orgUser))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, ok := userTrait(t, resource).GetProfile().GetFields()["managed"]
+			if ok != tt.wantField {
+				t.Errorf("profile has managed = %v, want %v", ok, tt.wantField)
+			}
+			if got := userProfileField(t, resource, "managed"); got != tt.wantManaged {
+				t.Errorf("managed = %v, want %v", got, tt.wantManaged)
+			}
+		})
+	}
+}
+
+// TestDomainSyncer checks domains are only synced with the org admin API configured.
+func TestDomainSyncer(t *testing.T) {
+	tests := []struct {
+		name        string
+		syncDomains bool
+	}{
+		{name: "org client configured", syncDomains: true},
+		{name: "no org client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.NotFoundHandler())
+			j.syncDomains = tt.syncDomains
+			if tt.syncDomains {
+				j.atlassianClient = &atlassianclient.AtlassianClient{}
+			}
+
+			found := false
+			for _, syncer := range j.ResourceSyncers(context.Background()) {
+				if syncer.ResourceType(context.Background()).GetId() == resourceTypeDomain.Id {
+					found = true
+				}
+			}
+			if found != tt.syncDomains {
+				t.Errorf("domain syncer registered = %v, want %v", found, tt.syncDomains)
+			}
+		})
+	}
+}
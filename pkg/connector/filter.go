@@ -0,0 +1,152 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+var resourceTypeFilter = &v2.ResourceType{
+	Id:          "filter",
+	DisplayName: "Filter",
+}
+
+type filterResourceType struct {
+	resourceType *v2.ResourceType
+	client       *jira.Client
+	apiClient    *client.Client
+}
+
+func filterResource(ctx context.Context, id string, name string, jql string) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		name,
+		resourceTypeFilter,
+		id,
+		rs.WithDescription(jql),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (f *filterResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return f.resourceType
+}
+
+func filterBuilder(client *jira.Client, apiClient *client.Client) *filterResourceType {
+	return &filterResourceType{
+		resourceType: resourceTypeFilter,
+		client:       client,
+		apiClient:    apiClient,
+	}
+}
+
+func (f *filterResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeFilter.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	filters, _, err := f.client.Filter.Search(ctx, &jira.FilterSearchOptions{
+		StartAt:    offset,
+		MaxResults: int32(resourcePageSize),
+		Expand:     "jql",
+		OrderBy:    "id",
+	})
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to search filters")
+	}
+
+	var resources []*v2.Resource
+	for _, filter := range filters.Values {
+		resource, err := filterResource(ctx, filter.ID, filter.Name, filter.Jql)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if filters.IsLast || isLastPage(len(filters.Values), resourcePageSize) {
+		return resources, "", nil, nil
+	}
+
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(filters.Values)))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return resources, nextPage, nil, nil
+}
+
+func (f *filterResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Owner of %s filter", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s filter %s", resource.DisplayName, ownerEntitlement)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, ownerEntitlement, assigmentOptions...))
+
+	assigmentOptions = []ent.EntitlementOption{
+		ent.WithGrantableTo(sharePrincipalTypes...),
+		ent.WithDescription(fmt.Sprintf("Can view %s filter", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s filter %s", resource.DisplayName, viewerEntitlement)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, viewerEntitlement, assigmentOptions...))
+
+	assigmentOptions = []ent.EntitlementOption{
+		ent.WithGrantableTo(sharePrincipalTypes...),
+		ent.WithDescription(fmt.Sprintf("Can edit %s filter", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s filter %s", resource.DisplayName, editorEntitlement)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, editorEntitlement, assigmentOptions...))
+
+	return rv, "", nil, nil
+}
+
+// Grants covers the owner, and who the filter is shared with or editable by. Shares with
+// every user of the site or with anyone are annotated on the response, see sharePermissionGrants.
+func (f *filterResourceType) Grants(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	filter, err := f.apiClient.GetFilter(ctx, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get filter")
+	}
+
+	var rv []*v2.Grant
+
+	if filter.Owner.AccountID != "" {
+		owner, err := userResource(ctx, &filter.Owner)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		rv = append(rv, grant.NewGrant(resource, ownerEntitlement, owner.Id))
+	}
+
+	viewers, annos, err := sharePermissionGrants(ctx, resource, viewerEntitlement, filter.SharePermissions)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	rv = append(rv, viewers...)
+
+	editors, editorAnnos, err := sharePermissionGrants(ctx, resource, editorEntitlement, filter.EditPermissions)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	rv = append(rv, editors...)
+	annos = append(annos, editorAnnos...)
+
+	return rv, "", annos, nil
+}
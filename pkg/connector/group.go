@@ -3,8 +3,11 @@ package connector
 import (
 	"context"
 	"fmt"
-	"net/http"
+	"time"
 
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
@@ -14,6 +17,8 @@ import (
 	jira "github.com/conductorone/go-jira/v2/cloud"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var resourceTypeGroup = &v2.ResourceType{
@@ -27,14 +32,48 @@ var resourceTypeGroup = &v2.ResourceType{
 type groupResourceType struct {
 	resourceType *v2.ResourceType
 	client       *jira.Client
+	apiClient    *client.Client
+
+	// atlassianClient is nil unless the org admin API is configured.
+	atlassianClient *atlassianclient.AtlassianClient
+	session         *sessionStore
+	siteID          string
+
+	// sizeLogThreshold logs the synced member count of groups at least this large. Zero disables it.
+	sizeLogThreshold int
+
+	// modelDefaultGroupsAsLicenses leaves out the member grants of application role default
+	// groups, which are synced as license grants of the application role instead.
+	modelDefaultGroupsAsLicenses bool
+
+	// listTimeout bounds each page of groups and of group members. Zero disables it.
+	listTimeout time.Duration
+
+	// expandNestedGroups grants the member entitlement to the nested groups of a group,
+	// expanded to their members. It needs the org admin API.
+	expandNestedGroups bool
+
+	// annotateManagedGroupGrants adds the directory owning a group synced from an identity
+	// provider to the grants of the group. It needs the org admin API.
+	annotateManagedGroupGrants bool
+
+	// claimStatusFilter drops the member grants of users the user builder skips.
+	claimStatusFilter *claimStatusFilter
 }
 
-func groupResource(ctx context.Context, group *jira.Group) (*v2.Resource, error) {
+// groupResource builds a group. orgGroup is the matching org directory group, if known.
+func groupResource(ctx context.Context, group *jira.Group, orgGroup *atlassianclient.Group) (*v2.Resource, error) {
 	profile := map[string]interface{}{
 		"id":   group.ID,
 		"name": group.Name,
 	}
 
+	if orgGroup != nil {
+		profile["member_count"] = orgGroup.Counts.Members
+		profile["directory_id"] = orgGroup.DirectoryID
+		profile["managed"] = orgGroup.Managed()
+	}
+
 	groupTraitOptions := []rs.GroupTraitOption{
 		rs.WithGroupProfile(profile),
 	}
@@ -51,10 +90,32 @@ func (g *groupResourceType) ResourceType(_ context.Context) *v2.ResourceType {
 	return g.resourceType
 }
 
-func groupBuilder(client *jira.Client) *groupResourceType {
+func groupBuilder(
+	jiraClient *jira.Client,
+	apiClient *client.Client,
+	atlassianClient *atlassianclient.AtlassianClient,
+	session *sessionStore,
+	siteID string,
+	sizeLogThreshold int,
+	modelDefaultGroupsAsLicenses bool,
+	listTimeout time.Duration,
+	expandNestedGroups bool,
+	annotateManagedGroupGrants bool,
+	claimStatusFilter *claimStatusFilter,
+) *groupResourceType {
 	return &groupResourceType{
-		resourceType: resourceTypeGroup,
-		client:       client,
+		resourceType:                 resourceTypeGroup,
+		client:                       jiraClient,
+		apiClient:                    apiClient,
+		atlassianClient:              atlassianClient,
+		session:                      session,
+		siteID:                       siteID,
+		sizeLogThreshold:             sizeLogThreshold,
+		modelDefaultGroupsAsLicenses: modelDefaultGroupsAsLicenses,
+		listTimeout:                  listTimeout,
+		expandNestedGroups:           expandNestedGroups,
+		annotateManagedGroupGrants:   annotateManagedGroupGrants,
+		claimStatusFilter:            claimStatusFilter,
 	}
 }
 
@@ -79,28 +140,39 @@ func (u *groupResourceType) Grants(ctx context.Context, resource *v2.Resource, p
 		return nil, "", nil, err
 	}
 
-	groupMembers, _, err := u.client.Group.GetGroupMembers(
-		ctx,
-		resource.Id.Resource,
-		jira.WithStartAt(int(offset)),
-		jira.WithMaxResults(resourcePageSize),
-	)
+	if u.modelDefaultGroupsAsLicenses {
+		// Without the application roles no license grants are synced either, so the group
+		// keeps its member grants rather than failing the grants of every group.
+		defaultGroup, err := u.session.isDefaultGroup(ctx, u.apiClient, resource.Id.Resource)
+		if err != nil {
+			ctxzap.Extract(ctx).Warn(
+				"baton-jira: failed to list application roles, syncing group members",
+				zap.String("group_id", resource.Id.Resource),
+				zap.Error(err),
+			)
+		}
+		if defaultGroup {
+			return nil, "", nil, nil
+		}
+	}
+
+	listCtx, cancel := withTimeout(ctx, u.listTimeout)
+	groupMembers, lastPage, err := u.apiClient.GetGroupMembers(listCtx, resource.Id.Resource, int(offset), resourcePageSize)
+	cancel()
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get group members")
+		return nil, "", nil, client.WrapError(err, "failed to get group members")
 	}
 
-	var rv []*v2.Grant
+	// Activity is only known for users already synced by the user builder.
+	accountIDs := make([]string, 0, len(groupMembers))
 	for _, groupMember := range groupMembers {
-		user, err := userResource(ctx, &jira.User{
-			Name:         groupMember.Name,
-			Key:          groupMember.Key,
-			AccountID:    groupMember.AccountID,
-			EmailAddress: groupMember.EmailAddress,
-			DisplayName:  groupMember.DisplayName,
-			Active:       groupMember.Active,
-			TimeZone:     groupMember.TimeZone,
-			AccountType:  groupMember.AccountType,
-		})
+		accountIDs = append(accountIDs, groupMember.AccountID)
+	}
+	lastActive := u.session.cachedUsersLastActive(accountIDs)
+
+	var rv []*v2.Grant
+	for i := range groupMembers {
+		user, err := userResource(ctx, userFromGroupMember(&groupMembers[i]), withLastActive(lastActive[groupMembers[i].AccountID]))
 		if err != nil {
 			return nil, "", nil, err
 		}
@@ -109,11 +181,44 @@ func (u *groupResourceType) Grants(ctx context.Context, resource *v2.Resource, p
 		rv = append(rv, grant)
 	}
 
-	if isLastPage(len(groupMembers), resourcePageSize) {
+	rv, err = u.claimStatusFilter.filterGrants(ctx, rv)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// Resources cannot be updated once listed, so the count of site-effective members,
+	// which can differ from the org directory's member_count, is only logged.
+	memberCount := u.session.addGroupMembers(resource.Id.Resource, offset == 0, len(rv))
+
+	if u.expandNestedGroups && offset == 0 {
+		nestedGrants, err := u.nestedGroupGrants(ctx, resource)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, nestedGrants...)
+	}
+
+	if u.annotateManagedGroupGrants {
+		err = u.annotateManagedBy(ctx, resource, rv)
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	if lastPage {
+		if u.sizeLogThreshold > 0 && memberCount >= u.sizeLogThreshold {
+			ctxzap.Extract(ctx).Info(
+				"baton-jira: synced large group",
+				zap.String("group_id", resource.Id.Resource),
+				zap.String("group_name", resource.DisplayName),
+				zap.Int("synced_member_count", memberCount),
+			)
+		}
+
 		return rv, "", nil, nil
 	}
 
-	nextPage, err := getPageTokenFromOffset(bag, offset+int64(resourcePageSize))
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(groupMembers)))
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -121,37 +226,170 @@ func (u *groupResourceType) Grants(ctx context.Context, resource *v2.Resource, p
 	return rv, nextPage, nil, nil
 }
 
+// annotateManagedBy adds the directory owning the group to its grants, when the group is
+// synced from an identity provider. Revoking such a membership in Jira does not last, so
+// it has to be changed in the directory instead.
+func (u *groupResourceType) annotateManagedBy(ctx context.Context, resource *v2.Resource, grants []*v2.Grant) error {
+	if len(grants) == 0 {
+		return nil
+	}
+
+	orgGroups, err := u.session.getOrgGroups(ctx, u.atlassianClient, u.siteID)
+	if err != nil {
+		return client.WrapError(err, "failed to list org directory groups")
+	}
+
+	orgGroup, ok := orgGroups[resource.Id.Resource]
+	if !ok || !orgGroup.Managed() {
+		return nil
+	}
+
+	directoryNames, err := u.session.getDirectoryNames(ctx, u.atlassianClient)
+	if err != nil {
+		return client.WrapError(err, "failed to list org directories")
+	}
+
+	managedBy := &pbjira.JiraManagedBy{
+		DirectoryId:   orgGroup.DirectoryID,
+		DirectoryName: directoryNames[orgGroup.DirectoryID],
+	}
+	for _, g := range grants {
+		annos := annotations.Annotations(g.Annotations)
+		annos.Update(managedBy)
+		g.Annotations = annos
+	}
+
+	return nil
+}
+
+// nestedGroupGrants grants the member entitlement of a group to its nested groups. Jira
+// only lists the direct members of a group, so the grants are expanded to the members of
+// the nested groups, which expand to theirs in turn. Each nested group is granted once
+// and a group is never granted to itself; the SDK breaks longer cycles when expanding.
+func (u *groupResourceType) nestedGroupGrants(ctx context.Context, resource *v2.Resource) ([]*v2.Grant, error) {
+	orgGroups, err := u.session.getOrgGroups(ctx, u.atlassianClient, u.siteID)
+	if err != nil {
+		return nil, client.WrapError(err, "failed to list org directory groups")
+	}
+
+	orgGroup, ok := orgGroups[resource.Id.Resource]
+	if !ok {
+		return nil, nil
+	}
+
+	seen := map[string]bool{
+		orgGroup.ID: true,
+	}
+
+	var rv []*v2.Grant
+	cursor := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing group memberships"); err != nil {
+			return nil, err
+		}
+
+		memberships, next, err := u.atlassianClient.ListGroupMemberships(ctx, orgGroup.DirectoryID, orgGroup.ID, cursor)
+		if err != nil {
+			return nil, client.WrapError(err, "failed to list group memberships")
+		}
+
+		for _, membership := range memberships {
+			if membership.Type != atlassianclient.GroupMembershipTypeGroup || seen[membership.ID] {
+				continue
+			}
+			seen[membership.ID] = true
+
+			nested, ok := orgGroups[membership.ID]
+			if !ok {
+				nested = atlassianclient.Group{ID: membership.ID}
+			}
+
+			nestedResource, err := groupResource(ctx, &jira.Group{ID: nested.ID, Name: nested.Name}, &nested)
+			if err != nil {
+				return nil, err
+			}
+
+			// Groups are listed before grants are synced. Expanding a group that was not
+			// listed would reference a member entitlement that does not exist.
+			if u.session.groupNotListed(nested.ID) {
+				rv = append(rv, grant.NewGrant(
+					resource,
+					memberEntitlement,
+					nestedResource.Id,
+					grant.WithAnnotation(&pbjira.JiraUnexpandedGroup{GroupId: nested.ID}),
+				))
+				continue
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				memberEntitlement,
+				nestedResource.Id,
+				grant.WithAnnotation(&v2.GrantExpandable{
+					EntitlementIds:  []string{fmt.Sprintf("group:%s:%s", nested.ID, memberEntitlement)},
+					ResourceTypeIds: []string{resourceTypeUser.Id},
+				}),
+			))
+		}
+
+		if next == "" {
+			return rv, nil
+		}
+		cursor = next
+	}
+}
+
 func (u *groupResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
 	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeGroup.Id})
 	if err != nil {
 		return nil, "", nil, err
 	}
 
-	groups, _, err := u.client.Group.Bulk(ctx, jira.WithMaxResults(resourcePageSize), jira.WithStartAt(int(offset)))
+	listCtx, cancel := withTimeout(ctx, u.listTimeout)
+	groups, lastPage, err := u.apiClient.ListGroups(listCtx, int(offset), resourcePageSize)
+	cancel()
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to list groups")
+		return nil, "", nil, client.WrapError(err, "failed to list groups")
+	}
+
+	var orgGroups map[string]atlassianclient.Group
+	var source *syncSource
+	if u.atlassianClient != nil {
+		var sourceCtx context.Context
+		sourceCtx, source = withSyncSource(ctx)
+		orgGroups, err = u.session.getOrgGroups(sourceCtx, u.atlassianClient, u.siteID)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, "failed to list org groups")
+		}
 	}
 
 	var resources []*v2.Resource
+	groupIDs := make([]string, 0, len(groups))
 	for i := range groups {
-		group := jira.Group{
-			ID:   groups[i].ID,
-			Name: groups[i].Name,
+		groupIDs = append(groupIDs, groups[i].ID)
+
+		var orgGroup *atlassianclient.Group
+		if match, ok := orgGroups[groups[i].ID]; ok {
+			orgGroup = &match
 		}
-		resource, err := groupResource(ctx, &group)
 
+		resource, err := groupResource(ctx, &groups[i], orgGroup)
 		if err != nil {
 			return nil, "", nil, err
 		}
 
 		resources = append(resources, resource)
 	}
+	if source != nil {
+		source.annotate(resources)
+	}
+	u.session.recordListedGroups(groupIDs, offset == 0, lastPage)
 
-	if isLastPage(len(groups), resourcePageSize) {
+	if lastPage {
 		return resources, "", nil, nil
 	}
 
-	nextPage, err := getPageTokenFromOffset(bag, offset+int64(resourcePageSize))
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(groups)))
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -174,7 +412,7 @@ func (u *groupResourceType) Grant(ctx context.Context, principal *v2.Resource, e
 		return nil, err
 	}
 
-	_, resp, err := u.client.Group.AddUserByGroupName(ctx, entitlement.Resource.Id.Resource, principal.Id.Resource)
+	err := u.apiClient.AddGroupMember(ctx, entitlement.Resource.Id.Resource, principal.Id.Resource)
 	if err != nil {
 		l.Error(
 			"failed to add user to group",
@@ -183,20 +421,7 @@ func (u *groupResourceType) Grant(ctx context.Context, principal *v2.Resource, e
 			zap.String("user", principal.Id.Resource),
 		)
 
-		return nil, err
-	}
-
-	if resp.StatusCode != http.StatusCreated {
-		err := fmt.Errorf("baton-jira: failed to add user to group: %s", resp.Status)
-
-		l.Error(
-			err.Error(),
-			zap.String("group", entitlement.Resource.Id.Resource),
-			zap.String("user", principal.Id.Resource),
-			zap.Int("status_code", resp.StatusCode),
-		)
-
-		return nil, err
+		return nil, explainUserGrantError(ctx, u.apiClient, principal.Id.Resource, err, "failed to add user to group")
 	}
 
 	return nil, nil
@@ -220,7 +445,7 @@ func (u *groupResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annota
 		return nil, err
 	}
 
-	resp, err := u.client.Group.RemoveUserByGroupName(ctx, entitlement.Resource.Id.Resource, principal.Id.Resource)
+	err := u.apiClient.RemoveGroupMember(ctx, entitlement.Resource.Id.Resource, principal.Id.Resource)
 	if err != nil {
 		l.Error(
 			"failed to remove user from group",
@@ -229,21 +454,69 @@ func (u *groupResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annota
 			zap.String("user", principal.Id.Resource),
 		)
 
-		return nil, err
+		return nil, client.WrapError(err, "failed to remove user from group")
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		err := fmt.Errorf("baton-jira: failed to remove user from group: %s", resp.Status)
+	return nil, nil
+}
 
-		l.Error(
-			err.Error(),
-			zap.String("group", entitlement.Resource.Id.Resource),
-			zap.String("user", principal.Id.Resource),
-			zap.Int("status_code", resp.StatusCode),
-		)
+// CreateGroup creates a group named name. A group of that name that already exists is
+// returned with a GrantAlreadyExists annotation.
+func (u *groupResourceType) CreateGroup(ctx context.Context, name string) (*v2.Resource, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
 
-		return nil, err
+	var annos annotations.Annotations
+	group, err := u.apiClient.CreateGroup(ctx, name)
+	if err != nil {
+		if status.Code(err) != codes.AlreadyExists {
+			l.Error("failed to create group", zap.Error(err), zap.String("group_name", name))
+			return nil, nil, client.WrapError(err, "failed to create group")
+		}
+
+		groupID, err := u.session.getGroupIDByName(ctx, u.apiClient, name)
+		if err != nil {
+			return nil, nil, client.WrapError(err, "failed to find existing group")
+		}
+		if groupID == "" {
+			return nil, nil, fmt.Errorf("baton-jira: group %s already exists but was not found", name)
+		}
+
+		group = &jira.Group{
+			ID:   groupID,
+			Name: name,
+		}
+		annos.Update(&v2.GrantAlreadyExists{})
+	}
+
+	resource, err := groupResource(ctx, group, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resource, annos, nil
+}
+
+// DeleteGroup deletes a group and, with it, every membership of the group.
+func (u *groupResourceType) DeleteGroup(ctx context.Context, resource *v2.Resource) (annotations.Annotations, error) {
+	err := u.apiClient.DeleteGroup(ctx, resource.Id.Resource)
+	if err != nil {
+		ctxzap.Extract(ctx).Error("failed to delete group", zap.Error(err), zap.String("group", resource.Id.Resource))
+		return nil, client.WrapError(err, "failed to delete group")
 	}
 
 	return nil, nil
 }
+
+// Create implements connectorbuilder.ResourceManager with CreateGroup, named after the resource's display name.
+func (u *groupResourceType) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	if resource.GetDisplayName() == "" {
+		return nil, nil, status.Error(codes.InvalidArgument, "baton-jira: a display name is required to create a group")
+	}
+
+	return u.CreateGroup(ctx, resource.GetDisplayName())
+}
+
+// Delete implements connectorbuilder.ResourceManager with DeleteGroup.
+func (u *groupResourceType) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	return u.DeleteGroup(ctx, &v2.Resource{Id: resourceId})
+}
@@ -0,0 +1,171 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+// groupMembersHandler serves total members of group g-1, at most clamp at once.
+func groupMembersHandler(total int, clamp int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/group/member" || r.URL.Query().Get("groupId") != "g-1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		end := min(startAt+min(maxResults, clamp), total)
+
+		members := []string{}
+		for i := startAt; i < end; i++ {
+			members = append(members, fmt.Sprintf(`{"accountId":"u%d","active":true}`, i))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"startAt":%d,"isLast":%t,"values":[%s]}`, startAt, end >= total, strings.Join(members, ","))
+	})
+}
+
+func TestGroupMemberCount(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		clamp int
+	}{
+		{name: "empty group", total: 0, clamp: resourcePageSize},
+		{name: "single page", total: 10, clamp: resourcePageSize},
+		{name: "exact pages", total: 2 * resourcePageSize, clamp: resourcePageSize},
+		{name: "several pages", total: 2*resourcePageSize + 7, clamp: resourcePageSize},
+		{name: "clamped page size", total: 45, clamp: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, groupMembersHandler(tt.total, tt.clamp))
+			g := groupBuilder(j.client, j.apiClient, nil, j.session, "", 1, false, time.Minute, false, false, nil)
+			resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeGroup.Id, Resource: "g-1"}, DisplayName: "Developers"}
+
+			// A second pass over the group, as in the next sync, starts the count over.
+			for pass := 1; pass <= 2; pass++ {
+				granted := 0
+				token := ""
+				for calls := 0; ; calls++ {
+					if calls > tt.total+1 {
+						t.Fatalf("paging did not end after %d calls", calls)
+					}
+
+					grants, next, _, err := g.Grants(context.Background(), resource, &pagination.Token{Token: token})
+					if err != nil {
+						t.Fatalf("Grants: %v", err)
+					}
+					granted += len(grants)
+
+					if next == "" {
+						break
+					}
+					token = next
+				}
+
+				if granted != tt.total {
+					t.Errorf("pass %d granted %d members, want %d", pass, granted, tt.total)
+				}
+				if got := j.session.groupMemberCounts["g-1"]; got != tt.total {
+					t.Errorf("pass %d counted %d members, want %d", pass, got, tt.total)
+				}
+			}
+		})
+	}
+}
+
+// TestManagedGroupGrants lists the grants of group g-1 with the org directory groups and
+// directory names already cached for the sync, and checks which carry the directory.
+func TestManagedGroupGrants(t *testing.T) {
+	tests := []struct {
+		name     string
+		annotate bool
+		orgGroup *atlassianclient.Group
+		want     *pbjira.JiraManagedBy
+	}{
+		{
+			name:     "synced from an identity provider",
+			annotate: true,
+			orgGroup: &atlassianclient.Group{ID: "g-1", DirectoryID: "d-okta", ManagementAccess: "READ_ONLY"},
+			want:     &pbjira.JiraManagedBy{DirectoryId: "d-okta", DirectoryName: "Okta"},
+		},
+		{
+			name:     "directory without a name",
+			annotate: true,
+			orgGroup: &atlassianclient.Group{ID: "g-1", DirectoryID: "d-unknown", ManagementAccess: "READ_ONLY"},
+			want:     &pbjira.JiraManagedBy{DirectoryId: "d-unknown"},
+		},
+		{
+			name:     "local group",
+			annotate: true,
+			orgGroup: &atlassianclient.Group{ID: "g-1", DirectoryID: "d-local", ManagementAccess: "FULL"},
+		},
+		{
+			name:     "not in the org directory",
+			annotate: true,
+		},
+		{
+			name:     "annotation disabled",
+			orgGroup: &atlassianclient.Group{ID: "g-1", DirectoryID: "d-okta", ManagementAccess: "READ_ONLY"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, groupMembersHandler(3, resourcePageSize))
+
+			// Both are cached, so the org admin API is never called.
+			orgGroups := map[string]atlassianclient.Group{}
+			if tt.orgGroup != nil {
+				orgGroups[tt.orgGroup.ID] = *tt.orgGroup
+			}
+			j.session.orgGroups["site-1"] = orgGroupsEntry{groups: orgGroups, fetchedAt: time.Now()}
+			j.session.directoryNames = map[string]string{"d-okta": "Okta", "d-local": "Jira"}
+			j.session.directoryNamesFetchedAt = time.Now()
+
+			g := groupBuilder(j.client, j.apiClient, &atlassianclient.AtlassianClient{}, j.session, "site-1", 1, false, time.Minute, false, tt.annotate, nil)
+			resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeGroup.Id, Resource: "g-1"}, DisplayName: "Developers"}
+
+			grants, _, _, err := g.Grants(context.Background(), resource, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("Grants: %v", err)
+			}
+			if len(grants) != 3 {
+				t.Fatalf("listed %d grants, want 3", len(grants))
+			}
+
+			for _, gr := range grants {
+				annos := annotations.Annotations(gr.Annotations)
+				managedBy := &pbjira.JiraManagedBy{}
+				ok, err := annos.Pick(managedBy)
+				if err != nil {
+					t.Fatalf("Pick: %v", err)
+				}
+
+				switch {
+				case tt.want == nil && ok:
+					t.Errorf("grant of %s is annotated with %v, want no annotation", gr.Principal.Id.Resource, managedBy)
+				case tt.want != nil && !ok:
+					t.Errorf("grant of %s has no managed by annotation", gr.Principal.Id.Resource)
+				case tt.want != nil && (managedBy.DirectoryId != tt.want.DirectoryId || managedBy.DirectoryName != tt.want.DirectoryName):
+					t.Errorf("grant of %s is managed by %v, want %v", gr.Principal.Id.Resource, managedBy, tt.want)
+				}
+			}
+		})
+	}
+}
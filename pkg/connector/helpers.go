@@ -1,19 +1,32 @@
 package connector
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
-	"regexp"
 	"slices"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/conductorone/baton-jira/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func wrapError(err error, message string) error {
-	return fmt.Errorf("jira-connector: %s: %w", message, err)
+// withTimeout bounds a single Jira request by timeout. Zero keeps the caller's deadline.
+func withTimeout(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+
+	return context.WithTimeout(ctx, timeout)
 }
 
 func parsePageToken(i string, resourceID *v2.ResourceId) (*pagination.Bag, int64, error) {
@@ -67,33 +80,227 @@ func getPageTokenFromOffset(bag *pagination.Bag, offset int64) (string, error) {
 
 var RoleIDNotFoundErr = fmt.Errorf("role id not found in role link")
 
-// Unfortunatelly, the Jira API does not provide a way to get the role id from project.
-// It only provides a link to the role. Like this: https://your-domain.atlassian.net/rest/api/3/project/10001/role/10002
-// So, we need to parse the role id from the link.
-func parseRoleIdFromRoleLink(roleLink string) (int, error) {
-	// Parse the URL
+// roleKeyFromRoleLink returns the role segment of a role link as is. Classic and
+// team-managed roles have numeric IDs, custom roles of team-managed projects do not.
+func roleKeyFromRoleLink(roleLink string) (string, error) {
 	parsedURL, err := url.Parse(roleLink)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse URL: %w", err)
+		return "", fmt.Errorf("failed to parse URL: %w", err)
 	}
 
-	// Split the path of the URL
 	pathElems := strings.Split(parsedURL.Path, "/")
-	// Find the index of the "role" element in the path, the next element should be the role id
 	roleIndex := slices.Index(pathElems, "role")
-	if roleIndex == -1 || roleIndex+1 >= len(pathElems) {
-		return 0, RoleIDNotFoundErr
+	if roleIndex == -1 || roleIndex+1 >= len(pathElems) || pathElems[roleIndex+1] == "" {
+		return "", RoleIDNotFoundErr
+	}
+
+	return pathElems[roleIndex+1], nil
+}
+
+// selfLinkHostMismatch reports whether a self link returned by Jira points at another host
+// than the configured site, which happens on sandboxes cloned from production or when the
+// configured URL is an alias of the site.
+func selfLinkHostMismatch(siteURL *url.URL, selfLink string) bool {
+	if selfLink == "" {
+		return false
+	}
+
+	parsed, err := url.Parse(selfLink)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+
+	return !strings.EqualFold(parsed.Hostname(), siteURL.Hostname())
+}
+
+// projectsPageToken walks the project keys in batches, and the project search
+// pages within each batch.
+type projectsPageToken struct {
+	BatchIndex int `json:"batchIndex"`
+	StartAt    int `json:"startAt"`
+}
+
+// parseProjectsPageToken also accepts plain integer offsets, as an offset into the first batch.
+func parseProjectsPageToken(token string) (*projectsPageToken, error) {
+	rv := &projectsPageToken{}
+	if token == "" {
+		return rv, nil
+	}
+
+	if offset, err := strconv.Atoi(token); err == nil {
+		rv.StartAt = offset
+		return rv, nil
+	}
+
+	err := json.Unmarshal([]byte(token), rv)
+	if err != nil {
+		return nil, fmt.Errorf("invalid projects page token: %w", err)
+	}
+
+	return rv, nil
+}
+
+func (t *projectsPageToken) marshal() (string, error) {
+	data, err := json.Marshal(t)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// findProjectsPage returns one page of projects and the token of the next page, which is
+// empty after the last one. With keys set, only those projects are searched, at most
+// client.MaxProjectKeysPerSearch keys per request, or client.MaxProjectKeysPerExpandedSearch
+// with an expand.
+func findProjectsPage(
+	ctx context.Context,
+	apiClient *client.Client,
+	keys []string,
+	pageToken string,
+	pageSize int,
+	expand []string,
+) ([]jira.Project, string, error) {
+	token, err := parseProjectsPageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Without keys there is a single batch covering every visible project.
+	batchSize := client.MaxProjectKeysPerSearch
+	if len(expand) > 0 {
+		batchSize = client.MaxProjectKeysPerExpandedSearch
+	}
+
+	batches := client.BatchProjectKeys(keys, batchSize)
+	if len(batches) == 0 {
+		batches = [][]string{nil}
+	}
+
+	if token.BatchIndex >= len(batches) {
+		return nil, "", nil
 	}
-	regexPattern := `\d+` // Regex pattern to match any number in the URL path
-	r := regexp.MustCompile(regexPattern)
-	matches := r.FindStringSubmatch(pathElems[roleIndex+1])
-	// If there are no matches, return an error
-	if len(matches) == 0 {
-		return 0, RoleIDNotFoundErr
+
+	projects, lastPage, err := apiClient.FindProjects(ctx, client.FindProjectsOptions{
+		StartAt:    token.StartAt,
+		MaxResults: pageSize,
+		Keys:       batches[token.BatchIndex],
+		Expand:     expand,
+	})
+	if err != nil {
+		return nil, "", client.WrapError(err, "failed to get projects")
 	}
-	roleID, err := strconv.Atoi(matches[0])
+
+	err = refetchDroppedIssueTypes(ctx, apiClient, projects, expand)
 	if err != nil {
-		return 0, fmt.Errorf("failed to parse role id: %w", err)
+		return nil, "", err
+	}
+
+	next := &projectsPageToken{
+		BatchIndex: token.BatchIndex,
+		StartAt:    token.StartAt + len(projects),
 	}
-	return roleID, nil
+	if lastPage || len(projects) == 0 {
+		next = &projectsPageToken{
+			BatchIndex: token.BatchIndex + 1,
+		}
+	}
+
+	if next.BatchIndex >= len(batches) {
+		return projects, "", nil
+	}
+
+	nextPageToken, err := next.marshal()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return projects, nextPageToken, nil
+}
+
+// refetchDroppedIssueTypes fetches on its own each project that came back without issue
+// types from a search expanding them. Every project has issue types, so none means Jira
+// dropped the expand, which it does without an error for long queries.
+func refetchDroppedIssueTypes(ctx context.Context, apiClient *client.Client, projects []jira.Project, expand []string) error {
+	if !slices.Contains(expand, "issueTypes") {
+		return nil
+	}
+
+	for i := range projects {
+		if len(projects[i].IssueTypes) > 0 {
+			continue
+		}
+
+		ctxzap.Extract(ctx).Info(
+			"baton-jira: project search dropped issue types, fetching project on its own",
+			zap.String("project_key", projects[i].Key),
+		)
+
+		project, err := apiClient.GetProject(ctx, projects[i].ID, expand)
+		if err != nil {
+			return client.WrapError(err, "failed to get project")
+		}
+
+		projects[i].IssueTypes = project.IssueTypes
+	}
+
+	return nil
+}
+
+// projectInScope reports whether a project is among the synced project keys.
+// No keys means every project is synced.
+func projectInScope(projectKeys []string, projectKey string) bool {
+	return len(projectKeys) == 0 || slices.Contains(projectKeys, projectKey)
+}
+
+// explainUserGrantError looks up the user a group or project role add failed for, since
+// Jira answers with a vague 400 both for unknown account IDs and for accounts that cannot
+// hold Jira access. The lookup only runs once the add has failed, leaving grants that
+// succeed at one request.
+func explainUserGrantError(ctx context.Context, apiClient *client.Client, accountID string, err error, message string) error {
+	user, lookupErr := apiClient.GetUser(ctx, accountID, nil)
+	if lookupErr != nil {
+		if client.IsNotFound(lookupErr) {
+			return status.Errorf(codes.NotFound, "baton-jira: %s: user %s does not exist", message, accountID)
+		}
+
+		return client.WrapError(err, message)
+	}
+
+	switch user.AccountType {
+	case "customer":
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"baton-jira: %s: user %s is a customer account, which only has access to service portals and cannot join Jira groups or project roles",
+			message,
+			accountID,
+		)
+	case "app":
+		return status.Errorf(
+			codes.FailedPrecondition,
+			"baton-jira: %s: user %s is an app account, which is managed by its app and cannot join Jira groups or project roles",
+			message,
+			accountID,
+		)
+	}
+
+	return client.WrapError(err, message)
+}
+
+// truncateDescription shortens s to at most maxLength characters, cutting at the last
+// word boundary and marking the cut with an ellipsis.
+func truncateDescription(s string, maxLength int) string {
+	s = strings.Join(strings.Fields(s), " ")
+
+	runes := []rune(s)
+	if len(runes) <= maxLength {
+		return s
+	}
+
+	cut := string(runes[:maxLength-1])
+	if i := strings.LastIndex(cut, " "); i > 0 {
+		cut = cut[:i]
+	}
+
+	return strings.TrimRight(cut, " ,.;:") + "…"
 }
@@ -0,0 +1,308 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestParseProjectsPageToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    projectsPageToken
+		wantErr bool
+	}{
+		{name: "first page", token: ""},
+		{name: "legacy offset", token: "30", want: projectsPageToken{StartAt: 30}},
+		{name: "composite", token: `{"batchIndex":1,"startAt":10}`, want: projectsPageToken{BatchIndex: 1, StartAt: 10}},
+		{name: "invalid", token: "next", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseProjectsPageToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProjectsPageToken(%q) error = %v, want error %v", tt.token, err, tt.wantErr)
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("parseProjectsPageToken(%q) = %+v, want %+v", tt.token, *got, tt.want)
+			}
+		})
+	}
+}
+
+// projectKeysHandler serves a project search over the projects P000 to P079, each with a
+// Task issue type, filtered by the requested keys. It records the keys of each search.
+func projectKeysHandler(t *testing.T, searchKeys *[][]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/3/project/search":
+			keys := r.URL.Query()["keys"]
+			*searchKeys = append(*searchKeys, keys)
+
+			var matching []string
+			for i := 0; i < 80; i++ {
+				key := fmt.Sprintf("P%03d", i)
+				if len(keys) == 0 || slices.Contains(keys, key) {
+					matching = append(matching, key)
+				}
+			}
+
+			startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+			maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+			end := min(startAt+maxResults, len(matching))
+
+			var values []string
+			for _, key := range matching[startAt:end] {
+				values = append(values, fmt.Sprintf(`{"id":"1%s","key":%q,"name":%q,"issueTypes":[{"id":"1","name":"Task"}]}`, key[1:], key, key))
+			}
+			_, _ = fmt.Fprintf(w, `{"isLast":%t,"startAt":%d,"maxResults":%d,"values":[%s]}`, end == len(matching), startAt, maxResults, strings.Join(values, ","))
+		case r.URL.Path == "/rest/api/3/statuses/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"1","name":"Done"}]}`))
+		case strings.Contains(r.URL.Path, "/issue/createmeta/"):
+			_, _ = w.Write([]byte(`{"isLast":true,"fields":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/issueLinkType"):
+			_, _ = w.Write([]byte(`{"issueLinkTypes":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+// testProjectKeys returns the 70 configured keys P000 to P069.
+func testProjectKeys() []string {
+	keys := make([]string, 0, 70)
+	for i := 0; i < 70; i++ {
+		keys = append(keys, fmt.Sprintf("P%03d", i))
+	}
+	return keys
+}
+
+// TestFindProjectsPageBatches walks 70 configured keys, split into batches that each span
+// several search pages, and checks every project is returned exactly once.
+func TestFindProjectsPageBatches(t *testing.T) {
+	tests := []struct {
+		name        string
+		expand      []string
+		wantBatches int
+	}{
+		{name: "search", wantBatches: 2},
+		{name: "expanded search", expand: []string{"issueTypes"}, wantBatches: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var searchKeys [][]string
+			j := newTestJira(t, projectKeysHandler(t, &searchKeys))
+			keys := testProjectKeys()
+
+			var got []string
+			pageToken := ""
+			for {
+				projects, next, err := findProjectsPage(context.Background(), j.apiClient, keys, pageToken, 10, tt.expand)
+				if err != nil {
+					t.Fatalf("findProjectsPage(%q): %v", pageToken, err)
+				}
+				for _, project := range projects {
+					got = append(got, project.Key)
+				}
+				if next == "" {
+					break
+				}
+				if len(got) > len(keys) {
+					t.Fatalf("findProjectsPage returned %d projects, more than the %d keys", len(got), len(keys))
+				}
+				pageToken = next
+			}
+
+			if !slices.Equal(got, keys) {
+				t.Errorf("projects = %v, want %v", got, keys)
+			}
+
+			var batches [][]string
+			for _, search := range searchKeys {
+				if len(batches) == 0 || !slices.Equal(batches[len(batches)-1], search) {
+					batches = append(batches, search)
+				}
+			}
+			if len(batches) != tt.wantBatches {
+				t.Errorf("searched %d batches of keys, want %d", len(batches), tt.wantBatches)
+			}
+			if len(searchKeys) <= len(batches) {
+				t.Errorf("%d searches for %d batches, want batches spanning several pages", len(searchKeys), len(batches))
+			}
+		})
+	}
+}
+
+// droppedExpandHandler serves a project search over the projects P000 to P079 that, like
+// Jira, drops the expand of queries longer than maxQuery, and the projects on their own
+// with their issue types. It records the projects fetched on their own.
+func droppedExpandHandler(t *testing.T, maxQuery int, fetched *[]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/3/project/search":
+			expand := r.URL.Query().Get("expand") == "issueTypes" && len(r.URL.RawQuery) <= maxQuery
+
+			var values []string
+			for _, key := range r.URL.Query()["keys"] {
+				issueTypes := ""
+				if expand {
+					issueTypes = `,"issueTypes":[{"id":"1","name":"Task"}]`
+				}
+				values = append(values, fmt.Sprintf(`{"id":"1%s","key":%q%s}`, key[1:], key, issueTypes))
+			}
+			_, _ = fmt.Fprintf(w, `{"isLast":true,"values":[%s]}`, strings.Join(values, ","))
+		case strings.HasPrefix(r.URL.Path, "/rest/api/3/project/"):
+			id := strings.TrimPrefix(r.URL.Path, "/rest/api/3/project/")
+			if r.URL.Query().Get("expand") != "issueTypes" {
+				t.Errorf("project %s fetched without the issue types expand", id)
+			}
+			*fetched = append(*fetched, "P"+id[1:])
+			_, _ = fmt.Fprintf(w, `{"id":%q,"key":"P%s","issueTypes":[{"id":"1","name":"Task"}]}`, id, id[1:])
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+// TestFindProjectsPageDroppedExpand checks projects whose search dropped the issue types
+// expand are fetched on their own, and that expanded searches are batched small enough
+// to keep it.
+func TestFindProjectsPageDroppedExpand(t *testing.T) {
+	keys := testProjectKeys()[:30]
+
+	tests := []struct {
+		name        string
+		maxQuery    int
+		wantFetched []string
+	}{
+		{name: "expand kept", maxQuery: 4096},
+		// A batch of 25 keys fits, where the 30 keys in a single search would not.
+		{name: "expanded batches fit", maxQuery: 300},
+		{name: "large batch drops the expand", maxQuery: 200, wantFetched: keys[:client.MaxProjectKeysPerExpandedSearch]},
+		{name: "every batch drops the expand", maxQuery: 0, wantFetched: keys},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fetched []string
+			j := newTestJira(t, droppedExpandHandler(t, tt.maxQuery, &fetched))
+
+			var got []string
+			pageToken := ""
+			for {
+				projects, next, err := findProjectsPage(context.Background(), j.apiClient, keys, pageToken, 50, []string{"issueTypes"})
+				if err != nil {
+					t.Fatalf("findProjectsPage(%q): %v", pageToken, err)
+				}
+				for _, project := range projects {
+					if len(project.IssueTypes) == 0 {
+						t.Errorf("project %s has no issue types", project.Key)
+					}
+					got = append(got, project.Key)
+				}
+				if next == "" {
+					break
+				}
+				pageToken = next
+			}
+
+			if !slices.Equal(got, keys) {
+				t.Errorf("projects = %v, want %v", got, keys)
+			}
+			if !slices.Equal(fetched, tt.wantFetched) {
+				t.Errorf("fetched on their own %v, want %v", fetched, tt.wantFetched)
+			}
+		})
+	}
+}
+
+// TestListTicketSchemasProjectKeys checks ListTicketSchemas walks the batches of configured
+// keys with its composite token, listing the schema of every project once.
+func TestListTicketSchemasProjectKeys(t *testing.T) {
+	var searchKeys [][]string
+	j := newTestJira(t, projectKeysHandler(t, &searchKeys))
+	j.projectKeys = testProjectKeys()
+
+	var got []string
+	pageToken := ""
+	for pages := 0; ; pages++ {
+		if pages > len(j.projectKeys) {
+			t.Fatalf("ListTicketSchemas did not finish after %d pages", pages)
+		}
+
+		schemas, next, _, err := j.ListTicketSchemas(context.Background(), &pagination.Token{Token: pageToken, Size: 10})
+		if err != nil {
+			t.Fatalf("ListTicketSchemas(%q): %v", pageToken, err)
+		}
+		for _, schema := range schemas {
+			got = append(got, schema.GetId())
+		}
+		if next == "" {
+			break
+		}
+		pageToken = next
+	}
+
+	want := make([]string, 0, len(j.projectKeys))
+	for _, key := range j.projectKeys {
+		want = append(want, key+":1")
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("schema IDs = %v, want %v", got, want)
+	}
+	for _, keys := range searchKeys {
+		if len(keys) > client.MaxProjectKeysPerExpandedSearch {
+			t.Errorf("searched %d keys at once, want at most %d", len(keys), client.MaxProjectKeysPerExpandedSearch)
+		}
+	}
+}
+
+func TestExplainUserGrantError(t *testing.T) {
+	tests := []struct {
+		name       string
+		userStatus int
+		user       string
+		wantCode   codes.Code
+	}{
+		{name: "unknown account", userStatus: http.StatusNotFound, wantCode: codes.NotFound},
+		{name: "customer account", userStatus: http.StatusOK, user: `{"accountId":"a-1","accountType":"customer"}`, wantCode: codes.FailedPrecondition},
+		{name: "app account", userStatus: http.StatusOK, user: `{"accountId":"a-1","accountType":"app"}`, wantCode: codes.FailedPrecondition},
+		{name: "licensed account", userStatus: http.StatusOK, user: `{"accountId":"a-1","accountType":"atlassian"}`, wantCode: codes.Unknown},
+		{name: "lookup failing", userStatus: http.StatusInternalServerError, wantCode: codes.Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/user" {
+					t.Errorf("unexpected request %s", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.userStatus)
+				_, _ = w.Write([]byte(tt.user))
+			}))
+
+			err := explainUserGrantError(context.Background(), j.apiClient, "a-1", errors.New("Status code: 400"), "failed to add user to group")
+			if got := status.Code(err); got != tt.wantCode {
+				t.Errorf("explainUserGrantError() = %v, want code %v", err, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+var resourceTypeIssueSecurityLevel = &v2.ResourceType{
+	Id:          "issue-security-level",
+	DisplayName: "Issue Security Level",
+}
+
+type issueSecurityLevelResourceType struct {
+	resourceType *v2.ResourceType
+	apiClient    *client.Client
+}
+
+// issueSecurityLevelID is "<scheme ID>:<level ID>", since the members endpoint needs both.
+func issueSecurityLevelID(schemeID string, levelID string) string {
+	return fmt.Sprintf("%s:%s", schemeID, levelID)
+}
+
+func parseIssueSecurityLevelID(id string) (string, string, error) {
+	schemeID, levelID, ok := strings.Cut(id, ":")
+	if !ok || schemeID == "" || levelID == "" {
+		return "", "", fmt.Errorf("invalid issue security level id %q, expected 'schemeID:levelID'", id)
+	}
+
+	return schemeID, levelID, nil
+}
+
+func issueSecurityLevelResource(scheme *client.IssueSecurityScheme, level *client.IssueSecurityLevel) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		fmt.Sprintf("%s (%s)", level.Name, scheme.Name),
+		resourceTypeIssueSecurityLevel,
+		issueSecurityLevelID(strconv.FormatInt(scheme.ID, 10), level.ID),
+		rs.WithDescription(level.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (i *issueSecurityLevelResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return i.resourceType
+}
+
+func issueSecurityLevelBuilder(apiClient *client.Client) *issueSecurityLevelResourceType {
+	return &issueSecurityLevelResourceType{
+		resourceType: resourceTypeIssueSecurityLevel,
+		apiClient:    apiClient,
+	}
+}
+
+// List returns the levels of one scheme per page. The scheme list has no levels, so each
+// scheme is fetched on its own.
+func (i *issueSecurityLevelResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeIssueSecurityLevel.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	schemes, err := i.apiClient.ListIssueSecuritySchemes(ctx)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list issue security schemes")
+	}
+
+	if int(offset) >= len(schemes) {
+		return nil, "", nil, nil
+	}
+
+	scheme, err := i.apiClient.GetIssueSecurityScheme(ctx, strconv.FormatInt(schemes[offset].ID, 10))
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get issue security scheme")
+	}
+
+	var resources []*v2.Resource
+	for j := range scheme.Levels {
+		resource, err := issueSecurityLevelResource(scheme, &scheme.Levels[j])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if int(offset)+1 >= len(schemes) {
+		return resources, "", nil, nil
+	}
+
+	nextPage, err := getPageTokenFromOffset(bag, offset+1)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return resources, nextPage, nil, nil
+}
+
+func (i *issueSecurityLevelResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	options := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup, resourceTypeRole),
+		ent.WithDescription(fmt.Sprintf("Can see issues of %s security level", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s security level %s", resource.DisplayName, memberEntitlement)),
+	}
+
+	return []*v2.Entitlement{ent.NewPermissionEntitlement(resource, memberEntitlement, options...)}, "", nil, nil
+}
+
+// Grants covers user, group and project role members. Members such as the reporter or
+// the assignee are relative to an issue and are skipped.
+func (i *issueSecurityLevelResourceType) Grants(ctx context.Context, resource *v2.Resource, p *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeIssueSecurityLevel.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	schemeID, levelID, err := parseIssueSecurityLevelID(resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	members, lastPage, err := i.apiClient.GetIssueSecurityLevelMembers(ctx, schemeID, levelID, int(offset), resourcePageSize)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get issue security level members")
+	}
+
+	var rv []*v2.Grant
+	for _, member := range members {
+		holder := member.Holder
+
+		switch holder.Type {
+		case "user":
+			accountID := holder.Value
+			if accountID == "" {
+				accountID = holder.Parameter
+			}
+
+			user, err := userResource(ctx, userFromAccountID(accountID))
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(resource, memberEntitlement, user.Id))
+		case "group":
+			// Data Center members only carry the group name, which is also its resource ID there.
+			groupID := holder.Value
+			if groupID == "" {
+				groupID = holder.Parameter
+			}
+
+			group, err := groupResource(ctx, &jira.Group{
+				ID:   groupID,
+				Name: holder.Parameter,
+			}, nil)
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				memberEntitlement,
+				group.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("group:%s:%s", group.Id.Resource, memberEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		case "projectRole":
+			roleID, ok := parseRoleID(holder.Parameter)
+			if !ok {
+				// Custom roles of team-managed projects have string IDs, and no role resource.
+				ctxzap.Extract(ctx).Debug("baton-jira: skipping issue security level member with a custom project role", zap.String("role_id", holder.Parameter))
+				continue
+			}
+
+			role, err := roleResource(&jira.Role{
+				ID: roleID,
+			})
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				memberEntitlement,
+				role.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("role:%d:%s", roleID, appointedEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		}
+	}
+
+	if lastPage {
+		return rv, "", nil, nil
+	}
+
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(members)))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return rv, nextPage, nil, nil
+}
@@ -0,0 +1,167 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+)
+
+// createIssuesPermission is the project permission needed to create issues.
+const createIssuesPermission = "CREATE_ISSUES"
+
+var resourceTypeIssueType = &v2.ResourceType{
+	Id:          "issue-type",
+	DisplayName: "Issue Type",
+}
+
+type issueTypeResourceType struct {
+	resourceType *v2.ResourceType
+	client       *jira.Client
+	apiClient    *client.Client
+	session      *sessionStore
+	projectKeys  []string
+}
+
+func issueTypeResource(issueType *jira.IssueType) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		issueType.Name,
+		resourceTypeIssueType,
+		issueType.ID,
+		rs.WithDescription(issueType.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (i *issueTypeResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return i.resourceType
+}
+
+func issueTypeBuilder(jiraClient *jira.Client, apiClient *client.Client, session *sessionStore, projectKeys []string) *issueTypeResourceType {
+	return &issueTypeResourceType{
+		resourceType: resourceTypeIssueType,
+		client:       jiraClient,
+		apiClient:    apiClient,
+		session:      session,
+		projectKeys:  projectKeys,
+	}
+}
+
+// The issue type endpoint is not paginated, so everything is returned in one page.
+func (i *issueTypeResourceType) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	ctx, source := withSyncSource(ctx)
+	issueTypes, err := i.session.getIssueTypes(ctx, i.apiClient)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list issue types")
+	}
+
+	var resources []*v2.Resource
+	for j := range issueTypes {
+		resource, err := issueTypeResource(&issueTypes[j])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+	source.annotate(resources)
+
+	return resources, "", nil, nil
+}
+
+func (i *issueTypeResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	permissionOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeProjectRole),
+		ent.WithDescription(fmt.Sprintf("Can create %s issues", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s issue type %s", resource.DisplayName, canCreateEntitlement)),
+	}
+
+	return []*v2.Entitlement{
+		ent.NewPermissionEntitlement(resource, canCreateEntitlement, permissionOptions...),
+	}, "", nil, nil
+}
+
+// Grants walks the projects using the issue type, a page of projects at a time, and grants
+// the issue type to the project roles holding CREATE_ISSUES in each project's permission scheme.
+// The permission schemes and the roles of each project are cached in the session, so the walk
+// of the next issue type only lists the projects again.
+func (i *issueTypeResourceType) Grants(ctx context.Context, resource *v2.Resource, pt *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	projects, nextPage, err := findProjectsPage(ctx, i.apiClient, i.projectKeys, pt.Token, resourcePageSize, []string{"issueTypes"})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	var rv []*v2.Grant
+	for _, project := range projects {
+		usesIssueType := slices.ContainsFunc(project.IssueTypes, func(issueType jira.IssueType) bool {
+			return issueType.ID == resource.Id.Resource
+		})
+		if !usesIssueType {
+			continue
+		}
+
+		permissionGrants, err := i.session.getProjectPermissionGrants(ctx, i.apiClient, project.ID)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, fmt.Sprintf("failed to get permission scheme of project %s", project.Key))
+		}
+
+		projectRoleIDs, err := i.session.getProjectRoleIDs(ctx, i.client, project.ID)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, fmt.Sprintf("failed to get roles of project %s", project.Key))
+		}
+
+		for _, permissionGrant := range permissionGrants {
+			if permissionGrant.Permission != createIssuesPermission || permissionGrant.Holder.Type != "projectRole" {
+				continue
+			}
+
+			// Custom roles of team-managed projects have string IDs, so the parameter is kept as is.
+			roleID := permissionGrant.Holder.Parameter
+			if roleID == "" {
+				l.Warn("missing project role id in permission holder")
+				continue
+			}
+
+			// The scheme can be shared with projects that have roles this one lacks, which
+			// have no project role resource here.
+			if !projectRoleIDs[roleID] {
+				continue
+			}
+
+			projectRoleResourceID := &v2.ResourceId{
+				ResourceType: resourceTypeProjectRole.Id,
+				Resource:     projectRoleID(project.ID, roleID),
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				canCreateEntitlement,
+				projectRoleResourceID,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("%s:%s:%s", resourceTypeProjectRole.Id, projectRoleResourceID.Resource, assignedEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id, resourceTypeGroup.Id},
+					},
+				),
+			))
+		}
+	}
+
+	return rv, nextPage, nil, nil
+}
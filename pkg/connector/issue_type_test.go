@@ -0,0 +1,95 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+// issueTypeHandler serves projects 10000 and 10001, which share permission scheme 1 and
+// use issue types 1 and 2. The scheme grants CREATE_ISSUES to roles 10002, 10003 and
+// custom-reviewer, while project 10001 only has role 10002. It counts the project fetches.
+func issueTypeHandler(projectGets map[string]int, mu *sync.Mutex) http.Handler {
+	roleLinks := map[string]string{
+		"10000": `{"Administrators":"https://example.atlassian.net/rest/api/3/project/10000/role/10002","Developers":"https://example.atlassian.net/rest/api/3/project/10000/role/10003","Reviewers":"https://example.atlassian.net/rest/api/3/project/10000/role/custom-reviewer"}`,
+		"10001": `{"Administrators":"https://example.atlassian.net/rest/api/3/project/10001/role/10002"}`,
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/3/project/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[` +
+				`{"id":"10000","key":"A","issueTypes":[{"id":"1"},{"id":"2"}]},` +
+				`{"id":"10001","key":"B","issueTypes":[{"id":"1"},{"id":"2"}]}]}`))
+		case strings.HasSuffix(r.URL.Path, "/permissionscheme") && strings.HasPrefix(r.URL.Path, "/rest/api/3/project/"):
+			_, _ = w.Write([]byte(`{"id":1,"name":"Default"}`))
+		case r.URL.Path == "/rest/api/3/permissionscheme/1/permission":
+			_, _ = w.Write([]byte(`{"permissions":[` +
+				`{"id":1,"permission":"CREATE_ISSUES","holder":{"type":"projectRole","parameter":"10002"}},` +
+				`{"id":2,"permission":"CREATE_ISSUES","holder":{"type":"projectRole","parameter":"10003"}},` +
+				`{"id":3,"permission":"CREATE_ISSUES","holder":{"type":"projectRole","parameter":"custom-reviewer"}},` +
+				`{"id":4,"permission":"BROWSE_PROJECTS","holder":{"type":"projectRole","parameter":"10004"}}]}`))
+		case strings.HasPrefix(r.URL.Path, "/rest/api/2/project/"):
+			projectID := strings.TrimPrefix(r.URL.Path, "/rest/api/2/project/")
+			mu.Lock()
+			projectGets[projectID]++
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"id":"` + projectID + `","roles":` + roleLinks[projectID] + `}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestIssueTypeGrants(t *testing.T) {
+	projectGets := make(map[string]int)
+	var mu sync.Mutex
+	j := newTestJira(t, issueTypeHandler(projectGets, &mu))
+	i := issueTypeBuilder(j.client, j.apiClient, j.session, nil)
+
+	tests := []struct {
+		issueTypeID string
+		wantRoles   []string
+	}{
+		{issueTypeID: "1", wantRoles: []string{"10000:10002", "10000:10003", "10000:custom-reviewer", "10001:10002"}},
+		{issueTypeID: "2", wantRoles: []string{"10000:10002", "10000:10003", "10000:custom-reviewer", "10001:10002"}},
+		{issueTypeID: "3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.issueTypeID, func(t *testing.T) {
+			resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeIssueType.Id, Resource: tt.issueTypeID}}
+			grants, next, _, err := i.Grants(context.Background(), resource, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("Grants() error = %v", err)
+			}
+			if next != "" {
+				t.Errorf("Grants() next page = %q, want none", next)
+			}
+
+			var roles []string
+			for _, g := range grants {
+				if g.GetPrincipal().GetId().GetResourceType() != resourceTypeProjectRole.Id {
+					t.Errorf("principal type = %s, want %s", g.GetPrincipal().GetId().GetResourceType(), resourceTypeProjectRole.Id)
+				}
+				roles = append(roles, g.GetPrincipal().GetId().GetResource())
+			}
+			if !slices.Equal(roles, tt.wantRoles) {
+				t.Errorf("granted roles = %v, want %v", roles, tt.wantRoles)
+			}
+		})
+	}
+
+	for projectID, gets := range projectGets {
+		if gets != 1 {
+			t.Errorf("project %s fetched %d times, want once", projectID, gets)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+var resourceTypeJSMOrganization = &v2.ResourceType{
+	Id:          "jsm-organization",
+	DisplayName: "JSM Organization",
+	Traits: []v2.ResourceType_Trait{
+		v2.ResourceType_TRAIT_GROUP,
+	},
+}
+
+type jsmOrganizationResourceType struct {
+	resourceType *v2.ResourceType
+	client       *client.ServiceDeskClient
+}
+
+func jsmOrganizationResource(organization *client.Organization) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"id":   organization.ID,
+		"name": organization.Name,
+	}
+
+	groupTraitOptions := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+
+	resource, err := rs.NewGroupResource(organization.Name, resourceTypeJSMOrganization, organization.ID, groupTraitOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (o *jsmOrganizationResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return o.resourceType
+}
+
+func jsmOrganizationBuilder(client *client.ServiceDeskClient) *jsmOrganizationResourceType {
+	return &jsmOrganizationResourceType{
+		resourceType: resourceTypeJSMOrganization,
+		client:       client,
+	}
+}
+
+func (o *jsmOrganizationResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeJSMOrganization.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	organizations, lastPage, err := o.client.ListOrganizations(ctx, int(offset), resourcePageSize)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list jsm organizations")
+	}
+
+	var resources []*v2.Resource
+	for i := range organizations {
+		resource, err := jsmOrganizationResource(&organizations[i])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	// An empty page that is not marked last would otherwise be requested forever.
+	if lastPage || len(organizations) == 0 {
+		return resources, "", nil, nil
+	}
+
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(organizations)))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return resources, nextPage, nil, nil
+}
+
+func (o *jsmOrganizationResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Member of %s organization", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s organization %s", resource.DisplayName, memberEntitlement)),
+	}
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, memberEntitlement, assigmentOptions...))
+
+	return rv, "", nil, nil
+}
+
+func (o *jsmOrganizationResourceType) Grants(ctx context.Context, resource *v2.Resource, p *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeJSMOrganization.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	customers, lastPage, err := o.client.ListOrganizationUsers(ctx, resource.Id.Resource, int(offset), resourcePageSize)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list jsm organization users")
+	}
+
+	var rv []*v2.Grant
+	for _, customer := range customers {
+		// Customers are granted by account ID even when they are not synced as users,
+		// so the grants line up once customer accounts are synced.
+		user, err := userResource(ctx, userFromCustomer(&customer))
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		rv = append(rv, grant.NewGrant(resource, memberEntitlement, user.Id))
+	}
+
+	if lastPage || len(customers) == 0 {
+		return rv, "", nil, nil
+	}
+
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(customers)))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return rv, nextPage, nil, nil
+}
@@ -0,0 +1,103 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+func TestJSMOrganizationPaging(t *testing.T) {
+	tests := []struct {
+		name          string
+		page          string
+		wantCount     int
+		wantNextToken bool
+	}{
+		{
+			name:          "full page",
+			page:          `{"size":1,"start":0,"limit":1,"isLastPage":false,"values":[%s]}`,
+			wantCount:     1,
+			wantNextToken: true,
+		},
+		{
+			name:      "last page",
+			page:      `{"size":1,"start":0,"limit":1,"isLastPage":true,"values":[%s]}`,
+			wantCount: 1,
+		},
+		{
+			name: "empty page not marked last",
+			page: `{"size":0,"start":0,"limit":50,"isLastPage":false,"values":[]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/rest/servicedeskapi/organization":
+					_, _ = w.Write([]byte(strings.Replace(tt.page, "%s", `{"id":"1","name":"Example Org"}`, 1)))
+				case "/rest/servicedeskapi/organization/1/user":
+					_, _ = w.Write([]byte(strings.Replace(tt.page, "%s", `{"accountId":"c-1","displayName":"Customer","active":true}`, 1)))
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			o := jsmOrganizationBuilder(client.NewServiceDeskClient(j.client))
+
+			resources, next, _, err := o.List(context.Background(), nil, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(resources) != tt.wantCount || (next != "") != tt.wantNextToken {
+				t.Errorf("List() = %d resources, next %q; want %d, next token %v", len(resources), next, tt.wantCount, tt.wantNextToken)
+			}
+
+			organization, err := jsmOrganizationResource(&client.Organization{ID: "1", Name: "Example Org"})
+			if err != nil {
+				t.Fatalf("jsmOrganizationResource: %v", err)
+			}
+			grants, next, _, err := o.Grants(context.Background(), organization, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("Grants: %v", err)
+			}
+			if len(grants) != tt.wantCount || (next != "") != tt.wantNextToken {
+				t.Errorf("Grants() = %d grants, next %q; want %d, next token %v", len(grants), next, tt.wantCount, tt.wantNextToken)
+			}
+		})
+	}
+}
+
+func TestJSMOrganizationGrantsCustomer(t *testing.T) {
+	j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"isLastPage":true,"values":[{"accountId":"c-1","displayName":"Customer","active":true}]}`))
+	}))
+	o := jsmOrganizationBuilder(client.NewServiceDeskClient(j.client))
+
+	organization, err := jsmOrganizationResource(&client.Organization{ID: "1", Name: "Example Org"})
+	if err != nil {
+		t.Fatalf("jsmOrganizationResource: %v", err)
+	}
+	grants, _, _, err := o.Grants(context.Background(), organization, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Grants: %v", err)
+	}
+	if len(grants) != 1 {
+		t.Fatalf("Grants() = %d grants, want 1", len(grants))
+	}
+
+	want := &v2.ResourceId{ResourceType: resourceTypeUser.Id, Resource: "c-1"}
+	if got := grants[0].GetPrincipal().GetId(); got.GetResourceType() != want.GetResourceType() || got.GetResource() != want.GetResource() {
+		t.Errorf("principal = %v, want %v", got, want)
+	}
+	if got := grants[0].GetEntitlement().GetId(); got != "jsm-organization:1:member" {
+		t.Errorf("entitlement = %q, want %q", got, "jsm-organization:1:member")
+	}
+}
@@ -0,0 +1,376 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// siteSyncer is the syncer of one resource type on one site of the org.
+type siteSyncer struct {
+	syncer connectorbuilder.ResourceSyncer
+	// siteID and siteName are empty on the primary site, whose resource IDs keep no prefix.
+	siteID   string
+	siteName string
+}
+
+// multiSiteSyncer syncs one resource type across the primary site and the additional
+// sites of the org. Resource IDs from additional sites are prefixed with "<site ID>/",
+// except for users, whose account IDs are global, and sites, whose IDs are site IDs already.
+type multiSiteSyncer struct {
+	resourceType *v2.ResourceType
+	// sites starts with the primary site.
+	sites []siteSyncer
+	// metrics are logged once the resource type is listed, see listedResourceType.
+	metrics *client.Metrics
+}
+
+// multiSiteProvisioner routes grants and revokes to the site owning the entitlement.
+type multiSiteProvisioner struct {
+	*multiSiteSyncer
+}
+
+// multiSiteResourceManager creates resources on the primary site, and deletes them on the
+// site owning them.
+type multiSiteResourceManager struct {
+	*multiSiteProvisioner
+}
+
+// multiSiteAccountManager creates accounts on the primary site. Accounts are global to the
+// org, so they do not need a site.
+type multiSiteAccountManager struct {
+	*multiSiteSyncer
+	connectorbuilder.AccountManager
+}
+
+// newMultiSiteSyncer wraps the syncers of one resource type, the primary site's first,
+// keeping whatever provisioning the primary site's syncer supports.
+func newMultiSiteSyncer(ctx context.Context, sites []siteSyncer, metrics *client.Metrics) connectorbuilder.ResourceSyncer {
+	m := &multiSiteSyncer{
+		resourceType: sites[0].syncer.ResourceType(ctx),
+		sites:        sites,
+		metrics:      metrics,
+	}
+
+	switch primary := sites[0].syncer.(type) {
+	case connectorbuilder.ResourceProvisioner:
+		if _, ok := primary.(connectorbuilder.ResourceManager); ok {
+			return &multiSiteResourceManager{multiSiteProvisioner: &multiSiteProvisioner{multiSiteSyncer: m}}
+		}
+		return &multiSiteProvisioner{multiSiteSyncer: m}
+	case connectorbuilder.AccountManager:
+		return &multiSiteAccountManager{multiSiteSyncer: m, AccountManager: primary}
+	}
+
+	return m
+}
+
+// siteScoped reports whether resources of the type need a site prefix.
+func siteScoped(resourceTypeID string) bool {
+	return resourceTypeID != resourceTypeUser.Id && resourceTypeID != resourceTypeSite.Id
+}
+
+func (s siteSyncer) primary() bool {
+	return s.siteID == ""
+}
+
+func (s siteSyncer) scopeResourceID(id *v2.ResourceId) *v2.ResourceId {
+	if id == nil || s.primary() || !siteScoped(id.ResourceType) {
+		return id
+	}
+
+	return &v2.ResourceId{
+		ResourceType: id.ResourceType,
+		Resource:     fmt.Sprintf("%s/%s", s.siteID, id.Resource),
+	}
+}
+
+func (s siteSyncer) unscopeResourceID(id *v2.ResourceId) *v2.ResourceId {
+	if id == nil || s.primary() || !siteScoped(id.ResourceType) {
+		return id
+	}
+
+	return &v2.ResourceId{
+		ResourceType: id.ResourceType,
+		Resource:     strings.TrimPrefix(id.Resource, s.siteID+"/"),
+	}
+}
+
+// scopeResource prefixes the IDs of a resource and names the site in its display name.
+func (s siteSyncer) scopeResource(resource *v2.Resource) *v2.Resource {
+	if resource == nil || s.primary() {
+		return resource
+	}
+
+	rv := proto.Clone(resource).(*v2.Resource)
+	rv.Id = s.scopeResourceID(resource.Id)
+	rv.ParentResourceId = s.scopeResourceID(resource.ParentResourceId)
+	if siteScoped(resource.Id.ResourceType) {
+		rv.DisplayName = fmt.Sprintf("%s (%s)", resource.DisplayName, s.siteName)
+	}
+
+	return rv
+}
+
+func (s siteSyncer) unscopeResource(resource *v2.Resource) *v2.Resource {
+	if resource == nil || s.primary() {
+		return resource
+	}
+
+	rv := proto.Clone(resource).(*v2.Resource)
+	rv.Id = s.unscopeResourceID(resource.Id)
+	rv.ParentResourceId = s.unscopeResourceID(resource.ParentResourceId)
+
+	return rv
+}
+
+// moveEntitlement points an entitlement at another resource, keeping its slug.
+func moveEntitlement(entitlement *v2.Entitlement, resource *v2.Resource) *v2.Entitlement {
+	slug := strings.TrimPrefix(entitlement.Id, ent.NewEntitlementID(entitlement.Resource, ""))
+
+	rv := proto.Clone(entitlement).(*v2.Entitlement)
+	rv.Resource = resource
+	rv.Id = ent.NewEntitlementID(resource, slug)
+
+	return rv
+}
+
+// scopeEntitlementID prefixes the resource of an entitlement ID, e.g. "group:<id>:member".
+func (s siteSyncer) scopeEntitlementID(entitlementID string) string {
+	resourceType, rest, ok := strings.Cut(entitlementID, ":")
+	if !ok || s.primary() || !siteScoped(resourceType) {
+		return entitlementID
+	}
+
+	return fmt.Sprintf("%s:%s/%s", resourceType, s.siteID, rest)
+}
+
+// scopeGrant moves a grant onto the site scoped resource, and prefixes its principal and
+// the entitlements it expands.
+func (s siteSyncer) scopeGrant(g *v2.Grant, resource *v2.Resource) *v2.Grant {
+	if s.primary() {
+		return g
+	}
+
+	g.Entitlement = moveEntitlement(g.Entitlement, resource)
+	g.Principal = s.scopeResource(g.Principal)
+	g.Id = fmt.Sprintf("%s:%s:%s", g.Entitlement.Id, g.Principal.Id.ResourceType, g.Principal.Id.Resource)
+
+	annos := annotations.Annotations(g.Annotations)
+	expandable := &v2.GrantExpandable{}
+	ok, err := annos.Pick(expandable)
+	if err == nil && ok {
+		for i, entitlementID := range expandable.EntitlementIds {
+			expandable.EntitlementIds[i] = s.scopeEntitlementID(entitlementID)
+		}
+		annos.Update(expandable)
+		g.Annotations = annos
+	}
+
+	return g
+}
+
+// route returns the site owning a resource, and the resource as that site knows it.
+func (m *multiSiteSyncer) route(resource *v2.Resource) (siteSyncer, *v2.Resource) {
+	for _, site := range m.sites[1:] {
+		switch {
+		case resource.Id.ResourceType == resourceTypeSite.Id && resource.Id.Resource == site.siteID,
+			siteScoped(resource.Id.ResourceType) && strings.HasPrefix(resource.Id.Resource, site.siteID+"/"):
+			return site, site.unscopeResource(resource)
+		}
+	}
+
+	return m.sites[0], resource
+}
+
+// listedResourceType logs the metrics once the top-level listing of the resource type
+// ends. The SDK runs it once per sync, whereas child types are also listed once per
+// parent, which is not logged.
+func (m *multiSiteSyncer) listedResourceType(ctx context.Context) {
+	logMetrics(ctx, m.metrics, "listed resource type", zap.String("resource_type", m.resourceType.Id))
+}
+
+// multiSiteToken pages through the sites one after the other.
+type multiSiteToken struct {
+	Site  int    `json:"site"`
+	Token string `json:"token"`
+}
+
+func (m *multiSiteSyncer) ResourceType(_ context.Context) *v2.ResourceType {
+	return m.resourceType
+}
+
+func (m *multiSiteSyncer) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	// Child resources, e.g. components, are listed on the site owning their parent.
+	if parentResourceID != nil {
+		site, parent := m.route(&v2.Resource{Id: parentResourceID})
+
+		resources, nextPage, annos, err := site.syncer.List(ctx, parent.Id, pToken)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		for i := range resources {
+			resources[i] = site.scopeResource(resources[i])
+		}
+
+		return resources, nextPage, annos, nil
+	}
+
+	token := multiSiteToken{}
+	if pToken.Token != "" {
+		err := json.Unmarshal([]byte(pToken.Token), &token)
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	if token.Site >= len(m.sites) {
+		return nil, "", nil, nil
+	}
+
+	site := m.sites[token.Site]
+	resources, nextPage, annos, err := site.syncer.List(
+		ctx,
+		site.unscopeResourceID(parentResourceID),
+		&pagination.Token{Size: pToken.Size, Token: token.Token},
+	)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for i := range resources {
+		resources[i] = site.scopeResource(resources[i])
+	}
+
+	next := multiSiteToken{Site: token.Site, Token: nextPage}
+	if nextPage == "" {
+		next = multiSiteToken{Site: token.Site + 1}
+		if next.Site >= len(m.sites) {
+			m.listedResourceType(ctx)
+			return resources, "", annos, nil
+		}
+	}
+
+	data, err := json.Marshal(next)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return resources, string(data), annos, nil
+}
+
+func (m *multiSiteSyncer) Entitlements(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	site, siteResource := m.route(resource)
+
+	entitlements, nextPage, annos, err := site.syncer.Entitlements(ctx, siteResource, pToken)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if !site.primary() {
+		for i := range entitlements {
+			entitlements[i] = moveEntitlement(entitlements[i], resource)
+		}
+	}
+
+	return entitlements, nextPage, annos, nil
+}
+
+func (m *multiSiteSyncer) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	site, siteResource := m.route(resource)
+
+	grants, nextPage, annos, err := site.syncer.Grants(ctx, siteResource, pToken)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for i := range grants {
+		grants[i] = site.scopeGrant(grants[i], resource)
+	}
+
+	return grants, nextPage, annos, nil
+}
+
+func (m *multiSiteProvisioner) provisioner(site siteSyncer) (connectorbuilder.ResourceProvisioner, error) {
+	provisioner, ok := site.syncer.(connectorbuilder.ResourceProvisioner)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "baton-jira: %s cannot be provisioned on site %s", m.resourceType.Id, site.siteName)
+	}
+
+	return provisioner, nil
+}
+
+func (m *multiSiteProvisioner) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	site, siteResource := m.route(entitlement.Resource)
+
+	provisioner, err := m.provisioner(site)
+	if err != nil {
+		return nil, err
+	}
+
+	if !site.primary() {
+		entitlement = moveEntitlement(entitlement, siteResource)
+		principal = site.unscopeResource(principal)
+	}
+
+	return provisioner.Grant(ctx, principal, entitlement)
+}
+
+func (m *multiSiteProvisioner) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	site, siteResource := m.route(grant.Entitlement.Resource)
+
+	provisioner, err := m.provisioner(site)
+	if err != nil {
+		return nil, err
+	}
+
+	if !site.primary() {
+		grant = proto.Clone(grant).(*v2.Grant)
+		grant.Entitlement = moveEntitlement(grant.Entitlement, siteResource)
+		grant.Principal = site.unscopeResource(grant.Principal)
+	}
+
+	return provisioner.Revoke(ctx, grant)
+}
+
+func (m *multiSiteResourceManager) manager(site siteSyncer) (connectorbuilder.ResourceManager, error) {
+	manager, ok := site.syncer.(connectorbuilder.ResourceManager)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "baton-jira: %s cannot be managed on site %s", m.resourceType.Id, site.siteName)
+	}
+
+	return manager, nil
+}
+
+func (m *multiSiteResourceManager) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	manager, err := m.manager(m.sites[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return manager.Create(ctx, resource)
+}
+
+func (m *multiSiteResourceManager) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	site, siteResource := m.route(&v2.Resource{Id: resourceId})
+
+	manager, err := m.manager(site)
+	if err != nil {
+		return nil, err
+	}
+
+	return manager.Delete(ctx, siteResource.Id)
+}
@@ -0,0 +1,84 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// fakeComponentSyncer lists one component per parent project, and none without a parent,
+// like the component builder.
+type fakeComponentSyncer struct{}
+
+func (fakeComponentSyncer) ResourceType(_ context.Context) *v2.ResourceType {
+	return resourceTypeComponent
+}
+
+func (fakeComponentSyncer) List(_ context.Context, parentResourceID *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID == nil {
+		return nil, "", nil, nil
+	}
+
+	return []*v2.Resource{{
+		Id:               &v2.ResourceId{ResourceType: resourceTypeComponent.Id, Resource: "c-" + parentResourceID.Resource},
+		ParentResourceId: parentResourceID,
+	}}, "", nil, nil
+}
+
+func (fakeComponentSyncer) Entitlements(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+func (fakeComponentSyncer) Grants(_ context.Context, _ *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+func TestMultiSiteListLogsOncePerType(t *testing.T) {
+	var logs bytes.Buffer
+	logger := zap.New(zapcore.NewCore(zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()), zapcore.AddSync(&logs), zap.InfoLevel))
+	ctx := ctxzap.ToContext(context.Background(), logger)
+
+	m := newMultiSiteSyncer(ctx, []siteSyncer{
+		{syncer: fakeComponentSyncer{}},
+		{syncer: fakeComponentSyncer{}, siteID: "site-2", siteName: "second.atlassian.net"},
+	}, client.NewMetrics())
+
+	// The SDK lists the type once at the top, then once for each parent.
+	pages := []struct {
+		parent *v2.ResourceId
+	}{
+		{},
+		{parent: &v2.ResourceId{ResourceType: resourceTypeProject.Id, Resource: "10000"}},
+		{parent: &v2.ResourceId{ResourceType: resourceTypeProject.Id, Resource: "10001"}},
+		{parent: &v2.ResourceId{ResourceType: resourceTypeProject.Id, Resource: "site-2/10000"}},
+	}
+	for _, page := range pages {
+		token := ""
+		for {
+			resources, next, _, err := m.List(ctx, page.parent, &pagination.Token{Token: token})
+			if err != nil {
+				t.Fatalf("List(%v): %v", page.parent, err)
+			}
+			if page.parent != nil && (len(resources) != 1 || resources[0].GetParentResourceId().GetResource() != page.parent.GetResource()) {
+				t.Errorf("List(%v) = %v, want one component of the parent", page.parent, resources)
+			}
+			if next == "" {
+				break
+			}
+			token = next
+		}
+	}
+
+	if got := strings.Count(logs.String(), "listed resource type"); got != 1 {
+		t.Errorf("logged %d times, want once:\n%s", got, logs.String())
+	}
+}
@@ -0,0 +1,207 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+var resourceTypeNotificationScheme = &v2.ResourceType{
+	Id:          "notification-scheme",
+	DisplayName: "Notification Scheme",
+}
+
+// nonSlugCharacters are replaced by dashes in entitlement slugs built from event names.
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9]+`)
+
+type notificationSchemeResourceType struct {
+	resourceType *v2.ResourceType
+	apiClient    *client.Client
+}
+
+func notificationSchemeResource(scheme *client.NotificationScheme) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		scheme.Name,
+		resourceTypeNotificationScheme,
+		strconv.FormatInt(scheme.ID, 10),
+		rs.WithDescription(scheme.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// notifiedOnEntitlement is the slug of an event, e.g. "notified-on-issue-created".
+func notifiedOnEntitlement(eventName string) string {
+	return "notified-on-" + strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(eventName), "-"), "-")
+}
+
+func (n *notificationSchemeResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return n.resourceType
+}
+
+func notificationSchemeBuilder(apiClient *client.Client) *notificationSchemeResourceType {
+	return &notificationSchemeResourceType{
+		resourceType: resourceTypeNotificationScheme,
+		apiClient:    apiClient,
+	}
+}
+
+func (n *notificationSchemeResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeNotificationScheme.Id})
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	schemes, lastPage, err := n.apiClient.ListNotificationSchemes(ctx, int(offset), resourcePageSize)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list notification schemes")
+	}
+
+	var resources []*v2.Resource
+	for i := range schemes {
+		resource, err := notificationSchemeResource(&schemes[i])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	if lastPage {
+		return resources, "", nil, nil
+	}
+
+	nextPage, err := getPageTokenFromOffset(bag, offset+int64(len(schemes)))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return resources, nextPage, nil, nil
+}
+
+// Entitlements has one entitlement per event the scheme notifies about.
+func (n *notificationSchemeResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	scheme, err := n.apiClient.GetNotificationScheme(ctx, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get notification scheme")
+	}
+
+	var rv []*v2.Entitlement
+	for _, event := range scheme.Events {
+		options := []ent.EntitlementOption{
+			ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup, resourceTypeRole),
+			ent.WithDescription(fmt.Sprintf("Notified on %s by %s notification scheme", event.Event.Name, resource.DisplayName)),
+			ent.WithDisplayName(fmt.Sprintf("%s notification scheme %s", resource.DisplayName, event.Event.Name)),
+		}
+		rv = append(rv, ent.NewPermissionEntitlement(resource, notifiedOnEntitlement(event.Event.Name), options...))
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grants covers user, group and project role notifications. Recipients such as the
+// reporter, the assignee or the watchers are relative to an issue and are skipped.
+func (n *notificationSchemeResourceType) Grants(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	scheme, err := n.apiClient.GetNotificationScheme(ctx, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get notification scheme")
+	}
+
+	var rv []*v2.Grant
+	for _, event := range scheme.Events {
+		entitlement := notifiedOnEntitlement(event.Event.Name)
+
+		for _, notification := range event.Notifications {
+			switch notification.NotificationType {
+			case "User":
+				accountID := notification.Parameter
+				if notification.User != nil && notification.User.AccountID != "" {
+					accountID = notification.User.AccountID
+				}
+
+				user, err := userResource(ctx, userFromAccountID(accountID))
+				if err != nil {
+					return nil, "", nil, err
+				}
+
+				rv = append(rv, grant.NewGrant(resource, entitlement, user.Id))
+			case "Group":
+				// Data Center groups only carry the name, which is also their resource ID there.
+				groupID := notification.Parameter
+				groupName := notification.Parameter
+				if notification.Group != nil {
+					groupName = notification.Group.Name
+					if notification.Group.GroupID != "" {
+						groupID = notification.Group.GroupID
+					}
+				}
+
+				group, err := groupResource(ctx, &jira.Group{
+					ID:   groupID,
+					Name: groupName,
+				}, nil)
+				if err != nil {
+					return nil, "", nil, err
+				}
+
+				rv = append(rv, grant.NewGrant(
+					resource,
+					entitlement,
+					group.Id,
+					grant.WithAnnotation(
+						&v2.GrantExpandable{
+							EntitlementIds:  []string{fmt.Sprintf("group:%s:%s", group.Id.Resource, memberEntitlement)},
+							Shallow:         true,
+							ResourceTypeIds: []string{resourceTypeUser.Id},
+						},
+					),
+				))
+			case "ProjectRole":
+				roleID, ok := parseRoleID(notification.Parameter)
+				if !ok {
+					// Custom roles of team-managed projects have string IDs, and no role resource.
+					ctxzap.Extract(ctx).Debug("baton-jira: skipping notification to a custom project role", zap.String("role_id", notification.Parameter))
+					continue
+				}
+
+				role, err := roleResource(&jira.Role{
+					ID: roleID,
+				})
+				if err != nil {
+					return nil, "", nil, err
+				}
+
+				rv = append(rv, grant.NewGrant(
+					resource,
+					entitlement,
+					role.Id,
+					grant.WithAnnotation(
+						&v2.GrantExpandable{
+							EntitlementIds:  []string{fmt.Sprintf("role:%d:%s", roleID, appointedEntitlement)},
+							Shallow:         true,
+							ResourceTypeIds: []string{resourceTypeUser.Id},
+						},
+					),
+				))
+			}
+		}
+	}
+
+	return rv, "", nil, nil
+}
@@ -0,0 +1,50 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+func TestNotificationSchemeProjectRoleGrants(t *testing.T) {
+	tests := []struct {
+		name           string
+		parameter      string
+		wantPrincipals []string
+	}{
+		{name: "classic role", parameter: "10002", wantPrincipals: []string{"role:10002"}},
+		{name: "custom role skipped", parameter: "b7c1-reviewer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/notificationscheme/10100" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":10100,"name":"Default","notificationSchemeEvents":[{"event":{"id":1,"name":"Issue created"},"notifications":[{"id":1,"notificationType":"ProjectRole","parameter":"` + tt.parameter + `"}]}]}`))
+			}))
+			n := &notificationSchemeResourceType{resourceType: resourceTypeNotificationScheme, apiClient: j.apiClient}
+
+			resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeNotificationScheme.Id, Resource: "10100"}}
+			grants, _, _, err := n.Grants(context.Background(), resource, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("Grants() error = %v", err)
+			}
+
+			var principals []string
+			for _, g := range grants {
+				principals = append(principals, g.GetPrincipal().GetId().GetResourceType()+":"+g.GetPrincipal().GetId().GetResource())
+			}
+			if !slices.Equal(principals, tt.wantPrincipals) {
+				t.Errorf("principals = %v, want %v", principals, tt.wantPrincipals)
+			}
+		})
+	}
+}
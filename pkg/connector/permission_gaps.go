@@ -0,0 +1,103 @@
+package connector
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// Operations skipped for lack of a permission, as reported in permissionGap.Operation.
+const (
+	gapTicketSchema       = "ticket_schema"
+	gapNotificationScheme = "project_notification_scheme"
+)
+
+// permissionGap is one thing the sync skipped because the Jira account may not read it.
+// Its JSON form is logged for operators and must stay stable.
+type permissionGap struct {
+	Site       string `json:"site"`
+	Operation  string `json:"operation"`
+	ResourceID string `json:"resource_id"`
+	Error      string `json:"error"`
+}
+
+// permissionGaps collects the permission gaps of a sync. It is shared by every site and
+// safe for concurrent use, and a nil permissionGaps collects nothing.
+type permissionGaps struct {
+	mu   sync.Mutex
+	gaps map[permissionGap]struct{}
+}
+
+func newPermissionGaps() *permissionGaps {
+	return &permissionGaps{
+		gaps: make(map[permissionGap]struct{}),
+	}
+}
+
+// record adds a gap. The same gap found again, e.g. on a later page, is kept once.
+func (p *permissionGaps) record(site string, operation string, resourceID string, err error) {
+	if p == nil {
+		return
+	}
+
+	gap := permissionGap{
+		Site:       site,
+		Operation:  operation,
+		ResourceID: resourceID,
+	}
+	if err != nil {
+		gap.Error = err.Error()
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.gaps[gap] = struct{}{}
+}
+
+// list returns the gaps sorted by site, operation and resource.
+func (p *permissionGaps) list() []permissionGap {
+	if p == nil {
+		return nil
+	}
+
+	p.mu.Lock()
+	rv := make([]permissionGap, 0, len(p.gaps))
+	for gap := range p.gaps {
+		rv = append(rv, gap)
+	}
+	p.mu.Unlock()
+
+	sort.Slice(rv, func(i, k int) bool {
+		if rv[i].Site != rv[k].Site {
+			return rv[i].Site < rv[k].Site
+		}
+		if rv[i].Operation != rv[k].Operation {
+			return rv[i].Operation < rv[k].Operation
+		}
+		if rv[i].ResourceID != rv[k].ResourceID {
+			return rv[i].ResourceID < rv[k].ResourceID
+		}
+		return rv[i].Error < rv[k].Error
+	})
+
+	return rv
+}
+
+// LogPermissionGaps logs everything the sync skipped for lack of a permission, in one
+// warning, so operators can grant what is missing. Nothing is logged without gaps.
+func (j *Jira) LogPermissionGaps(ctx context.Context) {
+	gaps := j.permissionGaps.list()
+	if len(gaps) == 0 {
+		return
+	}
+
+	ctxzap.Extract(ctx).Warn(
+		"jira sync skipped data the account may not read",
+		zap.Int("permission_gap_count", len(gaps)),
+		zap.Any("permission_gaps", gaps),
+	)
+}
@@ -0,0 +1,133 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"sync"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+func TestPermissionGapsRecord(t *testing.T) {
+	denied := errors.New("forbidden")
+
+	tests := []struct {
+		name    string
+		records []permissionGap
+		want    []permissionGap
+	}{
+		{name: "no gaps", want: []permissionGap{}},
+		{
+			name: "sorted",
+			records: []permissionGap{
+				{Site: "b.atlassian.net", Operation: gapTicketSchema, ResourceID: "SW"},
+				{Site: "a.atlassian.net", Operation: gapTicketSchema, ResourceID: "SW"},
+				{Site: "a.atlassian.net", Operation: gapNotificationScheme, ResourceID: "10000"},
+			},
+			want: []permissionGap{
+				{Site: "a.atlassian.net", Operation: gapNotificationScheme, ResourceID: "10000", Error: "forbidden"},
+				{Site: "a.atlassian.net", Operation: gapTicketSchema, ResourceID: "SW", Error: "forbidden"},
+				{Site: "b.atlassian.net", Operation: gapTicketSchema, ResourceID: "SW", Error: "forbidden"},
+			},
+		},
+		{
+			name: "found again on a later page",
+			records: []permissionGap{
+				{Site: "a.atlassian.net", Operation: gapTicketSchema, ResourceID: "SW"},
+				{Site: "a.atlassian.net", Operation: gapTicketSchema, ResourceID: "SW"},
+			},
+			want: []permissionGap{
+				{Site: "a.atlassian.net", Operation: gapTicketSchema, ResourceID: "SW", Error: "forbidden"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gaps := newPermissionGaps()
+
+			var wg sync.WaitGroup
+			for _, gap := range tt.records {
+				wg.Add(1)
+				go func(gap permissionGap) {
+					defer wg.Done()
+					gaps.record(gap.Site, gap.Operation, gap.ResourceID, denied)
+				}(gap)
+			}
+			wg.Wait()
+
+			if got := gaps.list(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("list() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	var nilGaps *permissionGaps
+	nilGaps.record("a.atlassian.net", gapTicketSchema, "SW", denied)
+	if got := nilGaps.list(); got != nil {
+		t.Errorf("nil list() = %v, want nil", got)
+	}
+}
+
+// TestPermissionGapsSummary has the account denied both the create metadata and the
+// notification scheme of project SW, and checks both gaps end up in the summary.
+func TestPermissionGapsSummary(t *testing.T) {
+	j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/project/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"10000","key":"SW","name":"Software","issueTypes":[{"id":"10001","name":"Task"}]}]}`))
+		case "/rest/api/3/statuses/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"1","name":"Done"}]}`))
+		case "/rest/api/3/issue/createmeta/10000/issuetypes/10001", "/rest/api/3/project/10000/notificationscheme":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errorMessages":["You do not have permission to view this."]}`))
+		case "/rest/api/3/issueLinkType":
+			_, _ = w.Write([]byte(`{"issueLinkTypes":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	j.permissionGaps = newPermissionGaps()
+	p := projectBuilder(j.client, j.apiClient, j.session, 1, nil, false, true, false, false, j.permissionGaps)
+	ctx := context.Background()
+
+	// The builders of a sync record their gaps concurrently.
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		schemas, _, _, err := j.ListTicketSchemas(ctx, &pagination.Token{})
+		if err != nil {
+			t.Errorf("ListTicketSchemas: %v", err)
+		}
+		if len(schemas) != 0 {
+			t.Errorf("ListTicketSchemas() listed %d schemas, want the project skipped", len(schemas))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeProject.Id, Resource: "10000"}}
+		if err := p.annotateNotificationScheme(ctx, resource); err != nil {
+			t.Errorf("annotateNotificationScheme: %v", err)
+		}
+	}()
+	wg.Wait()
+
+	var got []string
+	for _, gap := range j.permissionGaps.list() {
+		if gap.Site != j.siteName() || gap.Error == "" {
+			t.Errorf("gap %+v, want site %s and the error", gap, j.siteName())
+		}
+		got = append(got, gap.Operation+" "+gap.ResourceID)
+	}
+	want := []string{gapNotificationScheme + " 10000", gapTicketSchema + " SW"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("gaps = %v, want %v", got, want)
+	}
+}
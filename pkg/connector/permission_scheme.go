@@ -0,0 +1,247 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+var resourceTypePermissionScheme = &v2.ResourceType{
+	Id:          "permission-scheme",
+	DisplayName: "Permission Scheme",
+}
+
+type permissionSchemeResourceType struct {
+	resourceType *v2.ResourceType
+	client       *jira.Client
+	apiClient    *client.Client
+	session      *sessionStore
+	// projectKeys limits the projects whose roles hold scheme permissions. Empty means every project.
+	projectKeys []string
+}
+
+func permissionSchemeResource(scheme *client.PermissionScheme) (*v2.Resource, error) {
+	resource, err := rs.NewResource(
+		scheme.Name,
+		resourceTypePermissionScheme,
+		strconv.FormatInt(scheme.ID, 10),
+		rs.WithDescription(scheme.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (p *permissionSchemeResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return p.resourceType
+}
+
+func permissionSchemeBuilder(jiraClient *jira.Client, apiClient *client.Client, session *sessionStore, projectKeys []string) *permissionSchemeResourceType {
+	return &permissionSchemeResourceType{
+		resourceType: resourceTypePermissionScheme,
+		client:       jiraClient,
+		apiClient:    apiClient,
+		session:      session,
+		projectKeys:  projectKeys,
+	}
+}
+
+// The permission scheme endpoint is not paginated, so everything is returned in one page.
+func (p *permissionSchemeResourceType) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	schemes, err := p.apiClient.ListPermissionSchemes(ctx)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list permission schemes")
+	}
+
+	var resources []*v2.Resource
+	for i := range schemes {
+		resource, err := permissionSchemeResource(&schemes[i])
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		resources = append(resources, resource)
+	}
+
+	return resources, "", nil, nil
+}
+
+// Entitlements has one entitlement per permission granted in the scheme, e.g. BROWSE_PROJECTS.
+func (p *permissionSchemeResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	permissionGrants, err := p.session.getPermissionSchemeGrants(ctx, p.apiClient, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get permission scheme grants")
+	}
+
+	permissions := make(map[string]bool)
+	for _, permissionGrant := range permissionGrants {
+		permissions[permissionGrant.Permission] = true
+	}
+
+	keys := make([]string, 0, len(permissions))
+	for permission := range permissions {
+		keys = append(keys, permission)
+	}
+	sort.Strings(keys)
+
+	var rv []*v2.Entitlement
+	for _, permission := range keys {
+		permissionOptions := []ent.EntitlementOption{
+			ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup, resourceTypeProjectRole),
+			ent.WithDescription(fmt.Sprintf("%s permission in %s permission scheme", permission, resource.DisplayName)),
+			ent.WithDisplayName(fmt.Sprintf("%s permission scheme %s", resource.DisplayName, permission)),
+		}
+		rv = append(rv, ent.NewPermissionEntitlement(resource, permission, permissionOptions...))
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grants covers user, group and project role holders. Holders such as "anyone",
+// "reporter" or "projectLead" are relative to an issue or project and are skipped.
+// User and group holders are granted on the first page. A project role holder stands
+// for the actors of the role in each project using the scheme, so the role of each of
+// those projects is granted, a page of projects at a time.
+func (p *permissionSchemeResourceType) Grants(ctx context.Context, resource *v2.Resource, pt *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	permissionGrants, err := p.session.getPermissionSchemeGrants(ctx, p.apiClient, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get permission scheme grants")
+	}
+
+	var rv []*v2.Grant
+	var roleHolders []client.PermissionGrant
+	for _, permissionGrant := range permissionGrants {
+		holder := permissionGrant.Holder
+		if holder.Type == "projectRole" {
+			roleHolders = append(roleHolders, permissionGrant)
+			continue
+		}
+
+		if pt.Token != "" {
+			continue
+		}
+
+		switch holder.Type {
+		case "user":
+			accountID := holder.Value
+			if accountID == "" {
+				accountID = holder.Parameter
+			}
+
+			user, err := userResource(ctx, userFromAccountID(accountID))
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(resource, permissionGrant.Permission, user.Id))
+		case "group":
+			// Data Center holders only carry the group name, which is also its resource ID there.
+			groupID := holder.Value
+			if groupID == "" {
+				groupID = holder.Parameter
+			}
+
+			group, err := groupResource(ctx, &jira.Group{
+				ID:   groupID,
+				Name: holder.Parameter,
+			}, nil)
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				permissionGrant.Permission,
+				group.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("group:%s:%s", group.Id.Resource, memberEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		}
+	}
+
+	if len(roleHolders) == 0 {
+		return rv, "", nil, nil
+	}
+
+	roleGrants, nextPage, err := p.projectRoleGrants(ctx, resource, roleHolders, pt.Token)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return append(rv, roleGrants...), nextPage, nil, nil
+}
+
+// projectRoleGrants grants the project role holders of the scheme to the roles of the
+// projects of a page that use the scheme. The grants expand to the role actors the
+// project role builder reads from the project role endpoint, through their groups too.
+func (p *permissionSchemeResourceType) projectRoleGrants(
+	ctx context.Context,
+	resource *v2.Resource,
+	roleHolders []client.PermissionGrant,
+	pageToken string,
+) ([]*v2.Grant, string, error) {
+	projects, nextPage, err := findProjectsPage(ctx, p.apiClient, p.projectKeys, pageToken, resourcePageSize, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var rv []*v2.Grant
+	for i := range projects {
+		project := &projects[i]
+
+		schemeID, err := p.session.getProjectPermissionSchemeID(ctx, p.apiClient, project.ID)
+		if err != nil {
+			return nil, "", client.WrapError(err, "failed to get project permission scheme")
+		}
+		if schemeID != resource.Id.Resource {
+			continue
+		}
+
+		roleIDs, err := p.session.getProjectRoleIDs(ctx, p.client, project.ID)
+		if err != nil {
+			return nil, "", client.WrapError(err, "failed to get project roles")
+		}
+
+		for _, permissionGrant := range roleHolders {
+			roleID := permissionGrant.Holder.Parameter
+			if !roleIDs[roleID] {
+				continue
+			}
+
+			principal := &v2.ResourceId{
+				ResourceType: resourceTypeProjectRole.Id,
+				Resource:     projectRoleID(project.ID, roleID),
+			}
+			rv = append(rv, grant.NewGrant(
+				resource,
+				permissionGrant.Permission,
+				principal,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("%s:%s:%s", resourceTypeProjectRole.Id, principal.Resource, assignedEntitlement)},
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		}
+	}
+
+	return rv, nextPage, nil
+}
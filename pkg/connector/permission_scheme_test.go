@@ -0,0 +1,97 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+// permissionSchemeHandler serves scheme 1, used by projects 10000 and 10001, and scheme 2,
+// used by project 10002. Scheme 1 grants BROWSE_PROJECTS to a user, a group and roles
+// 10002 and 10003, which only project 10000 has.
+func permissionSchemeHandler(t *testing.T) http.Handler {
+	schemes := map[string]string{"10000": "1", "10001": "1", "10002": "2"}
+	roleLinks := map[string]string{
+		"10000": `{"Administrators":"https://example.atlassian.net/rest/api/3/project/10000/role/10002","Developers":"https://example.atlassian.net/rest/api/3/project/10000/role/10003"}`,
+		"10001": `{"Administrators":"https://example.atlassian.net/rest/api/3/project/10001/role/10002"}`,
+		"10002": `{"Administrators":"https://example.atlassian.net/rest/api/3/project/10002/role/10002"}`,
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/3/project/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"10000","key":"A"},{"id":"10001","key":"B"},{"id":"10002","key":"C"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/permissionscheme") && strings.HasPrefix(r.URL.Path, "/rest/api/3/project/"):
+			projectID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/rest/api/3/project/"), "/permissionscheme")
+			_, _ = w.Write([]byte(`{"id":` + schemes[projectID] + `,"name":"Scheme"}`))
+		case r.URL.Path == "/rest/api/3/permissionscheme/1/permission":
+			_, _ = w.Write([]byte(`{"permissions":[` +
+				`{"id":1,"permission":"BROWSE_PROJECTS","holder":{"type":"user","parameter":"a-1","value":"a-1"}},` +
+				`{"id":2,"permission":"BROWSE_PROJECTS","holder":{"type":"group","parameter":"devs","value":"g-1"}},` +
+				`{"id":3,"permission":"BROWSE_PROJECTS","holder":{"type":"projectRole","parameter":"10002","value":"10002"}},` +
+				`{"id":4,"permission":"BROWSE_PROJECTS","holder":{"type":"projectRole","parameter":"10003","value":"10003"}},` +
+				`{"id":5,"permission":"BROWSE_PROJECTS","holder":{"type":"anyone"}}]}`))
+		case strings.HasPrefix(r.URL.Path, "/rest/api/2/project/"):
+			projectID := strings.TrimPrefix(r.URL.Path, "/rest/api/2/project/")
+			_, _ = w.Write([]byte(`{"id":"` + projectID + `","roles":` + roleLinks[projectID] + `}`))
+		default:
+			// The global role's default actors must not be read for project role holders.
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestPermissionSchemeGrants(t *testing.T) {
+	j := newTestJira(t, permissionSchemeHandler(t))
+	p := permissionSchemeBuilder(j.client, j.apiClient, j.session, nil)
+
+	resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypePermissionScheme.Id, Resource: "1"}}
+	grants, next, _, err := p.Grants(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Grants: %v", err)
+	}
+	if next != "" {
+		t.Errorf("next page = %q, want none", next)
+	}
+
+	var got []string
+	for _, g := range grants {
+		principal := g.GetPrincipal().GetId()
+		got = append(got, principal.GetResourceType()+":"+principal.GetResource())
+	}
+	want := []string{
+		"user:a-1",
+		"group:g-1",
+		"project-role:10000:10002",
+		"project-role:10000:10003",
+		"project-role:10001:10002",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("principals = %v, want %v", got, want)
+	}
+
+	for _, g := range grants {
+		if g.GetPrincipal().GetId().GetResourceType() != resourceTypeProjectRole.Id {
+			continue
+		}
+
+		expandable := &v2.GrantExpandable{}
+		found := false
+		for _, a := range g.GetAnnotations() {
+			if a.MessageIs(expandable) {
+				found = a.UnmarshalTo(expandable) == nil
+			}
+		}
+		wantEntitlement := "project-role:" + g.GetPrincipal().GetId().GetResource() + ":assigned"
+		if !found || !slices.Equal(expandable.GetEntitlementIds(), []string{wantEntitlement}) {
+			t.Errorf("grant to %s expands %v, want %s", g.GetPrincipal().GetId().GetResource(), expandable.GetEntitlementIds(), wantEntitlement)
+		}
+	}
+}
@@ -3,7 +3,12 @@ package connector
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
 
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
@@ -11,6 +16,11 @@ import (
 	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
 	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 var resourceTypeProject = &v2.ResourceType{
@@ -21,6 +31,21 @@ var resourceTypeProject = &v2.ResourceType{
 type projectResourceType struct {
 	resourceType *v2.ResourceType
 	client       *jira.Client
+	apiClient    *client.Client
+	session      *sessionStore
+	concurrency  int
+	// projectKeys limits the synced projects. Empty means every project.
+	projectKeys []string
+	// explainParticipantGrants annotates participate grants with the holder that grants them.
+	explainParticipantGrants bool
+	// syncNotificationSchemes annotates projects with their notification scheme.
+	syncNotificationSchemes bool
+	// syncComponents lists the components of each project as its children.
+	syncComponents bool
+	// syncProjectRoles expands the roles of team-managed projects through their project
+	// role. Without project roles they have nothing to expand through, so they are skipped.
+	syncProjectRoles bool
+	permissionGaps   *permissionGaps
 }
 
 func projectResource(ctx context.Context, project *jira.Project) (*v2.Resource, error) {
@@ -36,35 +61,44 @@ func (g *projectResourceType) ResourceType(_ context.Context) *v2.ResourceType {
 	return g.resourceType
 }
 
-func projectBuilder(client *jira.Client) *projectResourceType {
+func projectBuilder(
+	jiraClient *jira.Client,
+	apiClient *client.Client,
+	session *sessionStore,
+	concurrency int,
+	projectKeys []string,
+	explainParticipantGrants bool,
+	syncNotificationSchemes bool,
+	syncComponents bool,
+	syncProjectRoles bool,
+	permissionGaps *permissionGaps,
+) *projectResourceType {
 	return &projectResourceType{
-		resourceType: resourceTypeProject,
-		client:       client,
+		resourceType:             resourceTypeProject,
+		client:                   jiraClient,
+		apiClient:                apiClient,
+		session:                  session,
+		concurrency:              concurrency,
+		projectKeys:              projectKeys,
+		explainParticipantGrants: explainParticipantGrants,
+		syncNotificationSchemes:  syncNotificationSchemes,
+		syncComponents:           syncComponents,
+		syncProjectRoles:         syncProjectRoles,
+		permissionGaps:           permissionGaps,
 	}
 }
 
-func (p *projectResourceType) getRolesForProject(ctx context.Context, project *jira.Project) ([]jira.Role, error) {
-	var rv []jira.Role
-
-	for _, roleLink := range project.Roles {
-		roleId, err := parseRoleIdFromRoleLink(roleLink)
-		if err != nil {
-			return nil, err
-		}
-
-		role, _, err := p.client.Role.Get(ctx, roleId)
-		if err != nil {
-			return nil, err
-		}
-
-		rv = append(rv, *role)
+func (p *projectResourceType) getRolesForProject(ctx context.Context, project *jira.Project) ([]projectRole, error) {
+	globalRoles, err := p.session.getRoles(ctx, p.apiClient)
+	if err != nil {
+		return nil, err
 	}
 
-	return rv, nil
+	return rolesForProject(project, globalRoles)
 }
 
-func (p *projectResourceType) getRolesForProjectId(ctx context.Context, projectID string) ([]jira.Role, error) {
-	project, _, err := p.client.Project.Get(ctx, projectID)
+func (p *projectResourceType) getRolesForProjectId(ctx context.Context, projectID string) ([]projectRole, error) {
+	project, err := p.session.getProject(ctx, p.client, projectID)
 	if err != nil {
 		return nil, err
 	}
@@ -73,6 +107,11 @@ func (p *projectResourceType) getRolesForProjectId(ctx context.Context, projectI
 }
 
 func (u *projectResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	archived := projectArchivedEntitlement(resource)
+	if isArchivedProject(resource) {
+		return []*v2.Entitlement{archived}, "", nil, nil
+	}
+
 	var rv []*v2.Entitlement
 
 	assigmentOptions := []ent.EntitlementOption{
@@ -88,6 +127,7 @@ func (u *projectResourceType) Entitlements(ctx context.Context, resource *v2.Res
 		ent.WithDisplayName(fmt.Sprintf("%s project %s", resource.DisplayName, leadEntitlement)),
 	}
 	rv = append(rv, ent.NewAssignmentEntitlement(resource, leadEntitlement, assigmentOptions...))
+	rv = append(rv, archived)
 
 	roles, err := u.getRolesForProjectId(ctx, resource.Id.Resource)
 	if err != nil {
@@ -98,7 +138,24 @@ func (u *projectResourceType) Entitlements(ctx context.Context, resource *v2.Res
 	return rv, "", nil, nil
 }
 
-func getPermissionEntitlementsFromRoles(resource *v2.Resource, roles []jira.Role) []*v2.Entitlement {
+// projectArchivedEntitlement archives the project. Archiving is not held by anyone, so it
+// is only granted to the connector's user, which holds it while the project is archived.
+func projectArchivedEntitlement(resource *v2.Resource) *v2.Entitlement {
+	return ent.NewAssignmentEntitlement(
+		resource,
+		archivedEntitlement,
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Archives the %s project, making it read-only. Only the connector's user can be granted it", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s project %s", resource.DisplayName, archivedEntitlement)),
+	)
+}
+
+func isArchivedProject(resource *v2.Resource) bool {
+	annos := annotations.Annotations(resource.Annotations)
+	return annos.Contains(&pbjira.JiraProjectArchived{})
+}
+
+func getPermissionEntitlementsFromRoles(resource *v2.Resource, roles []projectRole) []*v2.Entitlement {
 	var rv []*v2.Entitlement
 
 	for _, role := range roles {
@@ -116,9 +173,27 @@ func getPermissionEntitlementsFromRoles(resource *v2.Resource, roles []jira.Role
 }
 
 func (p *projectResourceType) Grants(ctx context.Context, resource *v2.Resource, pt *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
-	project, _, err := p.client.Project.Get(ctx, resource.Id.Resource)
+	if isArchivedProject(resource) {
+		self, err := p.session.getSelf(ctx, p.apiClient)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, "failed to get the connector's user")
+		}
+
+		principal, err := userResource(ctx, self)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		return []*v2.Grant{grant.NewGrant(resource, archivedEntitlement, principal.Id)}, "", nil, nil
+	}
+
+	project, err := p.session.getProject(ctx, p.client, resource.Id.Resource)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get project")
+		return nil, "", nil, client.WrapError(err, "failed to get project")
+	}
+
+	if !projectInScope(p.projectKeys, project.Key) {
+		return nil, "", nil, nil
 	}
 
 	var rv []*v2.Grant
@@ -132,33 +207,44 @@ func (p *projectResourceType) Grants(ctx context.Context, resource *v2.Resource,
 		// handle grants without pagination
 		leadGrants, err := getLeadGrants(ctx, resource, project)
 		if err != nil {
-			return nil, "", nil, wrapError(err, "failed to get lead grants")
+			return nil, "", nil, client.WrapError(err, "failed to get lead grants")
 		}
 		rv = append(rv, leadGrants...)
 
 		projectRoles, err := p.getRolesForProject(ctx, project)
 		if err != nil {
-			return nil, "", nil, wrapError(err, "failed to get roles for project")
+			return nil, "", nil, client.WrapError(err, "failed to get roles for project")
 		}
 
-		roleGrants, err := getRoleGrants(ctx, p, resource, projectRoles)
+		globalRoles, err := p.session.getRoles(ctx, p.apiClient)
 		if err != nil {
-			return nil, "", nil, wrapError(err, "failed to get role grants")
+			return nil, "", nil, client.WrapError(err, "failed to get roles")
+		}
+
+		roleGrants, err := getRoleGrants(project, resource, projectRoles, globalRoles, p.syncProjectRoles, p.explainParticipantGrants)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, "failed to get role grants")
 		}
 		rv = append(rv, roleGrants...)
 	}
 
-	participateGrants, isLastPage, err := getGrantsForAllUsersIfProjectIsPublic(ctx, p, resource, project, int(offset), resourcePageSize)
+	var sources *participantSources
+	if p.explainParticipantGrants && !project.IsPrivate {
+		sources = p.getParticipantSources(ctx, project)
+	}
+
+	// Up to concurrency pages of users are fetched at once, and the token continues after the users they returned.
+	participateGrants, nextOffset, isLastPage, err := getGrantsForAllUsersIfProjectIsPublic(ctx, p, resource, project, int(offset), p.concurrency, sources)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get participate grants")
+		return nil, "", nil, client.WrapError(err, "failed to get participate grants")
 	}
-	rv = append(rv, participateGrants...)
+	rv = append(rv, p.session.dedupeParticipants(project.ID, offset == 0, isLastPage, participateGrants)...)
 
 	if isLastPage {
 		return rv, "", nil, nil
 	}
 
-	nextPage, err := getPageTokenFromOffset(bag, offset+int64(resourcePageSize))
+	nextPage, err := getPageTokenFromOffset(bag, int64(nextOffset))
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -169,17 +255,7 @@ func (p *projectResourceType) Grants(ctx context.Context, resource *v2.Resource,
 func getLeadGrants(ctx context.Context, resource *v2.Resource, project *jira.Project) ([]*v2.Grant, error) {
 	var rv []*v2.Grant
 	if project.Lead.AccountID != "" {
-		lead := project.Lead
-		leadResource, err := userResource(ctx, &jira.User{
-			Name:         lead.Name,
-			Key:          lead.Key,
-			AccountID:    lead.AccountID,
-			EmailAddress: lead.EmailAddress,
-			DisplayName:  lead.DisplayName,
-			Active:       lead.Active,
-			TimeZone:     lead.TimeZone,
-			AccountType:  lead.AccountType,
-		})
+		leadResource, err := userResource(ctx, userFromLead(&project.Lead))
 		if err != nil {
 			return nil, err
 		}
@@ -191,54 +267,252 @@ func getLeadGrants(ctx context.Context, resource *v2.Resource, project *jira.Pro
 	return rv, nil
 }
 
-func getGrantsForAllUsersIfProjectIsPublic(ctx context.Context, p *projectResourceType, resource *v2.Resource, project *jira.Project, offset int, count int) ([]*v2.Grant, bool, error) {
-	var rv []*v2.Grant
+// participantSources holds the BROWSE_PROJECTS holders of a project's permission scheme,
+// which explain why a user participates in a public project.
+type participantSources struct {
+	// direct holds the account IDs granted browse access by name.
+	direct map[string]bool
+	// public is the "anyone" or application role holder that makes the project public.
+	public *pbjira.JiraGrantSource
+}
 
-	lastPage := true
-	if !project.IsPrivate {
-		users, _, err := p.client.User.Find(ctx, "", jira.WithStartAt(offset), jira.WithMaxResults(count))
-		if err != nil {
-			return nil, lastPage, err
+// getParticipantSources reads the browse holders of the project's permission scheme. It
+// returns nil when the scheme cannot be read, leaving the grants unannotated.
+func (p *projectResourceType) getParticipantSources(ctx context.Context, project *jira.Project) *participantSources {
+	permissionGrants, err := p.session.getProjectPermissionGrants(ctx, p.apiClient, project.ID)
+	if err != nil {
+		ctxzap.Extract(ctx).Warn(
+			"baton-jira: cannot explain participate grants",
+			zap.String("project_key", project.Key),
+			zap.Error(err),
+		)
+		return nil
+	}
+
+	sources := &participantSources{
+		direct: make(map[string]bool),
+	}
+	for _, permissionGrant := range permissionGrants {
+		if permissionGrant.Permission != browseProjectsPermission {
+			continue
+		}
+
+		holder := permissionGrant.Holder
+		switch holder.Type {
+		case "user":
+			accountID := holder.Value
+			if accountID == "" {
+				accountID = holder.Parameter
+			}
+			sources.direct[accountID] = true
+		case "anyone":
+			sources.public = &pbjira.JiraGrantSource{Type: grantSourceAnyone}
+		case "applicationRole":
+			if sources.public == nil {
+				sources.public = &pbjira.JiraGrantSource{
+					Type: grantSourceApplicationRole,
+					Id:   holder.Parameter,
+					Name: holder.Parameter,
+				}
+			}
+		}
+	}
+
+	return sources
+}
+
+// grantOptions annotates the participate grant of a user, when its source is known.
+// Access through a group holder would need the group members and is left unannotated.
+func (s *participantSources) grantOptions(accountID string) []grant.GrantOption {
+	if s == nil {
+		return nil
+	}
+
+	if s.direct[accountID] {
+		return []grant.GrantOption{
+			grant.WithAnnotation(&pbjira.JiraGrantSource{Type: grantSourceDirect, Id: accountID}),
 		}
+	}
+
+	if s.public != nil {
+		return []grant.GrantOption{grant.WithAnnotation(s.public)}
+	}
+
+	return nil
+}
+
+// getGrantsForAllUsersIfProjectIsPublic fetches up to pages pages of users, starting at offset, and
+// returns the offset to continue from. Jira may return fewer users than asked, so the first page
+// sets the page size of the others, which are fetched in parallel. Only an empty page is the last.
+// A short page ends the pages kept, since the pages after it may not follow it.
+func getGrantsForAllUsersIfProjectIsPublic(
+	ctx context.Context,
+	p *projectResourceType,
+	resource *v2.Resource,
+	project *jira.Project,
+	offset int,
+	pages int,
+	sources *participantSources,
+) ([]*v2.Grant, int, bool, error) {
+	if project.IsPrivate {
+		return nil, offset, true, nil
+	}
 
+	participantGrants := func(ctx context.Context, users []jira.User) ([]*v2.Grant, error) {
+		rv := make([]*v2.Grant, 0, len(users))
 		for i := range users {
 			userResource, err := userResource(ctx, &users[i])
 			if err != nil {
-				return nil, lastPage, err
+				return nil, err
 			}
 
-			grant := grant.NewGrant(resource, participateEntitlement, userResource.Id)
-			rv = append(rv, grant)
+			rv = append(rv, grant.NewGrant(resource, participateEntitlement, userResource.Id, sources.grantOptions(users[i].AccountID)...))
+		}
+
+		return rv, nil
+	}
+
+	users, err := p.apiClient.FindUsers(ctx, offset, participantPageSize)
+	if err != nil {
+		return nil, offset, true, err
+	}
+	if len(users) == 0 {
+		return nil, offset, true, nil
+	}
+
+	rv, err := participantGrants(ctx, users)
+	if err != nil {
+		return nil, offset, true, err
+	}
+
+	pageSize := len(users)
+	offset += pageSize
+
+	pageUsers := make([][]jira.User, max(pages-1, 0))
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(pages)
+	for i := range pageUsers {
+		i := i
+		group.Go(func() error {
+			users, err := p.apiClient.FindUsers(groupCtx, offset+i*pageSize, pageSize)
+			if err != nil {
+				return err
+			}
+
+			pageUsers[i] = users
+
+			return nil
+		})
+	}
+
+	err = group.Wait()
+	if err != nil {
+		return nil, offset, true, err
+	}
+
+	for _, users := range pageUsers {
+		if len(users) == 0 {
+			return rv, offset, true, nil
+		}
+
+		grants, err := participantGrants(ctx, users)
+		if err != nil {
+			return nil, offset, true, err
+		}
+		rv = append(rv, grants...)
+		offset += len(users)
+
+		if len(users) < pageSize {
+			break
+		}
+	}
+
+	return rv, offset, false, nil
+}
+
+// rolesForProject crosses the project's role links with the global role list.
+//
+// Team-managed projects have their own roles (Administrator, Member, Viewer), with IDs
+// that are unique per project and missing from the global role list; /rest/api/3/role/{id}
+// returns 404 for them. Their links have the same shape as company-managed ones, so the
+// ID still parses, and the name is taken from the project's Roles map instead. Custom
+// roles of team-managed projects have string IDs, and are project scoped too.
+func rolesForProject(project *jira.Project, globalRoles map[int]jira.Role) ([]projectRole, error) {
+	rv := make([]projectRole, 0, len(project.Roles))
+
+	for name, roleLink := range project.Roles {
+		roleKey, err := roleKeyFromRoleLink(roleLink)
+		if err != nil {
+			return nil, err
+		}
+
+		role := projectRole{
+			ID:    roleKey,
+			Name:  name,
+			Scope: roleScopeProject,
+		}
+		if roleID, ok := parseRoleID(roleKey); ok {
+			if globalRole, ok := globalRoles[roleID]; ok {
+				role = projectRoleFromRole(&globalRole)
+				role.Scope = roleScopeGlobal
+			}
 		}
 
-		lastPage = isLastPage(len(users), resourcePageSize)
+		rv = append(rv, role)
 	}
 
-	return rv, lastPage, nil
+	sort.Slice(rv, func(i, j int) bool {
+		return lessRoleID(rv[i].ID, rv[j].ID)
+	})
+
+	return rv, nil
 }
 
-func getRoleGrants(ctx context.Context, p *projectResourceType, resource *v2.Resource, roles []jira.Role) ([]*v2.Grant, error) {
+// getRoleGrants expands global roles through the role resource. Team-managed roles have
+// no role resource, so they are expanded through the matching project role instead, and
+// skipped when project roles are not synced. With explain set, each grant is also
+// annotated with the role as its source.
+func getRoleGrants(project *jira.Project, resource *v2.Resource, roles []projectRole, globalRoles map[int]jira.Role, projectRoles bool, explain bool) ([]*v2.Grant, error) {
 	var rv []*v2.Grant
 
 	for _, role := range roles {
 		role := role
-		roleResource, err := roleResource(&role)
+
+		var principal *v2.Resource
+		var entitlementID string
+		var err error
+		roleID, _ := parseRoleID(role.ID)
+		if globalRole, ok := globalRoles[roleID]; ok && role.Scope == roleScopeGlobal {
+			principal, err = roleResource(&globalRole)
+			entitlementID = fmt.Sprintf("role:%d:%s", roleID, appointedEntitlement)
+		} else if projectRoles {
+			principal, err = projectRoleResource(project, &role)
+			entitlementID = fmt.Sprintf("%s:%s:%s", resourceTypeProjectRole.Id, projectRoleID(project.ID, role.ID), assignedEntitlement)
+		} else {
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
 
-		grant := grant.NewGrant(
-			resource,
-			participateEntitlement,
-			roleResource.Id,
+		grantOptions := []grant.GrantOption{
 			grant.WithAnnotation(
 				&v2.GrantExpandable{
-					EntitlementIds:  []string{fmt.Sprintf("role:%d:%s", role.ID, appointedEntitlement)},
+					EntitlementIds:  []string{entitlementID},
 					Shallow:         true,
 					ResourceTypeIds: []string{resourceTypeUser.Id},
 				},
 			),
-		)
+		}
+		if explain {
+			grantOptions = append(grantOptions, grant.WithAnnotation(&pbjira.JiraGrantSource{
+				Type: grantSourceProjectRole,
+				Id:   role.ID,
+				Name: role.Name,
+			}))
+		}
+
+		grant := grant.NewGrant(resource, participateEntitlement, principal.Id, grantOptions...)
 		rv = append(rv, grant)
 	}
 
@@ -246,17 +520,24 @@ func getRoleGrants(ctx context.Context, p *projectResourceType, resource *v2.Res
 }
 
 func (u *projectResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
-	bag, offset, err := parsePageToken(p.Token, &v2.ResourceId{ResourceType: resourceTypeGroup.Id})
-	if err != nil {
-		return nil, "", nil, err
+	if p.Token == "" {
+		u.session.warmUp(ctx, u.client, u.apiClient, u.projectKeys, u.concurrency)
 	}
 
-	projects, _, err := u.client.Project.Find(ctx, jira.WithStartAt(int(offset)), jira.WithMaxResults(resourcePageSize))
+	projects, nextPage, err := findProjectsPage(ctx, u.apiClient, u.projectKeys, p.Token, resourcePageSize, nil)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get projects")
+		return nil, "", nil, err
 	}
 
 	var resources []*v2.Resource
+	if p.Token == "" {
+		// Archived projects are few, and listed at once, so that their archived grant is synced.
+		resources, err = u.listArchivedProjects(ctx)
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+
 	for _, project := range projects {
 		resource, err := projectResource(ctx, &jira.Project{
 			Name: project.Name,
@@ -267,17 +548,189 @@ func (u *projectResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pag
 			return nil, "", nil, err
 		}
 
+		if u.syncComponents {
+			annos := annotations.Annotations(resource.Annotations)
+			annos.Update(&v2.ChildResourceType{ResourceTypeId: resourceTypeComponent.Id})
+			resource.Annotations = annos
+		}
+
+		if u.syncNotificationSchemes {
+			err = u.annotateNotificationScheme(ctx, resource)
+			if err != nil {
+				return nil, "", nil, err
+			}
+		}
+
 		resources = append(resources, resource)
 	}
 
-	if isLastPage(len(projects), resourcePageSize) {
-		return resources, "", nil, nil
+	return resources, nextPage, nil, nil
+}
+
+// listArchivedProjects returns the resources of the archived projects, which project
+// searches leave out. They have no children, nor notification scheme.
+func (u *projectResourceType) listArchivedProjects(ctx context.Context) ([]*v2.Resource, error) {
+	batches := client.BatchProjectKeys(u.projectKeys, client.MaxProjectKeysPerSearch)
+	if len(batches) == 0 {
+		batches = [][]string{nil}
+	}
+
+	var rv []*v2.Resource
+	for _, keys := range batches {
+		startAt := 0
+		for {
+			if err := client.PageContextErr(ctx, "listing archived projects"); err != nil {
+				return nil, err
+			}
+
+			projects, lastPage, err := u.apiClient.FindProjects(ctx, client.FindProjectsOptions{
+				StartAt:    startAt,
+				MaxResults: resourcePageSize,
+				Keys:       keys,
+				Archived:   true,
+			})
+			if err != nil {
+				return nil, client.WrapError(err, "failed to get archived projects")
+			}
+
+			for _, project := range projects {
+				resource, err := projectResource(ctx, &jira.Project{
+					Name: project.Name,
+					ID:   project.ID,
+				})
+				if err != nil {
+					return nil, err
+				}
+
+				annos := annotations.Annotations(resource.Annotations)
+				annos.Update(&pbjira.JiraProjectArchived{})
+				resource.Annotations = annos
+
+				rv = append(rv, resource)
+			}
+
+			if lastPage || len(projects) == 0 {
+				break
+			}
+			startAt += len(projects)
+		}
+	}
+
+	return rv, nil
+}
+
+// annotateNotificationScheme attaches the project's notification scheme. Projects whose
+// scheme cannot be read are left unannotated rather than failing the listing.
+func (u *projectResourceType) annotateNotificationScheme(ctx context.Context, resource *v2.Resource) error {
+	scheme, err := u.apiClient.GetProjectNotificationScheme(ctx, resource.Id.Resource)
+	if err != nil {
+		classified := client.ClassifyError(err)
+		denied := status.Code(classified) == codes.PermissionDenied
+		if denied || client.IsNotFound(err) {
+			ctxzap.Extract(ctx).Warn(
+				"baton-jira: cannot read project notification scheme",
+				zap.String("project_id", resource.Id.Resource),
+				zap.Error(err),
+			)
+			if denied {
+				u.permissionGaps.record(u.client.BaseURL.Hostname(), gapNotificationScheme, resource.Id.Resource, classified)
+			}
+			return nil
+		}
+
+		return client.WrapError(err, "failed to get project notification scheme")
+	}
+
+	annos := annotations.Annotations(resource.Annotations)
+	annos.Update(&pbjira.JiraProjectNotificationScheme{
+		SchemeId:   strconv.FormatInt(scheme.ID, 10),
+		SchemeName: scheme.Name,
+	})
+	resource.Annotations = annos
+
+	return nil
+}
+
+// setProjectArchived archives or restores a project. Both endpoints require the
+// Administer Jira global permission.
+func (p *projectResourceType) setProjectArchived(ctx context.Context, projectID string, archived bool) error {
+	action := "restore"
+	if archived {
+		action = "archive"
 	}
 
-	nextPage, err := getPageTokenFromOffset(bag, offset+int64(resourcePageSize))
+	resp, err := p.apiClient.SetProjectArchived(ctx, projectID, archived)
 	if err != nil {
-		return nil, "", nil, err
+		if resp != nil && (resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized) {
+			return status.Errorf(codes.PermissionDenied, "baton-jira: the Administer Jira global permission is required to %s project %s", action, projectID)
+		}
+
+		return err
 	}
 
-	return resources, nextPage, nil, nil
+	return nil
+}
+
+// Archiving is a property of the project itself, so it is only granted to the connector's user.
+func (p *projectResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if entitlement.Slug != archivedEntitlement {
+		return nil, fmt.Errorf("baton-jira: only the %s entitlement can be granted on projects", archivedEntitlement)
+	}
+
+	self, err := p.session.getSelf(ctx, p.apiClient)
+	if err != nil {
+		return nil, client.WrapError(err, "failed to get the connector's user")
+	}
+
+	selfResource, err := userResource(ctx, self)
+	if err != nil {
+		return nil, err
+	}
+
+	if principal.Id.ResourceType != resourceTypeUser.Id || principal.Id.Resource != selfResource.Id.Resource {
+		return nil, status.Errorf(codes.InvalidArgument, "baton-jira: the %s entitlement can only be granted to the connector's user %s", archivedEntitlement, self.DisplayName)
+	}
+
+	err = p.setProjectArchived(ctx, entitlement.Resource.Id.Resource, true)
+	if err != nil {
+		l.Error(
+			"failed to archive project",
+			zap.Error(err),
+			zap.String("project", entitlement.Resource.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (p *projectResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	entitlement := grant.Entitlement
+	principal := grant.Principal
+
+	if entitlement.Slug != archivedEntitlement {
+		return nil, fmt.Errorf("baton-jira: only the %s entitlement can be revoked on projects", archivedEntitlement)
+	}
+
+	err := p.setProjectArchived(ctx, entitlement.Resource.Id.Resource, false)
+	if err != nil {
+		l.Error(
+			"failed to restore project",
+			zap.Error(err),
+			zap.String("project", entitlement.Resource.Id.Resource),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	return nil, nil
 }
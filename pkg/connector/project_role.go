@@ -0,0 +1,477 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+var resourceTypeProjectRole = &v2.ResourceType{
+	Id:          "project-role",
+	DisplayName: "Project Role",
+	Traits: []v2.ResourceType_Trait{
+		v2.ResourceType_TRAIT_ROLE,
+	},
+}
+
+type projectRoleResourceType struct {
+	resourceType *v2.ResourceType
+	client       *jira.Client
+	apiClient    *client.Client
+	session      *sessionStore
+	concurrency  int
+	// projectKeys limits the synced projects. Empty means every project.
+	projectKeys []string
+}
+
+// projectRole is a role of a project. Classic and team-managed roles have numeric IDs,
+// custom roles of team-managed projects have string IDs, which jira.Role cannot hold.
+type projectRole struct {
+	ID          string
+	Name        string
+	Description string
+	// Scope is roleScopeGlobal for roles shared by every project, roleScopeProject for
+	// roles of a single team-managed project.
+	Scope string
+}
+
+const (
+	roleScopeGlobal  = "global"
+	roleScopeProject = "project"
+
+	// maxRoleDescriptionLength bounds the role description repeated in entitlement
+	// descriptions.
+	maxRoleDescriptionLength = 200
+)
+
+func projectRoleFromRole(role *jira.Role) projectRole {
+	return projectRole{
+		ID:          strconv.Itoa(role.ID),
+		Name:        role.Name,
+		Description: role.Description,
+	}
+}
+
+// Format is projectID:roleID. Numeric role IDs format the same as before string IDs were
+// supported, so existing grants keep matching.
+func projectRoleID(projectID string, roleID string) string {
+	return fmt.Sprintf("%s:%s", projectID, roleID)
+}
+
+func parseProjectRoleID(id string) (string, string, error) {
+	projectID, roleID, ok := strings.Cut(id, ":")
+	if !ok || projectID == "" || roleID == "" {
+		return "", "", fmt.Errorf("invalid project role id %q, expected 'projectID:roleID'", id)
+	}
+
+	return projectID, roleID, nil
+}
+
+// parseRoleID parses a role ID, as found in role links, scheme holders and project role
+// IDs. Classic and team-managed roles have numeric IDs, the custom roles of team-managed
+// projects string IDs, for which numeric is false.
+func parseRoleID(id string) (int, bool) {
+	numericID, err := strconv.Atoi(id)
+	if err != nil {
+		return 0, false
+	}
+
+	return numericID, true
+}
+
+// lessRoleID orders numeric role IDs by number, before string IDs in lexical order.
+func lessRoleID(a, b string) bool {
+	numericA, okA := parseRoleID(a)
+	numericB, okB := parseRoleID(b)
+	switch {
+	case okA && okB:
+		return numericA < numericB
+	case okA != okB:
+		return okA
+	}
+
+	return a < b
+}
+
+func projectRoleResource(project *jira.Project, role *projectRole) (*v2.Resource, error) {
+	// Numeric IDs stay numbers in the profile, as they were before custom roles.
+	var profileRoleID interface{} = role.ID
+	if id, ok := parseRoleID(role.ID); ok {
+		profileRoleID = id
+	}
+
+	profile := map[string]interface{}{
+		"project_id":   project.ID,
+		"project_key":  project.Key,
+		"project_name": project.Name,
+		"role_id":      profileRoleID,
+		"role_name":    role.Name,
+		"description":  role.Description,
+		"role_scope":   role.Scope,
+	}
+
+	roleTraitOptions := []rs.RoleTraitOption{
+		rs.WithRoleProfile(profile),
+	}
+
+	resource, err := rs.NewRoleResource(
+		fmt.Sprintf("%s - %s", project.Name, role.Name),
+		resourceTypeProjectRole,
+		projectRoleID(project.ID, role.ID),
+		roleTraitOptions,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (p *projectRoleResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return p.resourceType
+}
+
+func projectRoleBuilder(jiraClient *jira.Client, apiClient *client.Client, session *sessionStore, concurrency int, projectKeys []string) *projectRoleResourceType {
+	return &projectRoleResourceType{
+		resourceType: resourceTypeProjectRole,
+		client:       jiraClient,
+		apiClient:    apiClient,
+		session:      session,
+		concurrency:  concurrency,
+		projectKeys:  projectKeys,
+	}
+}
+
+func (p *projectRoleResourceType) List(ctx context.Context, _ *v2.ResourceId, pt *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	ctx, source := withSyncSource(ctx)
+	if pt.Token == "" {
+		p.session.warmUp(ctx, p.client, p.apiClient, p.projectKeys, p.concurrency)
+	}
+
+	globalRoles, err := p.session.getRoles(ctx, p.apiClient)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get roles")
+	}
+
+	// The page token tracks the projects, not the number of roles returned.
+	projects, nextPage, err := findProjectsPage(ctx, p.apiClient, p.projectKeys, pt.Token, resourcePageSize, nil)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// Each worker only writes its own slot, so no locking is needed.
+	fullProjects := make([]*jira.Project, len(projects))
+	projectRoles := make([][]projectRole, len(projects))
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(p.concurrency)
+	for i := range projects {
+		i := i
+		group.Go(func() error {
+			// The find endpoint does not return a project with the roles populated
+			fullProject, err := p.session.getProject(groupCtx, p.client, projects[i].ID)
+			if err != nil {
+				return client.WrapError(err, "failed to get project")
+			}
+
+			roles, err := rolesForProject(fullProject, globalRoles)
+			if err != nil {
+				return client.WrapError(err, "failed to get roles for project")
+			}
+
+			fullProjects[i] = fullProject
+			projectRoles[i] = roles
+
+			return nil
+		})
+	}
+
+	err = group.Wait()
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// Roles are already sorted by ID, so sorting projects by key keeps the output deterministic.
+	order := make([]int, len(projects))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return fullProjects[order[a]].Key < fullProjects[order[b]].Key
+	})
+
+	var rv []*v2.Resource
+	for _, i := range order {
+		for j := range projectRoles[i] {
+			resource, err := projectRoleResource(fullProjects[i], &projectRoles[i][j])
+			if err != nil {
+				return nil, "", nil, client.WrapError(err, "failed to create project role resource")
+			}
+
+			rv = append(rv, resource)
+		}
+	}
+	source.annotate(rv)
+
+	return rv, nextPage, nil, nil
+}
+
+// Entitlements describe the role from the profile List built out of the cached project
+// and roles, so no request is needed.
+func (p *projectRoleResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+
+	description := fmt.Sprintf("Assigned to %s role", resource.DisplayName)
+
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup),
+		ent.WithDisplayName(fmt.Sprintf("%s role %s", resource.DisplayName, assignedEntitlement)),
+	}
+
+	roleTrait, err := rs.GetRoleTrait(resource)
+	if err == nil {
+		profile := roleTrait.GetProfile()
+		projectID, _ := rs.GetProfileStringValue(profile, "project_id")
+		projectKey, _ := rs.GetProfileStringValue(profile, "project_key")
+		projectName, _ := rs.GetProfileStringValue(profile, "project_name")
+		roleName, _ := rs.GetProfileStringValue(profile, "role_name")
+		roleDescription, _ := rs.GetProfileStringValue(profile, "description")
+
+		if roleName != "" && projectName != "" {
+			description = fmt.Sprintf("Assigned to %s role on the %s project", roleName, projectName)
+		}
+		if roleDescription = truncateDescription(roleDescription, maxRoleDescriptionLength); roleDescription != "" {
+			description = fmt.Sprintf("%s: %s", description, roleDescription)
+		}
+
+		if projectKey != "" {
+			assigmentOptions = append(assigmentOptions, ent.WithAnnotation(&pbjira.JiraProjectReference{
+				ProjectId:  projectID,
+				ProjectKey: projectKey,
+			}))
+		}
+	}
+	assigmentOptions = append(assigmentOptions, ent.WithDescription(description))
+	rv = append(rv, ent.NewAssignmentEntitlement(resource, assignedEntitlement, assigmentOptions...))
+
+	return rv, "", nil, nil
+}
+
+// Grants fetches the role actors lazily, only for the project role being synced.
+// The project scoped endpoint serves the actors of company-managed and team-managed
+// roles alike, so both kinds resolve the same way here.
+func (p *projectRoleResourceType) Grants(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	projectID, roleID, err := parseProjectRoleID(resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to parse project role id")
+	}
+
+	if len(p.projectKeys) > 0 {
+		project, err := p.session.getProject(ctx, p.client, projectID)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, "failed to get project")
+		}
+
+		if !projectInScope(p.projectKeys, project.Key) {
+			return nil, "", nil, nil
+		}
+	}
+
+	actors, err := p.apiClient.GetProjectRoleActors(ctx, projectID, roleID)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to get role actors for project")
+	}
+
+	// Instances mid-migration can list a group twice, once by name and once by ID, and
+	// the SDK rejects duplicate grants, so actors are deduplicated by resource ID.
+	seen := make(map[string]bool)
+
+	var rv []*v2.Grant
+	for _, actor := range actors {
+		switch {
+		case actor.ActorUser != nil:
+			user, err := userResource(ctx, userFromActor(actor))
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			if seen[user.Id.String()] {
+				continue
+			}
+			seen[user.Id.String()] = true
+
+			rv = append(rv, grant.NewGrant(resource, assignedEntitlement, user.Id))
+		case actor.ActorGroup != nil:
+			groupID, err := p.actorGroupID(ctx, actor.ActorGroup)
+			if err != nil {
+				return nil, "", nil, client.WrapError(err, "failed to find group of role actor")
+			}
+
+			group, err := groupResource(ctx, &jira.Group{
+				ID:   groupID,
+				Name: actor.ActorGroup.Name,
+			}, nil)
+			if err != nil {
+				return nil, "", nil, err
+			}
+
+			if seen[group.Id.String()] {
+				continue
+			}
+			seen[group.Id.String()] = true
+
+			// Groups are listed before grants are synced. Expanding a group that was not
+			// listed would reference a member entitlement that does not exist.
+			if p.session.groupNotListed(groupID) {
+				ctxzap.Extract(ctx).Debug(
+					"not expanding role actor group that was not synced",
+					zap.String("project_role", resource.Id.Resource),
+					zap.String("group_id", groupID),
+				)
+
+				rv = append(rv, grant.NewGrant(
+					resource,
+					assignedEntitlement,
+					group.Id,
+					grant.WithAnnotation(&pbjira.JiraUnexpandedGroup{GroupId: groupID}),
+				))
+				continue
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				assignedEntitlement,
+				group.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("group:%s:%s", group.Id.Resource, memberEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		}
+	}
+
+	return rv, "", nil, nil
+}
+
+// actorGroupID returns the group ID of a role actor. Actors that only carry a name are
+// looked up by exact name, and fall back to the name when no group matches, which is
+// also the group ID on Data Center.
+func (p *projectRoleResourceType) actorGroupID(ctx context.Context, actor *jira.ActorGroup) (string, error) {
+	if actor.GroupID != "" {
+		return actor.GroupID, nil
+	}
+
+	if p.apiClient.IsServer() {
+		return actor.Name, nil
+	}
+
+	groupID, err := p.session.getGroupIDByName(ctx, p.apiClient, actor.Name)
+	if err != nil {
+		return "", err
+	}
+
+	if groupID == "" {
+		return actor.Name, nil
+	}
+
+	return groupID, nil
+}
+
+// Grant adds a user or a group to the role, through the project scoped endpoint, which
+// works for classic, team-managed and custom roles alike.
+func (p *projectRoleResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	projectID, roleID, err := parseProjectRoleID(entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, client.WrapError(err, "failed to parse project role id")
+	}
+
+	isGroup := principal.Id.ResourceType == resourceTypeGroup.Id
+	if !isGroup && principal.Id.ResourceType != resourceTypeUser.Id {
+		err := fmt.Errorf("baton-jira: only users and groups can be granted to project roles")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	err = p.apiClient.AddProjectRoleActor(ctx, projectID, roleID, principal.Id.Resource, isGroup)
+	if err != nil {
+		l.Error(
+			"failed to add actor to project role",
+			zap.Error(err),
+			zap.String("project_role", entitlement.Resource.Id.Resource),
+			zap.String("principal", principal.Id.Resource),
+		)
+
+		if !isGroup {
+			return nil, explainUserGrantError(ctx, p.apiClient, principal.Id.Resource, err, "failed to add actor to project role")
+		}
+
+		return nil, client.WrapError(err, "failed to add actor to project role")
+	}
+
+	return nil, nil
+}
+
+func (p *projectRoleResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	entitlement := grant.Entitlement
+	principal := grant.Principal
+
+	projectID, roleID, err := parseProjectRoleID(entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, client.WrapError(err, "failed to parse project role id")
+	}
+
+	isGroup := principal.Id.ResourceType == resourceTypeGroup.Id
+	if !isGroup && principal.Id.ResourceType != resourceTypeUser.Id {
+		err := fmt.Errorf("baton-jira: only users and groups can be revoked from project roles")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	err = p.apiClient.RemoveProjectRoleActor(ctx, projectID, roleID, principal.Id.Resource, isGroup)
+	if err != nil {
+		l.Error(
+			"failed to remove actor from project role",
+			zap.Error(err),
+			zap.String("project_role", entitlement.Resource.Id.Resource),
+			zap.String("principal", principal.Id.Resource),
+		)
+
+		return nil, client.WrapError(err, "failed to remove actor from project role")
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,364 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+func TestParseRoleID(t *testing.T) {
+	tests := []struct {
+		id          string
+		wantID      int
+		wantNumeric bool
+	}{
+		{id: "10002", wantID: 10002, wantNumeric: true},
+		{id: "10105", wantID: 10105, wantNumeric: true},
+		{id: "5f3c2a1b-custom", wantNumeric: false},
+		{id: "", wantNumeric: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			id, numeric := parseRoleID(tt.id)
+			if id != tt.wantID || numeric != tt.wantNumeric {
+				t.Errorf("parseRoleID(%q) = %d, %v, want %d, %v", tt.id, id, numeric, tt.wantID, tt.wantNumeric)
+			}
+		})
+	}
+}
+
+func TestParseProjectRoleID(t *testing.T) {
+	tests := []struct {
+		id            string
+		wantProjectID string
+		wantRoleID    string
+		wantErr       bool
+	}{
+		{id: "10000:10002", wantProjectID: "10000", wantRoleID: "10002"},
+		{id: "10000:b7c1-reviewer", wantProjectID: "10000", wantRoleID: "b7c1-reviewer"},
+		{id: "10000", wantErr: true},
+		{id: ":10002", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			projectID, roleID, err := parseProjectRoleID(tt.id)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseProjectRoleID(%q) error = %v, wantErr %v", tt.id, err, tt.wantErr)
+			}
+			if projectID != tt.wantProjectID || roleID != tt.wantRoleID {
+				t.Errorf("parseProjectRoleID(%q) = %q, %q, want %q, %q", tt.id, projectID, roleID, tt.wantProjectID, tt.wantRoleID)
+			}
+			if !tt.wantErr && projectRoleID(projectID, roleID) != tt.id {
+				t.Errorf("projectRoleID(%q, %q) = %q, want %q", projectID, roleID, projectRoleID(projectID, roleID), tt.id)
+			}
+		})
+	}
+}
+
+func TestRolesForProject(t *testing.T) {
+	globalRoles := map[int]jira.Role{
+		10002: {ID: 10002, Name: "Administrators", Description: "Project admins"},
+	}
+	roleLink := func(id string) string {
+		return "https://example.atlassian.net/rest/api/3/project/10000/role/" + id
+	}
+
+	tests := []struct {
+		name      string
+		roles     map[string]string
+		wantRoles []projectRole
+		wantErr   bool
+	}{
+		{
+			name:  "global role",
+			roles: map[string]string{"Administrators": roleLink("10002")},
+			wantRoles: []projectRole{
+				{ID: "10002", Name: "Administrators", Description: "Project admins", Scope: roleScopeGlobal},
+			},
+		},
+		{
+			name:  "team-managed role",
+			roles: map[string]string{"Member": roleLink("10105")},
+			wantRoles: []projectRole{
+				{ID: "10105", Name: "Member", Scope: roleScopeProject},
+			},
+		},
+		{
+			name: "custom role",
+			roles: map[string]string{
+				"Reviewer":       roleLink("b7c1-reviewer"),
+				"Member":         roleLink("10105"),
+				"Administrators": roleLink("10002"),
+			},
+			wantRoles: []projectRole{
+				{ID: "10002", Name: "Administrators", Description: "Project admins", Scope: roleScopeGlobal},
+				{ID: "10105", Name: "Member", Scope: roleScopeProject},
+				{ID: "b7c1-reviewer", Name: "Reviewer", Scope: roleScopeProject},
+			},
+		},
+		{
+			name:    "invalid role link",
+			roles:   map[string]string{"Broken": "https://example.atlassian.net/rest/api/3/project/10000"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			roles, err := rolesForProject(&jira.Project{ID: "10000", Roles: tt.roles}, globalRoles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rolesForProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(roles, tt.wantRoles) && !tt.wantErr {
+				t.Errorf("rolesForProject() = %+v, want %+v", roles, tt.wantRoles)
+			}
+		})
+	}
+}
+
+func TestGetRoleGrants(t *testing.T) {
+	project := &jira.Project{ID: "10000", Key: "PRJ", Name: "Project"}
+	globalRoles := map[int]jira.Role{
+		10002: {ID: 10002, Name: "Administrators"},
+	}
+	resource, err := projectResource(context.Background(), project)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name            string
+		role            projectRole
+		projectRoles    bool
+		wantPrincipal   string
+		wantEntitlement string
+	}{
+		{
+			name:            "global role",
+			role:            projectRole{ID: "10002", Name: "Administrators", Scope: roleScopeGlobal},
+			projectRoles:    true,
+			wantPrincipal:   "role:10002",
+			wantEntitlement: "role:10002:appointed",
+		},
+		{
+			name:            "team-managed role",
+			role:            projectRole{ID: "10105", Name: "Member", Scope: roleScopeProject},
+			projectRoles:    true,
+			wantPrincipal:   "project-role:10000:10105",
+			wantEntitlement: "project-role:10000:10105:assigned",
+		},
+		{
+			name:            "custom role",
+			role:            projectRole{ID: "b7c1-reviewer", Name: "Reviewer", Scope: roleScopeProject},
+			projectRoles:    true,
+			wantPrincipal:   "project-role:10000:b7c1-reviewer",
+			wantEntitlement: "project-role:10000:b7c1-reviewer:assigned",
+		},
+		{
+			name:            "global role without project roles",
+			role:            projectRole{ID: "10002", Name: "Administrators", Scope: roleScopeGlobal},
+			wantPrincipal:   "role:10002",
+			wantEntitlement: "role:10002:appointed",
+		},
+		{
+			name: "team-managed role without project roles",
+			role: projectRole{ID: "10105", Name: "Member", Scope: roleScopeProject},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			grants, err := getRoleGrants(project, resource, []projectRole{tt.role}, globalRoles, tt.projectRoles, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.wantPrincipal == "" {
+				if len(grants) != 0 {
+					t.Errorf("got %d grants, want none", len(grants))
+				}
+				return
+			}
+			if len(grants) != 1 {
+				t.Fatalf("got %d grants, want 1", len(grants))
+			}
+
+			principal := grants[0].GetPrincipal().GetId()
+			if got := principal.GetResourceType() + ":" + principal.GetResource(); got != tt.wantPrincipal {
+				t.Errorf("principal = %s, want %s", got, tt.wantPrincipal)
+			}
+
+			expandable := &v2.GrantExpandable{}
+			annos := annotations.Annotations(grants[0].GetAnnotations())
+			ok, err := annos.Pick(expandable)
+			if err != nil || !ok {
+				t.Fatalf("grant is not expandable: %v", err)
+			}
+			if !reflect.DeepEqual(expandable.GetEntitlementIds(), []string{tt.wantEntitlement}) {
+				t.Errorf("expanded entitlements = %v, want %s", expandable.GetEntitlementIds(), tt.wantEntitlement)
+			}
+		})
+	}
+}
+
+func TestProjectRoleGrantsUnlistedGroup(t *testing.T) {
+	tests := []struct {
+		name           string
+		resumed        bool
+		wantExpandable map[string]bool
+	}{
+		{name: "excluded group", wantExpandable: map[string]bool{"g1": true, "g2": false}},
+		{name: "resumed group listing", resumed: true, wantExpandable: map[string]bool{"g1": true, "g2": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/project/10000/role/10002" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"id":10002,"name":"Developers","actors":[` +
+					`{"id":1,"type":"atlassian-group-role-actor","actorGroup":{"name":"one","groupId":"g1"}},` +
+					`{"id":2,"type":"atlassian-group-role-actor","actorGroup":{"name":"two","groupId":"g2"}}]}`))
+			}))
+			j.session.recordListedGroups([]string{"g1"}, !tt.resumed, true)
+			p := &projectRoleResourceType{resourceType: resourceTypeProjectRole, client: j.client, apiClient: j.apiClient, session: j.session}
+
+			resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeProjectRole.Id, Resource: projectRoleID("10000", "10002")}}
+			grants, _, _, err := p.Grants(context.Background(), resource, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("Grants() error = %v", err)
+			}
+
+			expandable := make(map[string]bool)
+			for _, g := range grants {
+				annos := annotations.Annotations(g.GetAnnotations())
+				expandable[g.GetPrincipal().GetId().GetResource()] = annos.Contains(&v2.GrantExpandable{})
+				if unexpanded := annos.Contains(&pbjira.JiraUnexpandedGroup{}); unexpanded == expandable[g.GetPrincipal().GetId().GetResource()] {
+					t.Errorf("grant to %s: expandable and JiraUnexpandedGroup must be exclusive", g.GetPrincipal().GetId().GetResource())
+				}
+			}
+			if !maps.Equal(expandable, tt.wantExpandable) {
+				t.Errorf("expandable grants = %v, want %v", expandable, tt.wantExpandable)
+			}
+		})
+	}
+}
+
+// TestProjectRoleTeamManaged lists the built-in roles of a team-managed project, whose IDs
+// are missing from the global role list, and resolves the members of each.
+func TestProjectRoleTeamManaged(t *testing.T) {
+	project := readTestdata(t, "team_managed_project.json")
+	actors := map[string]string{
+		"10131": `{"id":1,"type":"atlassian-user-role-actor","actorUser":{"accountId":"a-admin"}}`,
+		"10132": `{"id":2,"type":"atlassian-user-role-actor","actorUser":{"accountId":"a-member"}},` +
+			`{"id":3,"type":"atlassian-group-role-actor","actorGroup":{"name":"team","groupId":"g-team"}}`,
+		"10133": `{"id":4,"type":"atlassian-user-role-actor","actorUser":{"accountId":"a-viewer"}}`,
+	}
+
+	j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/role":
+			_, _ = w.Write([]byte(`[{"id":10002,"name":"Administrators"}]`))
+		case "/rest/api/3/project/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"10003","key":"TM","name":"Example Team"}]}`))
+		case "/rest/api/2/project/10003":
+			_, _ = w.Write(project)
+		default:
+			roleID, ok := strings.CutPrefix(r.URL.Path, "/rest/api/3/project/10003/role/")
+			if !ok || actors[roleID] == "" {
+				t.Errorf("unexpected request %s", r.URL.Path)
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"id":%s,"actors":[%s]}`, roleID, actors[roleID])
+		}
+	}))
+	p := projectRoleBuilder(j.client, j.apiClient, j.session, 1, nil)
+	ctx := context.Background()
+
+	resources, _, _, err := p.List(ctx, nil, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+
+	wantMembers := map[string][]string{
+		projectRoleID("10003", "10131"): {"a-admin"},
+		projectRoleID("10003", "10132"): {"a-member", "g-team"},
+		projectRoleID("10003", "10133"): {"a-viewer"},
+	}
+	if len(resources) != len(wantMembers) {
+		t.Fatalf("List() = %d project roles, want %d", len(resources), len(wantMembers))
+	}
+
+	for _, resource := range resources {
+		want, ok := wantMembers[resource.GetId().GetResource()]
+		if !ok {
+			t.Errorf("unexpected project role %s (%s)", resource.GetId().GetResource(), resource.GetDisplayName())
+			continue
+		}
+		roleTrait, err := rs.GetRoleTrait(resource)
+		if err != nil {
+			t.Fatalf("GetRoleTrait: %v", err)
+		}
+		if scope, _ := rs.GetProfileStringValue(roleTrait.GetProfile(), "role_scope"); scope != roleScopeProject {
+			t.Errorf("scope of %s = %q, want %q", resource.GetDisplayName(), scope, roleScopeProject)
+		}
+
+		grants, _, _, err := p.Grants(ctx, resource, &pagination.Token{})
+		if err != nil {
+			t.Fatalf("Grants(%s): %v", resource.GetId().GetResource(), err)
+		}
+		if got := grantPrincipals(grants); !slices.Equal(got, want) {
+			t.Errorf("members of %s = %v, want %v", resource.GetDisplayName(), got, want)
+		}
+	}
+}
+
+func TestProjectRoleSyncer(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(j *Jira)
+		want  bool
+	}{
+		{name: "default", setup: func(*Jira) {}},
+		{name: "project roles", setup: func(j *Jira) { j.syncProjectRoles = true }, want: true},
+		{name: "issue types", setup: func(j *Jira) { j.syncIssueTypes = true }, want: true},
+		{name: "permission schemes", setup: func(j *Jira) { j.syncPermissionSchemes = true }, want: true},
+		{name: "filters", setup: func(j *Jira) { j.syncFilters = true }, want: true},
+		{name: "unrelated syncer", setup: func(j *Jira) { j.syncComponents = true }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.NotFoundHandler())
+			tt.setup(j)
+
+			found := false
+			for _, syncer := range j.ResourceSyncers(context.Background()) {
+				if syncer.ResourceType(context.Background()).GetId() == resourceTypeProjectRole.Id {
+					found = true
+				}
+			}
+			if found != tt.want {
+				t.Errorf("project role syncer registered = %v, want %v", found, tt.want)
+			}
+		})
+	}
+}
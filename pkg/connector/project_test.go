@@ -0,0 +1,318 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// archiveHandler serves the live project 10000, the archived project 10001, and the
+// connector's user, and counts the archive requests.
+func archiveHandler(archives *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/3/project/search" && r.URL.Query().Get("status") == "archived":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"10001","key":"OLD","name":"Old"}]}`))
+		case r.URL.Path == "/rest/api/3/project/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"10000","key":"LIVE","name":"Live"}]}`))
+		case r.URL.Path == "/rest/api/3/myself":
+			_, _ = w.Write([]byte(`{"accountId":"connector","displayName":"Connector","active":true}`))
+		case r.URL.Path == "/rest/api/3/project/10000/archive" && r.Method == http.MethodPost:
+			*archives++
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func newArchiveTestProjects(t *testing.T, archives *int) *projectResourceType {
+	j := newTestJira(t, archiveHandler(archives))
+	return &projectResourceType{
+		resourceType:   resourceTypeProject,
+		client:         j.client,
+		apiClient:      j.apiClient,
+		session:        j.session,
+		concurrency:    1,
+		permissionGaps: newPermissionGaps(),
+	}
+}
+
+func TestProjectListArchived(t *testing.T) {
+	archives := 0
+	p := newArchiveTestProjects(t, &archives)
+
+	resources, _, _, err := p.List(context.Background(), nil, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	archived := map[string]bool{}
+	for _, resource := range resources {
+		archived[resource.Id.Resource] = isArchivedProject(resource)
+	}
+	want := map[string]bool{"10000": false, "10001": true}
+	if !maps.Equal(archived, want) {
+		t.Errorf("listed projects = %v, want %v", archived, want)
+	}
+}
+
+func TestProjectArchivedGrants(t *testing.T) {
+	archives := 0
+	p := newArchiveTestProjects(t, &archives)
+
+	resource, err := projectResource(context.Background(), &jira.Project{ID: "10001", Key: "OLD", Name: "Old"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	annos := annotations.Annotations(resource.Annotations)
+	annos.Update(&pbjira.JiraProjectArchived{})
+	resource.Annotations = annos
+
+	entitlements, _, _, err := p.Entitlements(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Entitlements() error = %v", err)
+	}
+	if len(entitlements) != 1 || entitlements[0].Slug != archivedEntitlement {
+		t.Errorf("entitlements = %v, want only %s", entitlements, archivedEntitlement)
+	}
+
+	grants, _, _, err := p.Grants(context.Background(), resource, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("Grants() error = %v", err)
+	}
+	if len(grants) != 1 {
+		t.Fatalf("got %d grants, want 1", len(grants))
+	}
+	if got := grants[0].Principal.Id; got.ResourceType != resourceTypeUser.Id || got.Resource != "connector" {
+		t.Errorf("principal = %v, want the connector's user", got)
+	}
+	if got, want := grants[0].Entitlement.Id, entitlements[0].Id; got != want {
+		t.Errorf("entitlement = %s, want %s", got, want)
+	}
+}
+
+func TestProjectArchivedGrant(t *testing.T) {
+	tests := []struct {
+		name         string
+		principalID  string
+		wantCode     codes.Code
+		wantArchives int
+	}{
+		{name: "connector's user", principalID: "connector", wantCode: codes.OK, wantArchives: 1},
+		{name: "other user", principalID: "someone", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archives := 0
+			p := newArchiveTestProjects(t, &archives)
+
+			resource, err := projectResource(context.Background(), &jira.Project{ID: "10000", Key: "LIVE", Name: "Live"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			principal := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeUser.Id, Resource: tt.principalID}}
+
+			_, err = p.Grant(context.Background(), principal, projectArchivedEntitlement(resource))
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("Grant() error = %v, want code %s", err, tt.wantCode)
+			}
+			if archives != tt.wantArchives {
+				t.Errorf("archive requests = %d, want %d", archives, tt.wantArchives)
+			}
+		})
+	}
+}
+
+// userSearchHandler serves total users, at most clamp at once. With overlap, every page
+// after the first starts with the last user of the previous one, as Jira's user search
+// does when users change during the sync.
+func userSearchHandler(total int, clamp int, overlap bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/user/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		if overlap && startAt > 0 {
+			startAt--
+		}
+		end := min(startAt+min(maxResults, clamp), total)
+
+		users := []string{}
+		for i := startAt; i < end; i++ {
+			users = append(users, fmt.Sprintf(`{"accountId":"u%d","active":true}`, i))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte("[" + strings.Join(users, ",") + "]"))
+	})
+}
+
+func TestParticipantGrantsPaging(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		clamp       int
+		concurrency int
+		overlap     bool
+	}{
+		{name: "no users", total: 0, clamp: 1000, concurrency: 1},
+		{name: "single page", total: 10, clamp: 1000, concurrency: 1},
+		{name: "exact pages", total: 2000, clamp: 1000, concurrency: 1},
+		{name: "clamped page size", total: 250, clamp: 100, concurrency: 1},
+		{name: "clamped page size, concurrent", total: 250, clamp: 100, concurrency: 3},
+		{name: "overlapping pages", total: 250, clamp: 100, concurrency: 1, overlap: true},
+		{name: "overlapping pages, concurrent", total: 250, clamp: 100, concurrency: 4, overlap: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, userSearchHandler(tt.total, tt.clamp, tt.overlap))
+			p := &projectResourceType{resourceType: resourceTypeProject, client: j.client, apiClient: j.apiClient, session: j.session, concurrency: tt.concurrency}
+			project := &jira.Project{ID: "10000", Key: "LIVE", Name: "Live"}
+			resource, err := projectResource(context.Background(), project)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			granted := make(map[string]int)
+			offset := 0
+			for calls := 0; ; calls++ {
+				if calls > tt.total+1 {
+					t.Fatalf("paging did not end after %d calls", calls)
+				}
+
+				grants, nextOffset, lastPage, err := getGrantsForAllUsersIfProjectIsPublic(context.Background(), p, resource, project, offset, tt.concurrency, nil)
+				if err != nil {
+					t.Fatalf("getGrantsForAllUsersIfProjectIsPublic() error = %v", err)
+				}
+				for _, g := range j.session.dedupeParticipants(project.ID, offset == 0, lastPage, grants) {
+					granted[g.GetPrincipal().GetId().GetResource()]++
+				}
+
+				if lastPage {
+					break
+				}
+				offset = nextOffset
+			}
+
+			if len(granted) != tt.total {
+				t.Errorf("granted %d users, want %d", len(granted), tt.total)
+			}
+			for accountID, count := range granted {
+				if count != 1 {
+					t.Errorf("user %s granted %d times", accountID, count)
+				}
+			}
+		})
+	}
+}
+
+// participantSourceHandler serves project 10000 with a permission scheme granting
+// BROWSE_PROJECTS to the group devs, to user u1 by name, and to any extra holders.
+// It serves users u0 to u2. A nil holders list fails the scheme request.
+func participantSourceHandler(holders []string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/project/10000/permissionscheme":
+			if holders == nil {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":7,"name":"Scheme"}`))
+		case "/rest/api/3/permissionscheme/7/permission":
+			permissions := append([]string{
+				`{"id":1,"permission":"BROWSE_PROJECTS","holder":{"type":"group","parameter":"devs","value":"g-1"}}`,
+				`{"id":2,"permission":"BROWSE_PROJECTS","holder":{"type":"user","parameter":"u1","value":"u1"}}`,
+				`{"id":3,"permission":"ADMINISTER_PROJECTS","holder":{"type":"user","parameter":"u2","value":"u2"}}`,
+			}, holders...)
+			_, _ = w.Write([]byte(`{"permissions":[` + strings.Join(permissions, ",") + `]}`))
+		case "/rest/api/2/user/search":
+			if r.URL.Query().Get("startAt") != "0" {
+				_, _ = w.Write([]byte(`[]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"accountId":"u0","active":true},{"accountId":"u1","active":true},{"accountId":"u2","active":true}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestParticipantGrantSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		holders []string
+		want    map[string]string
+	}{
+		{
+			name:    "group and direct user",
+			holders: []string{},
+			want:    map[string]string{"u0": "", "u1": "direct", "u2": ""},
+		},
+		{
+			name:    "anyone",
+			holders: []string{`{"id":4,"permission":"BROWSE_PROJECTS","holder":{"type":"anyone"}}`},
+			want:    map[string]string{"u0": "anyone", "u1": "direct", "u2": "anyone"},
+		},
+		{
+			name:    "application role",
+			holders: []string{`{"id":4,"permission":"BROWSE_PROJECTS","holder":{"type":"applicationRole","parameter":"jira-software"}}`},
+			want:    map[string]string{"u0": "application_role", "u1": "direct", "u2": "application_role"},
+		},
+		{
+			name: "unreadable scheme",
+			want: map[string]string{"u0": "", "u1": "", "u2": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			j := newTestJira(t, participantSourceHandler(tt.holders))
+			p := &projectResourceType{resourceType: resourceTypeProject, client: j.client, apiClient: j.apiClient, session: j.session, concurrency: 1}
+			project := &jira.Project{ID: "10000", Key: "LIVE", Name: "Live"}
+			resource, err := projectResource(ctx, project)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sources := p.getParticipantSources(ctx, project)
+			grants, _, _, err := getGrantsForAllUsersIfProjectIsPublic(ctx, p, resource, project, 0, 1, sources)
+			if err != nil {
+				t.Fatalf("getGrantsForAllUsersIfProjectIsPublic() error = %v", err)
+			}
+
+			got := make(map[string]string)
+			for _, g := range grants {
+				source := &pbjira.JiraGrantSource{}
+				annos := annotations.Annotations(g.GetAnnotations())
+				if _, err := annos.Pick(source); err != nil {
+					t.Fatal(err)
+				}
+				got[g.GetPrincipal().GetId().GetResource()] = source.GetType()
+			}
+			if !maps.Equal(got, tt.want) {
+				t.Errorf("grant sources = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
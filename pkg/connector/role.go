@@ -3,8 +3,8 @@ package connector
 import (
 	"context"
 	"fmt"
-	"strconv"
 
+	"github.com/conductorone/baton-jira/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
@@ -24,9 +24,16 @@ var resourceTypeRole = &v2.ResourceType{
 	},
 }
 
+// roleResourceType syncs the site's roles as such, with the actors of the default role
+// configuration, which new projects start with. Their assignments within each project are
+// synced as project roles.
 type roleResourceType struct {
 	resourceType *v2.ResourceType
 	client       *jira.Client
+	apiClient    *client.Client
+
+	// claimStatusFilter drops the grants of users the user builder skips.
+	claimStatusFilter *claimStatusFilter
 }
 
 func roleResource(role *jira.Role) (*v2.Resource, error) {
@@ -52,10 +59,12 @@ func (g *roleResourceType) ResourceType(_ context.Context) *v2.ResourceType {
 	return g.resourceType
 }
 
-func roleBuilder(client *jira.Client) *roleResourceType {
+func roleBuilder(jiraClient *jira.Client, apiClient *client.Client, claimStatusFilter *claimStatusFilter) *roleResourceType {
 	return &roleResourceType{
-		resourceType: resourceTypeRole,
-		client:       client,
+		resourceType:      resourceTypeRole,
+		client:            jiraClient,
+		apiClient:         apiClient,
+		claimStatusFilter: claimStatusFilter,
 	}
 }
 
@@ -79,44 +88,43 @@ func (u *roleResourceType) Entitlements(ctx context.Context, resource *v2.Resour
 	return rv, "", nil, nil
 }
 
+// Grants lists the default actors of the role, the users and groups new projects start
+// with in it.
 func (u *roleResourceType) Grants(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
-	roleId, err := strconv.Atoi(resource.Id.Resource)
+	actors, err := u.apiClient.GetRoleDefaultActors(ctx, resource.Id.Resource)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to convert role ID to integer")
+		return nil, "", nil, client.WrapError(err, "failed to get role actors")
 	}
 
-	role, _, err := u.client.Role.Get(ctx, roleId)
+	var rv []*v2.Grant
+	userGrants, err := getUserGrants(ctx, resource, actors)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get roles")
+		return nil, "", nil, client.WrapError(err, "failed to get user grants")
 	}
-
-	var rv []*v2.Grant
-	userGrants, err := getUserGrants(ctx, resource, role)
+	userGrants, err = u.claimStatusFilter.filterGrants(ctx, userGrants)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get user grants")
+		return nil, "", nil, err
 	}
 	rv = append(rv, userGrants...)
 
-	groupGrants, err := getGroupGrants(ctx, resource, role)
+	groupGrants, err := getGroupGrants(ctx, resource, actors)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get group grants")
+		return nil, "", nil, client.WrapError(err, "failed to get group grants")
 	}
 	rv = append(rv, groupGrants...)
 
 	return rv, "", nil, nil
 }
 
-func getUserGrants(ctx context.Context, resource *v2.Resource, role *jira.Role) ([]*v2.Grant, error) {
+func getUserGrants(ctx context.Context, resource *v2.Resource, actors []*jira.Actor) ([]*v2.Grant, error) {
 	var rv []*v2.Grant
 
-	for _, actor := range role.Actors {
+	for _, actor := range actors {
 		if actor.ActorUser == nil {
 			continue
 		}
 
-		user, err := userResource(ctx, &jira.User{
-			AccountID: actor.ActorUser.AccountID,
-		})
+		user, err := userResource(ctx, userFromActor(actor))
 		if err != nil {
 			return nil, err
 		}
@@ -128,17 +136,25 @@ func getUserGrants(ctx context.Context, resource *v2.Resource, role *jira.Role)
 	return rv, nil
 }
 
-func getGroupGrants(ctx context.Context, resource *v2.Resource, role *jira.Role) ([]*v2.Grant, error) {
+// getGroupGrants grants the role to its group actors. Actors without a group ID, as on
+// Data Center, are named by their group name, which is their group ID there.
+func getGroupGrants(ctx context.Context, resource *v2.Resource, actors []*jira.Actor) ([]*v2.Grant, error) {
 	var rv []*v2.Grant
 
-	for _, actor := range role.Actors {
+	for _, actor := range actors {
 		if actor.ActorGroup == nil {
 			continue
 		}
 
+		groupID := actor.ActorGroup.GroupID
+		if groupID == "" {
+			groupID = actor.ActorGroup.Name
+		}
+
 		group, err := groupResource(ctx, &jira.Group{
+			ID:   groupID,
 			Name: actor.ActorGroup.Name,
-		})
+		}, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -150,71 +166,89 @@ func getGroupGrants(ctx context.Context, resource *v2.Resource, role *jira.Role)
 	return rv, nil
 }
 
-func (u *roleResourceType) mapRoleIDsToProjectNames(ctx context.Context) (map[int]string, error) {
-	nextPage := ""
-	roleIDToProjectNameMap := make(map[int]string)
-	for {
-		bag, offset, err := parsePageToken(nextPage, &v2.ResourceId{ResourceType: resourceTypeProject.Id})
-		if err != nil {
-			return nil, err
-		}
+// Grant adds a user or a group to the default actors of the role. Projects that already
+// exist keep their actors; only projects created afterwards start with the new one.
+func (u *roleResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
 
-		projects, _, err := u.client.Project.Find(ctx, jira.WithStartAt(int(offset)), jira.WithMaxResults(resourcePageSize))
-		if err != nil {
-			return nil, wrapError(err, "failed to get projects")
-		}
+	isGroup := principal.Id.ResourceType == resourceTypeGroup.Id
+	if !isGroup && principal.Id.ResourceType != resourceTypeUser.Id {
+		err := fmt.Errorf("baton-jira: only users and groups can be granted to roles")
 
-		for _, project := range projects {
-			// The find endpoint does not return a project with the roles populated
-			project, _, err := u.client.Project.Get(ctx, project.ID)
-			if err != nil {
-				return nil, wrapError(err, "failed to get project")
-			}
-			for _, roleLink := range project.Roles {
-				roleId, err := parseRoleIdFromRoleLink(roleLink)
-				if err != nil {
-					return nil, wrapError(err, "failed to parse role id from role link")
-				}
-				roleIDToProjectNameMap[roleId] = project.Name
-			}
-		}
+		l.Warn(
+			err.Error(),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
 
-		if isLastPage(len(projects), resourcePageSize) {
-			break
-		}
+		return nil, err
+	}
 
-		nextPage, err = getPageTokenFromOffset(bag, offset+int64(resourcePageSize))
-		if err != nil {
-			return nil, err
-		}
-		if nextPage == "" {
-			break
+	err := u.apiClient.AddRoleDefaultActor(ctx, entitlement.Resource.Id.Resource, principal.Id.Resource, isGroup)
+	if err != nil {
+		l.Error(
+			"failed to add default actor to role",
+			zap.Error(err),
+			zap.String("role", entitlement.Resource.Id.Resource),
+			zap.String("principal", principal.Id.Resource),
+		)
+
+		if !isGroup {
+			return nil, explainUserGrantError(ctx, u.apiClient, principal.Id.Resource, err, "failed to add default actor to role")
 		}
+
+		return nil, client.WrapError(err, "failed to add default actor to role")
 	}
 
-	return roleIDToProjectNameMap, nil
+	return nil, nil
 }
 
-func (u *roleResourceType) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+func (u *roleResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
 	l := ctxzap.Extract(ctx)
-	roleIDToProjectName, err := u.mapRoleIDsToProjectNames(ctx)
+
+	entitlement := grant.Entitlement
+	principal := grant.Principal
+
+	isGroup := principal.Id.ResourceType == resourceTypeGroup.Id
+	if !isGroup && principal.Id.ResourceType != resourceTypeUser.Id {
+		err := fmt.Errorf("baton-jira: only users and groups can be revoked from roles")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	err := u.apiClient.RemoveRoleDefaultActor(ctx, entitlement.Resource.Id.Resource, principal.Id.Resource, isGroup)
 	if err != nil {
-		l.Error(wrapError(err, "failed to map role IDs to project names").Error(), zap.Error(err))
+		l.Error(
+			"failed to remove default actor from role",
+			zap.Error(err),
+			zap.String("role", entitlement.Resource.Id.Resource),
+			zap.String("principal", principal.Id.Resource),
+		)
+
+		return nil, client.WrapError(err, "failed to remove default actor from role")
 	}
-	roles, _, err := u.client.Role.GetList(ctx)
+
+	return nil, nil
+}
+
+func (u *roleResourceType) List(ctx context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	roles, err := u.apiClient.ListRoles(ctx)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get roles")
+		return nil, "", nil, client.WrapError(err, "failed to get roles")
 	}
 
 	var rv []*v2.Resource
-	for _, role := range *roles {
+	for _, role := range roles {
 		role := role
-		if name, ok := roleIDToProjectName[role.ID]; ok {
-			role.Name = fmt.Sprintf("%s - %s", name, role.Name)
-		}
 		resource, err := roleResource(&role)
 		if err != nil {
-			return nil, "", nil, wrapError(err, "failed to create role resource")
+			return nil, "", nil, client.WrapError(err, "failed to create role resource")
 		}
 
 		rv = append(rv, resource)
@@ -0,0 +1,72 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+// TestRoleListSkipsProjects checks that listing global roles never walks projects,
+// whether the session has none, some or all of them cached.
+func TestRoleListSkipsProjects(t *testing.T) {
+	tests := []struct {
+		name           string
+		cachedProjects []string
+	}{
+		{name: "cold start"},
+		{name: "partial miss", cachedProjects: []string{"10000"}},
+		{name: "cache hit", cachedProjects: []string{"10000", "10001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			var paths []string
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.HasPrefix(r.URL.Path, "/rest/api/2/project/"):
+					id := strings.TrimPrefix(r.URL.Path, "/rest/api/2/project/")
+					fmt.Fprintf(w, `{"id":%q,"key":"P%s","name":"Project %s"}`, id, id, id)
+				case r.URL.Path == "/rest/api/3/role":
+					w.Write([]byte(`[{"id":10002,"name":"Administrators"},{"id":10100,"name":"Developers"}]`))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+
+			for _, projectID := range tt.cachedProjects {
+				if _, err := j.session.getProject(ctx, j.client, projectID); err != nil {
+					t.Fatalf("caching project %s: %v", projectID, err)
+				}
+			}
+
+			paths = nil
+			r := roleBuilder(j.client, j.apiClient, nil)
+			resources, next, _, err := r.List(ctx, nil, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if next != "" {
+				t.Errorf("next token = %q, want none", next)
+			}
+
+			var ids []string
+			for _, resource := range resources {
+				ids = append(ids, resource.Id.Resource)
+			}
+			if want := []string{"10002", "10100"}; !reflect.DeepEqual(ids, want) {
+				t.Errorf("role IDs = %v, want %v", ids, want)
+			}
+			if want := []string{"/rest/api/3/role"}; !reflect.DeepEqual(paths, want) {
+				t.Errorf("requested %v, want %v", paths, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,1015 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Entries older than this are refetched, so a long running connector does not
+// serve data from a previous sync forever.
+const sessionTTL = 30 * time.Minute
+
+// A project Jira reported as not found, e.g. deleted mid-sync, is not fetched again for
+// this long.
+const projectNotFoundTTL = 2 * time.Minute
+
+type syncSourceKey struct{}
+
+// syncSource records whether the session store fetched from Jira on behalf of a List
+// call, or served it from its cache alone.
+type syncSource struct {
+	live atomic.Bool
+}
+
+// withSyncSource starts recording the session store reads made with the returned context.
+func withSyncSource(ctx context.Context) (context.Context, *syncSource) {
+	source := &syncSource{}
+	return context.WithValue(ctx, syncSourceKey{}, source), source
+}
+
+// recordLiveFetch marks the List call of ctx, if any, as having fetched from Jira.
+func recordLiveFetch(ctx context.Context) {
+	if source, ok := ctx.Value(syncSourceKey{}).(*syncSource); ok {
+		source.live.Store(true)
+	}
+}
+
+// annotate adds a JiraSyncSource annotation to each resource.
+func (s *syncSource) annotate(resources []*v2.Resource) {
+	source := syncSourceCache
+	if s.live.Load() {
+		source = syncSourceLiveAPI
+	}
+
+	for _, resource := range resources {
+		annos := annotations.Annotations(resource.Annotations)
+		annos.Update(&pbjira.JiraSyncSource{Source: source})
+		resource.Annotations = annos
+	}
+}
+
+// sessionStore caches Jira data that several resource builders need during a sync,
+// so that e.g. the global role list is fetched once instead of once per project.
+type sessionStore struct {
+	// mu guards the fields below, and is never held while calling Jira: concurrent misses
+	// may fetch the same data twice, the last fetch is kept.
+	mu sync.Mutex
+
+	// warmUpBudget caps how long warmUp may take. Zero disables the warm-up.
+	warmUpBudget time.Duration
+	// warmedUpAt is when warmUp last started. It runs again once what it fetched expired,
+	// so every sync of a long running connector starts warm.
+	warmedUpAt time.Time
+
+	// metrics counts the hits and misses of the project and role caches.
+	metrics *client.Metrics
+
+	roles          map[int]jira.Role
+	rolesFetchedAt time.Time
+
+	projects map[string]projectEntry
+
+	issueLinkTypes          []jira.IssueLinkType
+	issueLinkTypesFetchedAt time.Time
+
+	// self is the user the connector authenticates as.
+	self *jira.User
+
+	orgGroups map[string]orgGroupsEntry
+
+	// directoryNames maps the org directory IDs to their names.
+	directoryNames          map[string]string
+	directoryNamesFetchedAt time.Time
+
+	issueTypes          []jira.IssueType
+	issueTypesFetchedAt time.Time
+
+	// projectPermissionSchemes maps project IDs to their permission scheme ID.
+	projectPermissionSchemes map[string]string
+	permissionSchemeGrants   map[string][]client.PermissionGrant
+
+	// usersLastActive is keyed by account ID. Users the org directory does not know are nil.
+	usersLastActive map[string]*atlassianclient.UserLastActive
+
+	users map[string]userEntry
+
+	// groupMemberCounts counts the member grants of each group across pages.
+	groupMemberCounts map[string]int
+
+	// projectRoleIDs holds the IDs of the roles of each project, from its role links.
+	projectRoleIDs map[string]projectRoleIDsEntry
+
+	// projectParticipants holds the account IDs granted participate on each project
+	// during its current grants pagination.
+	projectParticipants map[string]map[string]bool
+
+	// groupIDsByName resolves group actors that only carry a name. Groups that were
+	// not found map to an empty ID.
+	groupIDsByName map[string]string
+
+	// listedGroups holds the IDs of the groups the group builder listed. It is only
+	// complete once groupsListed is set, which needs the listing to have started with
+	// this process, as groupsListingSeen records: a resumed sync lists the later pages only.
+	listedGroups      map[string]bool
+	groupsListingSeen bool
+	groupsListed      bool
+
+	applicationRoles          []client.ApplicationRole
+	applicationRolesFetchedAt time.Time
+
+	// ticketSchemas is keyed by schema ID, ticketStatuses by project ID. Their TTL is
+	// ticketSchemaTTL, since ticket schemas change more often than the sync data above.
+	ticketSchemas   map[string]ticketSchemaEntry
+	ticketStatuses  map[string]ticketStatusesEntry
+	ticketSchemaTTL time.Duration
+	// consolidatedSchemas are the consolidated schemas of the last listing, keyed by schema
+	// ID. They are kept for sessionTTL even without the ticket schema cache, as finding one
+	// again means reading the schemas of every project.
+	consolidatedSchemas   map[string]*v2.TicketSchema
+	consolidatedSchemasAt time.Time
+	// roleAssignments are the org role assignments on the org and the site.
+	roleAssignments          []atlassianclient.RoleAssignment
+	roleAssignmentsFetchedAt time.Time
+
+	// orgUsers is the org directory keyed by account ID.
+	orgUsers          map[string]atlassianclient.User
+	orgUsersFetchedAt time.Time
+}
+
+type userEntry struct {
+	user      *jira.User
+	fetchedAt time.Time
+}
+
+type orgGroupsEntry struct {
+	groups    map[string]atlassianclient.Group
+	fetchedAt time.Time
+}
+
+type ticketSchemaEntry struct {
+	schema    *v2.TicketSchema
+	projectID string
+	fetchedAt time.Time
+}
+
+type ticketStatusesEntry struct {
+	statuses  []*v2.TicketStatus
+	fetchedAt time.Time
+}
+
+// projectEntry is a project, or the NotFound error Jira returned for it.
+type projectEntry struct {
+	project   *jira.Project
+	err       error
+	fetchedAt time.Time
+}
+
+type projectRoleIDsEntry struct {
+	roleIDs   map[string]bool
+	fetchedAt time.Time
+}
+
+// newSessionStore returns an empty store. A zero ticketSchemaTTL disables the ticket schema cache.
+func newSessionStore(warmUpBudget time.Duration, ticketSchemaTTL time.Duration, metrics *client.Metrics) *sessionStore {
+	return &sessionStore{
+		warmUpBudget: warmUpBudget,
+		metrics:      metrics,
+
+		projects:  make(map[string]projectEntry),
+		orgGroups: make(map[string]orgGroupsEntry),
+
+		projectPermissionSchemes: make(map[string]string),
+		permissionSchemeGrants:   make(map[string][]client.PermissionGrant),
+
+		usersLastActive: make(map[string]*atlassianclient.UserLastActive),
+		users:           make(map[string]userEntry),
+
+		groupIDsByName:    make(map[string]string),
+		listedGroups:      make(map[string]bool),
+		groupMemberCounts: make(map[string]int),
+		projectRoleIDs:    make(map[string]projectRoleIDsEntry),
+
+		projectParticipants: make(map[string]map[string]bool),
+
+		ticketSchemas:   make(map[string]ticketSchemaEntry),
+		ticketStatuses:  make(map[string]ticketStatusesEntry),
+		ticketSchemaTTL: ticketSchemaTTL,
+	}
+}
+
+// getTicketSchema returns a copy of the cached schema, if it is fresh.
+func (s *sessionStore) getTicketSchema(schemaID string) (*v2.TicketSchema, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.ticketSchemas[schemaID]
+	if !ok || time.Since(entry.fetchedAt) >= s.ticketSchemaTTL {
+		return nil, false
+	}
+
+	return proto.Clone(entry.schema).(*v2.TicketSchema), true
+}
+
+// setTicketSchema caches a copy of a schema of a project. A zero TTL disables the cache.
+func (s *sessionStore) setTicketSchema(schemaID string, projectID string, schema *v2.TicketSchema) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ticketSchemaTTL <= 0 {
+		return
+	}
+
+	s.ticketSchemas[schemaID] = ticketSchemaEntry{
+		schema:    proto.Clone(schema).(*v2.TicketSchema),
+		projectID: projectID,
+		fetchedAt: time.Now(),
+	}
+}
+
+// getTicketStatuses returns the cached statuses of a project, if they are fresh.
+func (s *sessionStore) getTicketStatuses(projectID string) ([]*v2.TicketStatus, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, ok := s.ticketStatuses[projectID]
+	if !ok || time.Since(entry.fetchedAt) >= s.ticketSchemaTTL {
+		return nil, false
+	}
+
+	rv := make([]*v2.TicketStatus, 0, len(entry.statuses))
+	for _, status := range entry.statuses {
+		rv = append(rv, proto.Clone(status).(*v2.TicketStatus))
+	}
+
+	return rv, true
+}
+
+func (s *sessionStore) setTicketStatuses(projectID string, statuses []*v2.TicketStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ticketSchemaTTL <= 0 {
+		return
+	}
+
+	entry := ticketStatusesEntry{
+		statuses:  make([]*v2.TicketStatus, 0, len(statuses)),
+		fetchedAt: time.Now(),
+	}
+	for _, status := range statuses {
+		entry.statuses = append(entry.statuses, proto.Clone(status).(*v2.TicketStatus))
+	}
+
+	s.ticketStatuses[projectID] = entry
+}
+
+// invalidateTicketSchema drops a cached schema, and the statuses of its project.
+func (s *sessionStore) invalidateTicketSchema(schemaID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if entry, ok := s.ticketSchemas[schemaID]; ok {
+		delete(s.ticketStatuses, entry.projectID)
+	}
+	delete(s.ticketSchemas, schemaID)
+	delete(s.consolidatedSchemas, schemaID)
+}
+
+// setConsolidatedSchemas replaces the consolidated schemas with those of a listing.
+func (s *sessionStore) setConsolidatedSchemas(schemas []*v2.TicketSchema) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.consolidatedSchemas = make(map[string]*v2.TicketSchema, len(schemas))
+	for _, schema := range schemas {
+		s.consolidatedSchemas[schema.GetId()] = proto.Clone(schema).(*v2.TicketSchema)
+	}
+	s.consolidatedSchemasAt = time.Now()
+}
+
+// getConsolidatedSchema returns a copy of a consolidated schema of the last listing, if
+// the listing is fresh.
+func (s *sessionStore) getConsolidatedSchema(schemaID string) (*v2.TicketSchema, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if time.Since(s.consolidatedSchemasAt) >= sessionTTL {
+		return nil, false
+	}
+
+	schema, ok := s.consolidatedSchemas[schemaID]
+	if !ok {
+		return nil, false
+	}
+
+	return proto.Clone(schema).(*v2.TicketSchema), true
+}
+
+// getRoles returns the global role list keyed by role ID.
+func (s *sessionStore) getRoles(ctx context.Context, apiClient *client.Client) (map[int]jira.Role, error) {
+	s.mu.Lock()
+	roles, fetchedAt := s.roles, s.rolesFetchedAt
+	s.mu.Unlock()
+
+	if roles != nil && time.Since(fetchedAt) < sessionTTL {
+		s.metrics.RecordCacheHit(client.CacheRoles)
+		return roles, nil
+	}
+	s.metrics.RecordCacheMiss(client.CacheRoles)
+
+	roleList, err := apiClient.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordLiveFetch(ctx)
+
+	rv := make(map[int]jira.Role, len(roleList))
+	for _, role := range roleList {
+		rv[role.ID] = role
+	}
+
+	s.mu.Lock()
+	s.roles = rv
+	s.rolesFetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return rv, nil
+}
+
+// getProject returns the full project representation, including its role links. A
+// project that was not found is remembered for projectNotFoundTTL.
+func (s *sessionStore) getProject(ctx context.Context, jiraClient *jira.Client, projectID string) (*jira.Project, error) {
+	s.mu.Lock()
+	entry, ok := s.projects[projectID]
+	s.mu.Unlock()
+
+	if ok && entry.err != nil && time.Since(entry.fetchedAt) < projectNotFoundTTL {
+		s.metrics.RecordCacheHit(client.CacheProjects)
+		return nil, entry.err
+	}
+	if ok && entry.err == nil && time.Since(entry.fetchedAt) < sessionTTL {
+		s.metrics.RecordCacheHit(client.CacheProjects)
+		return entry.project, nil
+	}
+	s.metrics.RecordCacheMiss(client.CacheProjects)
+
+	project, resp, err := jiraClient.Project.Get(ctx, projectID)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			recordLiveFetch(ctx)
+			s.mu.Lock()
+			s.projects[projectID] = projectEntry{
+				err:       err,
+				fetchedAt: time.Now(),
+			}
+			s.mu.Unlock()
+		}
+		return nil, err
+	}
+	recordLiveFetch(ctx)
+
+	s.mu.Lock()
+	s.projects[projectID] = projectEntry{
+		project:   project,
+		fetchedAt: time.Now(),
+	}
+	s.mu.Unlock()
+
+	return project, nil
+}
+
+// getProjectRoleIDs returns the IDs of the roles of a project. Permission schemes are shared
+// by projects, and can name roles a project does not have.
+func (s *sessionStore) getProjectRoleIDs(ctx context.Context, jiraClient *jira.Client, projectID string) (map[string]bool, error) {
+	s.mu.Lock()
+	entry, ok := s.projectRoleIDs[projectID]
+	s.mu.Unlock()
+
+	if ok && time.Since(entry.fetchedAt) < sessionTTL {
+		return entry.roleIDs, nil
+	}
+
+	project, err := s.getProject(ctx, jiraClient, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	roleIDs := make(map[string]bool, len(project.Roles))
+	for _, roleLink := range project.Roles {
+		roleID, err := roleKeyFromRoleLink(roleLink)
+		if err != nil {
+			return nil, err
+		}
+		roleIDs[roleID] = true
+	}
+
+	s.mu.Lock()
+	s.projectRoleIDs[projectID] = projectRoleIDsEntry{
+		roleIDs:   roleIDs,
+		fetchedAt: time.Now(),
+	}
+	s.mu.Unlock()
+
+	return roleIDs, nil
+}
+
+// getIssueLinkTypes returns the issue link types configured on the site.
+func (s *sessionStore) getIssueLinkTypes(ctx context.Context, apiClient *client.Client) ([]jira.IssueLinkType, error) {
+	s.mu.Lock()
+	linkTypes, fetchedAt := s.issueLinkTypes, s.issueLinkTypesFetchedAt
+	s.mu.Unlock()
+
+	if linkTypes != nil && time.Since(fetchedAt) < sessionTTL {
+		return linkTypes, nil
+	}
+
+	linkTypes, err := apiClient.ListIssueLinkTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordLiveFetch(ctx)
+
+	s.mu.Lock()
+	s.issueLinkTypes = linkTypes
+	s.issueLinkTypesFetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return linkTypes, nil
+}
+
+// getSelf returns the user the connector authenticates as. It does not change during a
+// sync, so it is fetched once.
+func (s *sessionStore) getSelf(ctx context.Context, apiClient *client.Client) (*jira.User, error) {
+	s.mu.Lock()
+	self := s.self
+	s.mu.Unlock()
+
+	if self != nil {
+		return self, nil
+	}
+
+	self, err := apiClient.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordLiveFetch(ctx)
+
+	s.mu.Lock()
+	s.self = self
+	s.mu.Unlock()
+
+	return self, nil
+}
+
+// getOrgGroups returns the org directory groups of a site keyed by group ID. They are
+// listed in full on first use, so paging through Jira groups does not refetch them.
+func (s *sessionStore) getOrgGroups(ctx context.Context, atlassianClient *atlassianclient.AtlassianClient, siteID string) (map[string]atlassianclient.Group, error) {
+	s.mu.Lock()
+	entry, ok := s.orgGroups[siteID]
+	s.mu.Unlock()
+
+	if ok && time.Since(entry.fetchedAt) < sessionTTL {
+		return entry.groups, nil
+	}
+
+	recordLiveFetch(ctx)
+	rv := make(map[string]atlassianclient.Group)
+	cursor := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing org groups"); err != nil {
+			return nil, err
+		}
+
+		groups, next, err := atlassianClient.ListGroups(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, group := range groups {
+			rv[group.ID] = group
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	s.mu.Lock()
+	s.orgGroups[siteID] = orgGroupsEntry{
+		groups:    rv,
+		fetchedAt: time.Now(),
+	}
+	s.mu.Unlock()
+
+	return rv, nil
+}
+
+// getDirectoryNames returns the names of the org directories keyed by directory ID.
+func (s *sessionStore) getDirectoryNames(ctx context.Context, atlassianClient *atlassianclient.AtlassianClient) (map[string]string, error) {
+	s.mu.Lock()
+	directoryNames, fetchedAt := s.directoryNames, s.directoryNamesFetchedAt
+	s.mu.Unlock()
+
+	if directoryNames != nil && time.Since(fetchedAt) < sessionTTL {
+		return directoryNames, nil
+	}
+
+	recordLiveFetch(ctx)
+	rv := make(map[string]string)
+	cursor := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing org directories"); err != nil {
+			return nil, err
+		}
+
+		directories, next, err := atlassianClient.ListDirectories(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, directory := range directories {
+			rv[directory.ID] = directory.Name
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	s.mu.Lock()
+	s.directoryNames = rv
+	s.directoryNamesFetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return rv, nil
+}
+
+// getRoleAssignments returns every org role assignment on the org or the site, listed in full on first use.
+func (s *sessionStore) getRoleAssignments(ctx context.Context, atlassianClient *atlassianclient.AtlassianClient, siteID string) ([]atlassianclient.RoleAssignment, error) {
+	s.mu.Lock()
+	roleAssignments, fetchedAt := s.roleAssignments, s.roleAssignmentsFetchedAt
+	s.mu.Unlock()
+
+	if roleAssignments != nil && time.Since(fetchedAt) < sessionTTL {
+		return roleAssignments, nil
+	}
+
+	recordLiveFetch(ctx)
+	rv := make([]atlassianclient.RoleAssignment, 0)
+	cursor := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing org role assignments"); err != nil {
+			return nil, err
+		}
+
+		assignments, next, err := atlassianClient.ListRoleAssignments(ctx, siteID, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		rv = append(rv, assignments...)
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	s.mu.Lock()
+	s.roleAssignments = rv
+	s.roleAssignmentsFetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return rv, nil
+}
+
+// addGroupMembers adds a page of member grants to a group's count and returns the total
+// so far. The first page restarts the count, so a new sync does not add to the previous one.
+func (s *sessionStore) addGroupMembers(groupID string, firstPage bool, members int) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if firstPage {
+		s.groupMemberCounts[groupID] = 0
+	}
+	s.groupMemberCounts[groupID] += members
+
+	return s.groupMemberCounts[groupID]
+}
+
+// dedupeParticipants drops the participate grants of users already granted on an earlier
+// page of the project. The user search is not stable while users change, so a user can
+// be returned on two pages. The first page restarts the record, and the last one drops it.
+func (s *sessionStore) dedupeParticipants(projectID string, firstPage bool, lastPage bool, grants []*v2.Grant) []*v2.Grant {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	seen := s.projectParticipants[projectID]
+	if firstPage || seen == nil {
+		seen = make(map[string]bool)
+		s.projectParticipants[projectID] = seen
+	}
+	if lastPage {
+		delete(s.projectParticipants, projectID)
+	}
+
+	rv := make([]*v2.Grant, 0, len(grants))
+	for _, g := range grants {
+		accountID := g.GetPrincipal().GetId().GetResource()
+		if seen[accountID] {
+			continue
+		}
+		seen[accountID] = true
+		rv = append(rv, g)
+	}
+
+	return rv
+}
+
+// recordListedGroups adds a page of listed groups. The first page restarts the record,
+// and the last one completes it, if this process saw the first one.
+func (s *sessionStore) recordListedGroups(groupIDs []string, firstPage bool, lastPage bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if firstPage {
+		s.listedGroups = make(map[string]bool)
+		s.groupsListingSeen = true
+		s.groupsListed = false
+	}
+	for _, id := range groupIDs {
+		s.listedGroups[id] = true
+	}
+	if lastPage && s.groupsListingSeen {
+		s.groupsListed = true
+	}
+}
+
+// groupNotListed reports whether the groups were all listed, and groupID was not among them.
+func (s *sessionStore) groupNotListed(groupID string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.groupsListed && !s.listedGroups[groupID]
+}
+
+// getGroupIDByName returns the ID of the group with exactly this name, or "" if there is none.
+func (s *sessionStore) getGroupIDByName(ctx context.Context, apiClient *client.Client, name string) (string, error) {
+	s.mu.Lock()
+	id, ok := s.groupIDsByName[name]
+	s.mu.Unlock()
+
+	if ok {
+		return id, nil
+	}
+
+	groups, err := apiClient.FindGroupsByQuery(ctx, name, nil)
+	if err != nil {
+		return "", err
+	}
+
+	for _, group := range groups {
+		if group.Name == name {
+			id = group.ID
+			break
+		}
+	}
+
+	s.mu.Lock()
+	s.groupIDsByName[name] = id
+	s.mu.Unlock()
+
+	return id, nil
+}
+
+// getOrgUsers returns the org directory users keyed by account ID, listed in full on first use.
+func (s *sessionStore) getOrgUsers(ctx context.Context, atlassianClient *atlassianclient.AtlassianClient) (map[string]atlassianclient.User, error) {
+	s.mu.Lock()
+	orgUsers, fetchedAt := s.orgUsers, s.orgUsersFetchedAt
+	s.mu.Unlock()
+
+	if orgUsers != nil && time.Since(fetchedAt) < sessionTTL {
+		return orgUsers, nil
+	}
+
+	recordLiveFetch(ctx)
+	rv := make(map[string]atlassianclient.User)
+	cursor := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing org users"); err != nil {
+			return nil, err
+		}
+
+		users, next, err := atlassianClient.ListUsers(ctx, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users {
+			rv[user.AccountID] = user
+		}
+
+		if next == "" {
+			break
+		}
+		cursor = next
+	}
+
+	s.mu.Lock()
+	s.orgUsers = rv
+	s.orgUsersFetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return rv, nil
+}
+
+// getIssueTypes returns every issue type on the site.
+func (s *sessionStore) getIssueTypes(ctx context.Context, apiClient *client.Client) ([]jira.IssueType, error) {
+	s.mu.Lock()
+	issueTypes, fetchedAt := s.issueTypes, s.issueTypesFetchedAt
+	s.mu.Unlock()
+
+	if issueTypes != nil && time.Since(fetchedAt) < sessionTTL {
+		return issueTypes, nil
+	}
+
+	issueTypes, err := apiClient.ListIssueTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordLiveFetch(ctx)
+
+	s.mu.Lock()
+	s.issueTypes = issueTypes
+	s.issueTypesFetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return issueTypes, nil
+}
+
+// getApplicationRoles returns every application role of the site.
+func (s *sessionStore) getApplicationRoles(ctx context.Context, apiClient *client.Client) ([]client.ApplicationRole, error) {
+	s.mu.Lock()
+	roles, fetchedAt := s.applicationRoles, s.applicationRolesFetchedAt
+	s.mu.Unlock()
+
+	if roles != nil && time.Since(fetchedAt) < sessionTTL {
+		return roles, nil
+	}
+
+	roles, err := apiClient.ListApplicationRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	recordLiveFetch(ctx)
+
+	s.mu.Lock()
+	s.applicationRoles = roles
+	s.applicationRolesFetchedAt = time.Now()
+	s.mu.Unlock()
+
+	return roles, nil
+}
+
+// isDefaultGroup reports whether a group is the default group of an application role.
+func (s *sessionStore) isDefaultGroup(ctx context.Context, apiClient *client.Client, groupID string) (bool, error) {
+	roles, err := s.getApplicationRoles(ctx, apiClient)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range roles {
+		if slices.Contains(roles[i].DefaultGroupIDs(), groupID) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// getProjectPermissionGrants returns the grants of the permission scheme of a project.
+// Most projects share a handful of schemes, so grants are cached per scheme.
+func (s *sessionStore) getProjectPermissionGrants(ctx context.Context, apiClient *client.Client, projectID string) ([]client.PermissionGrant, error) {
+	schemeID, err := s.getProjectPermissionSchemeID(ctx, apiClient, projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getPermissionSchemeGrants(ctx, apiClient, schemeID)
+}
+
+// getProjectPermissionSchemeID returns the ID of the permission scheme of a project.
+func (s *sessionStore) getProjectPermissionSchemeID(ctx context.Context, apiClient *client.Client, projectID string) (string, error) {
+	s.mu.Lock()
+	schemeID, ok := s.projectPermissionSchemes[projectID]
+	s.mu.Unlock()
+
+	if ok {
+		return schemeID, nil
+	}
+
+	scheme, err := apiClient.GetProjectPermissionScheme(ctx, projectID)
+	if err != nil {
+		return "", err
+	}
+
+	schemeID = strconv.FormatInt(scheme.ID, 10)
+	s.mu.Lock()
+	s.projectPermissionSchemes[projectID] = schemeID
+	s.mu.Unlock()
+
+	return schemeID, nil
+}
+
+// getPermissionSchemeGrants returns the grants of a permission scheme.
+func (s *sessionStore) getPermissionSchemeGrants(ctx context.Context, apiClient *client.Client, schemeID string) ([]client.PermissionGrant, error) {
+	s.mu.Lock()
+	grants, ok := s.permissionSchemeGrants[schemeID]
+	s.mu.Unlock()
+
+	if ok {
+		return grants, nil
+	}
+
+	grants, err := apiClient.GetPermissionSchemeGrants(ctx, schemeID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.permissionSchemeGrants[schemeID] = grants
+	s.mu.Unlock()
+
+	return grants, nil
+}
+
+// warmUp prefetches the global roles and the first page of projects, so the first pages
+// of a sync do not pay for a cold cache one request at a time. It runs once per sessionTTL,
+// is best effort, and gives up after warmUpBudget; whatever it did not fetch is fetched on
+// demand later.
+func (s *sessionStore) warmUp(ctx context.Context, jiraClient *jira.Client, apiClient *client.Client, projectKeys []string, concurrency int) {
+	if s.warmUpBudget <= 0 {
+		return
+	}
+
+	s.mu.Lock()
+	if !s.warmedUpAt.IsZero() && time.Since(s.warmedUpAt) < sessionTTL {
+		s.mu.Unlock()
+		return
+	}
+	s.warmedUpAt = time.Now()
+	s.mu.Unlock()
+
+	l := ctxzap.Extract(ctx)
+
+	ctx, cancel := context.WithTimeout(ctx, s.warmUpBudget)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := s.getRoles(ctx, apiClient)
+	if err != nil {
+		l.Debug("session warm-up: failed to get roles", zap.Error(err))
+		return
+	}
+
+	projects, _, err := findProjectsPage(ctx, apiClient, projectKeys, "", resourcePageSize, nil)
+	if err != nil {
+		l.Debug("session warm-up: failed to get projects", zap.Error(err))
+		return
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(concurrency)
+	for i := range projects {
+		projectID := projects[i].ID
+		group.Go(func() error {
+			_, err := s.getProject(groupCtx, jiraClient, projectID)
+			return err
+		})
+	}
+
+	err = group.Wait()
+	if err != nil {
+		l.Debug("session warm-up: failed to get projects", zap.Error(err))
+	}
+
+	l.Debug("session warm-up done", zap.Int("projects", len(projects)), zap.Duration("took", time.Since(start)))
+}
+
+// lastActiveConcurrency is how many last active lookups run at once. The org API has
+// no bulk endpoint, so it is one request per user.
+const lastActiveConcurrency = 5
+
+// getUsersLastActive returns the org directory activity of the given accounts, fetching
+// the ones not cached yet. Accounts without activity, e.g. outside the org, are left out.
+func (s *sessionStore) getUsersLastActive(
+	ctx context.Context,
+	atlassianClient *atlassianclient.AtlassianClient,
+	accountIDs []string,
+) (map[string]*atlassianclient.UserLastActive, error) {
+	l := ctxzap.Extract(ctx)
+
+	var missing []string
+	s.mu.Lock()
+	for _, accountID := range accountIDs {
+		if _, ok := s.usersLastActive[accountID]; !ok && accountID != "" {
+			missing = append(missing, accountID)
+		}
+	}
+	s.mu.Unlock()
+
+	if len(missing) > 0 {
+		recordLiveFetch(ctx)
+	}
+
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(lastActiveConcurrency)
+	for _, accountID := range missing {
+		accountID := accountID
+		group.Go(func() error {
+			lastActive, err := atlassianClient.GetUserLastActive(groupCtx, accountID)
+			if err != nil {
+				if status.Code(err) != codes.NotFound {
+					return err
+				}
+
+				l.Debug("user not found in the org directory", zap.String("account_id", accountID))
+				lastActive = nil
+			}
+
+			s.mu.Lock()
+			s.usersLastActive[accountID] = lastActive
+			s.mu.Unlock()
+
+			return nil
+		})
+	}
+
+	err := group.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.cachedUsersLastActive(accountIDs), nil
+}
+
+// cachedUsersLastActive only returns what earlier calls of getUsersLastActive fetched.
+func (s *sessionStore) cachedUsersLastActive(accountIDs []string) map[string]*atlassianclient.UserLastActive {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rv := make(map[string]*atlassianclient.UserLastActive)
+	for _, accountID := range accountIDs {
+		if lastActive := s.usersLastActive[accountID]; lastActive != nil {
+			rv[accountID] = lastActive
+		}
+	}
+
+	return rv
+}
+
+// getUser returns the full user for an account ID, or key on Data Center.
+func (s *sessionStore) getUser(ctx context.Context, apiClient *client.Client, accountID string) (*jira.User, error) {
+	s.mu.Lock()
+	entry, ok := s.users[accountID]
+	s.mu.Unlock()
+
+	if ok && time.Since(entry.fetchedAt) < sessionTTL {
+		return entry.user, nil
+	}
+
+	user, err := apiClient.GetUser(ctx, accountID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.users[accountID] = userEntry{
+		user:      user,
+		fetchedAt: time.Now(),
+	}
+	s.mu.Unlock()
+
+	return user, nil
+}
@@ -0,0 +1,401 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+)
+
+func TestGroupNotListed(t *testing.T) {
+	type page struct {
+		groupIDs  []string
+		firstPage bool
+		lastPage  bool
+	}
+
+	tests := []struct {
+		name        string
+		pages       []page
+		wantSkipped map[string]bool
+	}{
+		{
+			name:        "nothing listed",
+			wantSkipped: map[string]bool{"g1": false, "g3": false},
+		},
+		{
+			name: "full listing",
+			pages: []page{
+				{groupIDs: []string{"g1"}, firstPage: true},
+				{groupIDs: []string{"g2"}, lastPage: true},
+			},
+			wantSkipped: map[string]bool{"g1": false, "g2": false, "g3": true},
+		},
+		{
+			name: "listing in progress",
+			pages: []page{
+				{groupIDs: []string{"g1"}, firstPage: true},
+			},
+			wantSkipped: map[string]bool{"g1": false, "g3": false},
+		},
+		{
+			name: "resumed mid-listing",
+			pages: []page{
+				{groupIDs: []string{"g2"}, lastPage: true},
+			},
+			wantSkipped: map[string]bool{"g1": false, "g2": false, "g3": false},
+		},
+		{
+			name: "single page",
+			pages: []page{
+				{groupIDs: []string{"g1"}, firstPage: true, lastPage: true},
+			},
+			wantSkipped: map[string]bool{"g1": false, "g3": true},
+		},
+		{
+			name: "relisted",
+			pages: []page{
+				{groupIDs: []string{"g1"}, firstPage: true, lastPage: true},
+				{groupIDs: []string{"g3"}, firstPage: true, lastPage: true},
+			},
+			wantSkipped: map[string]bool{"g1": true, "g3": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newSessionStore(0, time.Hour, nil)
+			for _, p := range tt.pages {
+				s.recordListedGroups(p.groupIDs, p.firstPage, p.lastPage)
+			}
+
+			for groupID, want := range tt.wantSkipped {
+				if got := s.groupNotListed(groupID); got != want {
+					t.Errorf("groupNotListed(%q) = %v, want %v", groupID, got, want)
+				}
+			}
+		})
+	}
+}
+
+// TestSessionFetchUnlocked checks that a slow fetch does not hold the session lock, which
+// would stall every other builder reading the session meanwhile.
+func TestSessionFetchUnlocked(t *testing.T) {
+	release := make(chan struct{})
+	j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/3/role":
+			<-release
+			_, _ = w.Write([]byte(`[{"id":10002,"name":"Administrators"}]`))
+		case "/rest/api/3/issuetype":
+			_, _ = w.Write([]byte(`[{"id":"10001","name":"Task"}]`))
+		case "/rest/api/3/applicationrole":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	var releaseOnce sync.Once
+	releaseRoles := func() { releaseOnce.Do(func() { close(release) }) }
+	t.Cleanup(releaseRoles)
+
+	rolesDone := make(chan error, 1)
+	go func() {
+		_, err := j.session.getRoles(context.Background(), j.apiClient)
+		rolesDone <- err
+	}()
+
+	tests := []struct {
+		name  string
+		fetch func() error
+	}{
+		{name: "issue types", fetch: func() error {
+			_, err := j.session.getIssueTypes(context.Background(), j.apiClient)
+			return err
+		}},
+		{name: "application roles", fetch: func() error {
+			_, err := j.session.getApplicationRoles(context.Background(), j.apiClient)
+			return err
+		}},
+		{name: "cached group ID", fetch: func() error {
+			j.session.mu.Lock()
+			j.session.groupIDsByName["one"] = "g1"
+			j.session.mu.Unlock()
+			_, err := j.session.getGroupIDByName(context.Background(), j.apiClient, "one")
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			done := make(chan error, 1)
+			go func() { done <- tt.fetch() }()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Errorf("fetch error = %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("fetch blocked by the pending role fetch")
+			}
+		})
+	}
+
+	releaseRoles()
+	if err := <-rolesDone; err != nil {
+		t.Errorf("getRoles() error = %v", err)
+	}
+}
+
+func TestWarmUpRepeats(t *testing.T) {
+	tests := []struct {
+		name      string
+		budget    time.Duration
+		expire    bool
+		wantRoles int
+	}{
+		{name: "disabled", budget: 0, wantRoles: 0},
+		{name: "same sync", budget: time.Minute, wantRoles: 1},
+		{name: "later sync after the cache expired", budget: time.Minute, expire: true, wantRoles: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			roleRequests := 0
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/rest/api/3/role":
+					mu.Lock()
+					roleRequests++
+					mu.Unlock()
+					_, _ = w.Write([]byte(`[{"id":10002,"name":"Administrators"}]`))
+				case "/rest/api/3/project/search":
+					_, _ = w.Write([]byte(`{"isLast":true,"values":[]}`))
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			j.session.warmUpBudget = tt.budget
+
+			ctx := context.Background()
+			j.session.warmUp(ctx, j.client, j.apiClient, nil, 1)
+
+			if tt.expire {
+				j.session.mu.Lock()
+				j.session.warmedUpAt = j.session.warmedUpAt.Add(-sessionTTL)
+				j.session.rolesFetchedAt = j.session.rolesFetchedAt.Add(-sessionTTL)
+				j.session.mu.Unlock()
+			}
+			j.session.warmUp(ctx, j.client, j.apiClient, nil, 1)
+
+			if roleRequests != tt.wantRoles {
+				t.Errorf("role requests = %d, want %d", roleRequests, tt.wantRoles)
+			}
+		})
+	}
+}
+
+// TestWarmUpCacheHits checks the first project role page is served from what the warm-up
+// fetched, and that a slow site does not hold the sync past the warm-up budget.
+func TestWarmUpCacheHits(t *testing.T) {
+	tests := []struct {
+		name         string
+		projectDelay time.Duration
+		budget       time.Duration
+		wantRequests map[string]int
+	}{
+		{
+			name:   "warm cache",
+			budget: time.Minute,
+			wantRequests: map[string]int{
+				"/rest/api/3/role":           1,
+				"/rest/api/3/project/search": 2,
+				"/rest/api/2/project/10000":  1,
+				"/rest/api/2/project/10001":  1,
+			},
+		},
+		{
+			name:         "budget exceeded",
+			projectDelay: time.Second,
+			budget:       50 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := make(map[string]int)
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests[r.URL.Path]++
+				mu.Unlock()
+
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/rest/api/3/role":
+					_, _ = w.Write([]byte(`[{"id":10002,"name":"Administrators"}]`))
+				case "/rest/api/3/project/search":
+					_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"10000","key":"A"},{"id":"10001","key":"B"}]}`))
+				case "/rest/api/2/project/10000", "/rest/api/2/project/10001":
+					select {
+					case <-time.After(tt.projectDelay):
+					case <-r.Context().Done():
+						return
+					}
+					id := strings.TrimPrefix(r.URL.Path, "/rest/api/2/project/")
+					_, _ = fmt.Fprintf(w, `{"id":%q,"key":"K%s","roles":{"Administrators":"https://example.atlassian.net/rest/api/3/project/%s/role/10002"}}`, id, id, id)
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			j.session.warmUpBudget = tt.budget
+
+			ctx := context.Background()
+			start := time.Now()
+			j.session.warmUp(ctx, j.client, j.apiClient, nil, 2)
+			if took := time.Since(start); took > tt.budget+500*time.Millisecond {
+				t.Errorf("warm-up took %v, over its budget of %v", took, tt.budget)
+			}
+
+			if tt.wantRequests == nil {
+				return
+			}
+
+			// List skips the warm-up it already had, and reads the projects and roles it cached.
+			p := projectRoleBuilder(j.client, j.apiClient, j.session, 2, nil)
+			resources, _, _, err := p.List(ctx, nil, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("List: %v", err)
+			}
+			if len(resources) != 2 {
+				t.Errorf("List() = %d project roles, want 2", len(resources))
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !maps.Equal(requests, tt.wantRequests) {
+				t.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestSessionGetUserCached(t *testing.T) {
+	requests := 0
+	j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"accountId":%q,"displayName":"Alice"}`, r.URL.Query().Get("accountId"))
+	}))
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		user, err := j.session.getUser(ctx, j.apiClient, "a-1")
+		if err != nil {
+			t.Fatalf("getUser: %v", err)
+		}
+		if user.AccountID != "a-1" {
+			t.Errorf("getUser() = %+v, want a-1", user)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("requests = %d, want 1", requests)
+	}
+
+	// An expired entry is fetched again.
+	j.session.mu.Lock()
+	entry := j.session.users["a-1"]
+	entry.fetchedAt = entry.fetchedAt.Add(-sessionTTL)
+	j.session.users["a-1"] = entry
+	j.session.mu.Unlock()
+
+	if _, err := j.session.getUser(ctx, j.apiClient, "a-1"); err != nil {
+		t.Fatalf("getUser: %v", err)
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d after the entry expired, want 2", requests)
+	}
+}
+
+// TestSessionProjectNotFound checks a project deleted mid-sync is requested once, however
+// many builders ask for it, until the not found entry expires. Other failures are not cached.
+func TestSessionProjectNotFound(t *testing.T) {
+	tests := []struct {
+		name         string
+		statusCode   int
+		wantErr      bool
+		wantNotFound bool
+		wantRequests int
+	}{
+		{name: "deleted project", statusCode: http.StatusNotFound, wantErr: true, wantNotFound: true, wantRequests: 1},
+		{name: "server error", statusCode: http.StatusInternalServerError, wantErr: true, wantRequests: 3},
+		{name: "existing project", statusCode: http.StatusOK, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/2/project/10000" {
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				requests++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statusCode)
+				if tt.statusCode == http.StatusOK {
+					_, _ = w.Write([]byte(`{"id":"10000","key":"SW","name":"Software","issueTypes":[]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"errorMessages":["No project could be found with key '10000'."]}`))
+			}))
+			ctx := context.Background()
+
+			for i := 0; i < 3; i++ {
+				_, err := j.session.getProject(ctx, j.client, "10000")
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("getProject() error = %v, want error %v", err, tt.wantErr)
+				}
+				if got := client.IsNotFound(err); got != tt.wantNotFound {
+					t.Errorf("IsNotFound(%v) = %v, want %v", err, got, tt.wantNotFound)
+				}
+			}
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %d, want %d", requests, tt.wantRequests)
+			}
+
+			if !tt.wantNotFound {
+				return
+			}
+
+			// An expired not found entry is fetched again.
+			j.session.mu.Lock()
+			entry := j.session.projects["10000"]
+			entry.fetchedAt = entry.fetchedAt.Add(-projectNotFoundTTL)
+			j.session.projects["10000"] = entry
+			j.session.mu.Unlock()
+
+			if _, err := j.session.getProject(ctx, j.client, "10000"); !client.IsNotFound(err) {
+				t.Errorf("getProject() error = %v, want not found", err)
+			}
+			if requests != tt.wantRequests+1 {
+				t.Errorf("requests = %d after the entry expired, want %d", requests, tt.wantRequests+1)
+			}
+		})
+	}
+}
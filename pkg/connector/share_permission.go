@@ -0,0 +1,124 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// sharePrincipalTypes are the principals filter and dashboard viewers and editors can be.
+var sharePrincipalTypes = []*v2.ResourceType{resourceTypeUser, resourceTypeGroup, resourceTypeProject, resourceTypeProjectRole}
+
+// sharePermissionGrants grants entitlement to the principals of share or edit permissions
+// of a filter or dashboard. Groups, projects and project roles are expanded to their users.
+// Shares with every user of the site or with anyone have no principal, so they are
+// returned as JiraBroadShare annotations instead.
+func sharePermissionGrants(
+	ctx context.Context,
+	resource *v2.Resource,
+	entitlement string,
+	permissions []client.SharePermission,
+) ([]*v2.Grant, annotations.Annotations, error) {
+	var rv []*v2.Grant
+	var annos annotations.Annotations
+	for _, permission := range permissions {
+		switch {
+		case permission.Type == "user" && permission.User != nil:
+			user, err := userResource(ctx, userFromAccountID(permission.User.AccountID))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(resource, entitlement, user.Id))
+		case permission.Type == "group" && permission.Group != nil:
+			group, err := groupResource(ctx, &jira.Group{
+				ID:   permission.Group.GroupID,
+				Name: permission.Group.Name,
+			}, nil)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				entitlement,
+				group.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("group:%s:%s", group.Id.Resource, memberEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		// Project shares name a role when they are limited to it.
+		case (permission.Type == "projectRole" || permission.Type == "project") && permission.Project != nil && permission.Role != nil:
+			principal, err := projectRoleResource(
+				&jira.Project{
+					ID:   permission.Project.ID,
+					Key:  permission.Project.Key,
+					Name: permission.Project.Name,
+				},
+				&projectRole{
+					ID:   strconv.Itoa(permission.Role.ID),
+					Name: permission.Role.Name,
+				},
+			)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				entitlement,
+				principal.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("%s:%s:%s", resourceTypeProjectRole.Id, principal.Id.Resource, assignedEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		case permission.Type == "project" && permission.Project != nil:
+			project, err := projectResource(ctx, &jira.Project{
+				ID:   permission.Project.ID,
+				Key:  permission.Project.Key,
+				Name: permission.Project.Name,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			rv = append(rv, grant.NewGrant(
+				resource,
+				entitlement,
+				project.Id,
+				grant.WithAnnotation(
+					&v2.GrantExpandable{
+						EntitlementIds:  []string{fmt.Sprintf("%s:%s:%s", resourceTypeProject.Id, project.Id.Resource, participateEntitlement)},
+						Shallow:         true,
+						ResourceTypeIds: []string{resourceTypeUser.Id},
+					},
+				),
+			))
+		case permission.Type == client.ShareTypeGlobal,
+			permission.Type == client.ShareTypeLoggedIn,
+			permission.Type == client.ShareTypeAuthenticated,
+			permission.Type == client.ShareTypePublic:
+			annos.Append(&pbjira.JiraBroadShare{
+				Entitlement: entitlement,
+				ShareType:   permission.Type,
+			})
+		}
+	}
+
+	return rv, annos, nil
+}
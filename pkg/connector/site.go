@@ -0,0 +1,196 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// suspendMessage is shown to users whose account is suspended through the connector.
+const suspendMessage = "Your Atlassian account was suspended by your organization administrator."
+
+var resourceTypeSite = &v2.ResourceType{
+	Id:          "site",
+	DisplayName: "Site",
+}
+
+// siteResourceType is the Jira site as an org workspace. Its active member entitlement
+// maps to the org account status, so revoking it suspends the account.
+type siteResourceType struct {
+	resourceType    *v2.ResourceType
+	atlassianClient *atlassianclient.AtlassianClient
+	siteID          string
+	siteName        string
+
+	// claimStatusFilter drops the grants of users the user builder skips.
+	claimStatusFilter *claimStatusFilter
+}
+
+func siteResource(siteID string, siteName string) (*v2.Resource, error) {
+	resource, err := rs.NewResource(siteName, resourceTypeSite, siteID)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+func (s *siteResourceType) ResourceType(_ context.Context) *v2.ResourceType {
+	return s.resourceType
+}
+
+func siteBuilder(atlassianClient *atlassianclient.AtlassianClient, siteID string, siteName string, claimStatusFilter *claimStatusFilter) *siteResourceType {
+	return &siteResourceType{
+		resourceType:      resourceTypeSite,
+		atlassianClient:   atlassianClient,
+		siteID:            siteID,
+		siteName:          siteName,
+		claimStatusFilter: claimStatusFilter,
+	}
+}
+
+func (s *siteResourceType) List(_ context.Context, _ *v2.ResourceId, _ *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	resource, err := siteResource(s.siteID, s.siteName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return []*v2.Resource{resource}, "", nil, nil
+}
+
+func (s *siteResourceType) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	assigmentOptions := []ent.EntitlementOption{
+		ent.WithGrantableTo(resourceTypeUser),
+		ent.WithDescription(fmt.Sprintf("Active Atlassian account in the organization of %s", resource.DisplayName)),
+		ent.WithDisplayName(fmt.Sprintf("%s site %s", resource.DisplayName, activeMemberEntitlement)),
+	}
+
+	return []*v2.Entitlement{
+		ent.NewAssignmentEntitlement(resource, activeMemberEntitlement, assigmentOptions...),
+	}, "", nil, nil
+}
+
+// Grants pages through the org directory users with its cursor, which is used as the page token.
+func (s *siteResourceType) Grants(ctx context.Context, resource *v2.Resource, pt *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	users, next, err := s.atlassianClient.ListUsers(ctx, pt.Token)
+	if err != nil {
+		return nil, "", nil, client.WrapError(err, "failed to list org users")
+	}
+
+	var rv []*v2.Grant
+	for _, orgUser := range users {
+		if orgUser.AccountStatus != atlassianclient.AccountStatusActive || !s.claimStatusFilter.allowed(orgUser.ClaimStatus) {
+			continue
+		}
+
+		user, err := userResource(ctx, userFromOrgUser(&orgUser))
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		rv = append(rv, grant.NewGrant(resource, activeMemberEntitlement, user.Id))
+	}
+
+	return rv, next, nil, nil
+}
+
+// checkManaged fails with PermissionDenied for accounts the org does not manage,
+// whose lifecycle the admin API cannot change.
+func (s *siteResourceType) checkManaged(ctx context.Context, accountID string) error {
+	orgUser, err := s.atlassianClient.GetUser(ctx, accountID)
+	if err != nil {
+		return client.WrapError(err, "failed to get org user")
+	}
+
+	if !orgUser.Managed() {
+		return status.Errorf(
+			codes.PermissionDenied,
+			"baton-jira: account %s is not managed by the organization (claim status %q), so it can only be suspended by its own organization",
+			accountID,
+			orgUser.ClaimStatus,
+		)
+	}
+
+	return nil
+}
+
+func (s *siteResourceType) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	if principal.Id.ResourceType != resourceTypeUser.Id {
+		err := fmt.Errorf("baton-jira: only users can be granted site membership")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	err := s.checkManaged(ctx, principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.atlassianClient.EnableUser(ctx, principal.Id.Resource)
+	if err != nil {
+		l.Error(
+			"failed to enable account",
+			zap.Error(err),
+			zap.String("user", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+func (s *siteResourceType) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	principal := grant.Principal
+	if principal.Id.ResourceType != resourceTypeUser.Id {
+		err := fmt.Errorf("baton-jira: only users can have site membership revoked")
+
+		l.Warn(
+			err.Error(),
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	err := s.checkManaged(ctx, principal.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.atlassianClient.DisableUser(ctx, principal.Id.Resource, suspendMessage)
+	if err != nil {
+		l.Error(
+			"failed to suspend account",
+			zap.Error(err),
+			zap.String("user", principal.Id.Resource),
+		)
+
+		return nil, err
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,76 @@
+package connector
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+func TestCascadingSelectSerialization(t *testing.T) {
+	schema := cascadingSelectCustomField(
+		&jira.MetaDataFields{Key: "customfield_1", Name: "Application"},
+		[]client.CascadingOption{
+			{ID: "1", Value: "Payroll", Children: []client.CascadingOption{{ID: "2", Value: "Viewer"}}},
+			{ID: "3", Value: "Wiki"},
+		},
+	)
+
+	var allowed []string
+	for _, value := range schema.GetPickObjectValue().GetAllowedValues() {
+		allowed = append(allowed, value.GetId()+"="+value.GetDisplayName())
+	}
+	if got, want := allowed, []string{"1=Payroll", "1:2=Payroll - Viewer", "3=Wiki"}; !slices.Equal(got, want) {
+		t.Errorf("allowed values = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name  string
+		field *v2.TicketCustomField
+		want  string
+	}{
+		{
+			name:  "parent and child",
+			field: sdkTicket.PickObjectValueField("customfield_1", &v2.TicketCustomFieldObjectValue{Id: "1:2"}),
+			want:  `{"id":"1","child":{"id":"2"}}`,
+		},
+		{
+			name:  "parent only",
+			field: sdkTicket.PickObjectValueField("customfield_1", &v2.TicketCustomFieldObjectValue{Id: "3"}),
+			want:  `{"id":"3"}`,
+		},
+	}
+
+	j := &Jira{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The value keeps the annotations of the schema, like tickets built from it.
+			tt.field.Annotations = schema.GetAnnotations()
+
+			value, err := j.customFieldSchemaToMetaField(tt.field)
+			if err != nil {
+				t.Fatalf("customFieldSchemaToMetaField: %v", err)
+			}
+			got, err := json.Marshal(value)
+			if err != nil {
+				t.Fatalf("marshaling value: %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("value = %s, want %s", got, tt.want)
+			}
+		})
+	}
+
+	// Other pick objects are still sent by ID alone, even when the ID has a colon.
+	value, err := j.customFieldSchemaToMetaField(sdkTicket.PickObjectValueField("customfield_2", &v2.TicketCustomFieldObjectValue{Id: "1:2"}))
+	if err != nil {
+		t.Fatalf("customFieldSchemaToMetaField: %v", err)
+	}
+	if got, _ := json.Marshal(value); string(got) != `{"id":"1:2"}` {
+		t.Errorf("pick object value = %s, want %s", got, `{"id":"1:2"}`)
+	}
+}
@@ -0,0 +1,206 @@
+package connector
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+const (
+	// Consolidated schema IDs are "consolidated:issueTypeID:fingerprint". They never match
+	// schemaIDPattern, so they cannot be mistaken for the schema of a project.
+	consolidatedSchemaIDPrefix = "consolidated:"
+
+	// ticketProjectFieldID is the custom field of a consolidated schema picking the
+	// project a ticket is created in.
+	ticketProjectFieldID = "project_key"
+)
+
+// schemaFingerprint hashes what tickets are validated against and sent to Jira with:
+// the custom fields and the statuses. Projects sharing an issue type scheme and a field
+// configuration have the same fingerprint.
+func schemaFingerprint(schema *v2.TicketSchema) (string, error) {
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(&v2.TicketSchema{
+		CustomFields: schema.GetCustomFields(),
+		Statuses:     schema.GetStatuses(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:8]), nil
+}
+
+// consolidateTicketSchemas merges the schemas of an issue type that are identical in
+// several projects into one schema listing those projects. Projects whose fields differ
+// keep their own schema.
+func consolidateTicketSchemas(projectSchemas []projectTicketSchema) ([]*v2.TicketSchema, error) {
+	type group struct {
+		id      string
+		members []projectTicketSchema
+	}
+
+	var groups []*group
+	groupsByID := make(map[string]*group)
+	for _, projectSchema := range projectSchemas {
+		fingerprint, err := schemaFingerprint(projectSchema.schema)
+		if err != nil {
+			return nil, err
+		}
+
+		id := fmt.Sprintf("%s%s:%s", consolidatedSchemaIDPrefix, projectSchema.issueType.ID, fingerprint)
+		g, ok := groupsByID[id]
+		if !ok {
+			g = &group{id: id}
+			groupsByID[id] = g
+			groups = append(groups, g)
+		}
+		g.members = append(g.members, projectSchema)
+	}
+
+	rv := make([]*v2.TicketSchema, 0, len(groups))
+	for _, g := range groups {
+		if len(g.members) == 1 {
+			rv = append(rv, g.members[0].schema)
+			continue
+		}
+
+		rv = append(rv, consolidatedTicketSchema(g.id, g.members))
+	}
+
+	return rv, nil
+}
+
+func consolidatedTicketSchema(id string, members []projectTicketSchema) *v2.TicketSchema {
+	first := members[0]
+
+	anno := &pbjira.JiraConsolidatedSchema{
+		IssueTypeId: first.issueType.ID,
+	}
+	allowedProjects := make([]*v2.TicketCustomFieldObjectValue, 0, len(members))
+	for _, member := range members {
+		anno.Projects = append(anno.Projects, &pbjira.JCIssueTypeProject{
+			ProjectId:   member.project.ID,
+			ProjectName: member.project.Name,
+			ProjectKey:  member.project.Key,
+		})
+		allowedProjects = append(allowedProjects, &v2.TicketCustomFieldObjectValue{
+			Id:          member.project.Key,
+			DisplayName: fmt.Sprintf("%s (%s)", member.project.Name, member.project.Key),
+		})
+	}
+
+	schema := proto.Clone(first.schema).(*v2.TicketSchema)
+	schema.Id = id
+	schema.DisplayName = fmt.Sprintf("%s (%d projects)", first.issueType.Name, len(members))
+	schema.Annotations = annotations.New(anno)
+	schema.CustomFields[ticketProjectFieldID] = sdkTicket.PickObjectValueFieldSchema(ticketProjectFieldID, "Project", true, allowedProjects)
+
+	return schema
+}
+
+// listConsolidatedTicketSchemas reads the schemas of every project and consolidates them.
+// Consolidated schemas are kept by the session, as GetTicketSchema can only rebuild one
+// by listing every project again.
+func (j *Jira) listConsolidatedTicketSchemas(ctx context.Context, pageSize int) ([]*v2.TicketSchema, error) {
+	var projectSchemas []projectTicketSchema
+	pageToken := ""
+	for {
+		if err := client.PageContextErr(ctx, "listing ticket schemas"); err != nil {
+			return nil, err
+		}
+
+		page, nextPageToken, err := j.listProjectTicketSchemas(ctx, pageToken, pageSize)
+		if err != nil {
+			return nil, err
+		}
+		projectSchemas = append(projectSchemas, page...)
+
+		if nextPageToken == "" {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	rv, err := consolidateTicketSchemas(projectSchemas)
+	if err != nil {
+		return nil, err
+	}
+
+	var consolidated []*v2.TicketSchema
+	for _, schema := range rv {
+		if strings.HasPrefix(schema.GetId(), consolidatedSchemaIDPrefix) {
+			consolidated = append(consolidated, schema)
+		}
+	}
+	j.session.setConsolidatedSchemas(consolidated)
+
+	return rv, nil
+}
+
+// getConsolidatedTicketSchema finds a consolidated schema in the last listing, or lists
+// them again when it is stale or does not hold the schema. Its ID holds the fingerprint of
+// the fields, so once a project's fields change it is gone.
+func (j *Jira) getConsolidatedTicketSchema(ctx context.Context, schemaID string) (*v2.TicketSchema, error) {
+	if schema, ok := j.session.getConsolidatedSchema(schemaID); ok {
+		return schema, nil
+	}
+
+	schemas, err := j.listConsolidatedTicketSchemas(ctx, resourcePageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, schema := range schemas {
+		if schema.GetId() == schemaID {
+			return schema, nil
+		}
+	}
+
+	return nil, status.Errorf(codes.NotFound, "baton-jira: consolidated ticket schema %q not found, the fields of its projects may have changed", schemaID)
+}
+
+// consolidatedProjectKey returns the project a ticket of a consolidated schema picked.
+func consolidatedProjectKey(anno *pbjira.JiraConsolidatedSchema, field *v2.TicketCustomField) (string, error) {
+	project, err := sdkTicket.GetPickObjectValue(field)
+	if err != nil || project.GetId() == "" {
+		return "", status.Errorf(codes.InvalidArgument, "baton-jira: missing required field %s", ticketProjectFieldID)
+	}
+
+	var keys []string
+	for _, p := range anno.GetProjects() {
+		if p.GetProjectKey() == project.GetId() {
+			return p.GetProjectKey(), nil
+		}
+		keys = append(keys, p.GetProjectKey())
+	}
+
+	return "", invalidTicketValue(ticketProjectFieldID, project.GetId(), keys)
+}
+
+func getConsolidatedSchemaAnnotation(annos []*anypb.Any) *pbjira.JiraConsolidatedSchema {
+	rv := &pbjira.JiraConsolidatedSchema{}
+	for _, a := range annos {
+		if a.MessageIs(rv) {
+			if err := a.UnmarshalTo(rv); err != nil {
+				return nil
+			}
+			return rv
+		}
+	}
+
+	return nil
+}
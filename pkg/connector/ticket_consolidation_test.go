@@ -0,0 +1,213 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testProjectSchema(projectKey string, issueTypeID string, fieldIDs ...string) projectTicketSchema {
+	project := &jira.Project{ID: "id-" + projectKey, Key: projectKey, Name: "Project " + projectKey}
+	issueType := &jira.IssueType{ID: issueTypeID, Name: "Task"}
+
+	var customFields []*v2.TicketCustomField
+	for _, fieldID := range fieldIDs {
+		customFields = append(customFields, sdkTicket.StringFieldSchema(fieldID, fieldID, false))
+	}
+	statuses := []*v2.TicketStatus{{Id: "1", DisplayName: "Done"}}
+
+	return projectTicketSchema{
+		project:   project,
+		issueType: issueType,
+		schema:    buildTicketSchema(project, issueType, statuses, true, customFields),
+	}
+}
+
+func TestConsolidateTicketSchemas(t *testing.T) {
+	tests := []struct {
+		name         string
+		schemas      []projectTicketSchema
+		wantIDs      []string
+		wantProjects map[string][]string
+	}{
+		{
+			name:    "single project keeps its own schema",
+			schemas: []projectTicketSchema{testProjectSchema("A", "1", "customfield_1")},
+			wantIDs: []string{"A:1"},
+		},
+		{
+			name: "identical schemas consolidate",
+			schemas: []projectTicketSchema{
+				testProjectSchema("A", "1", "customfield_1"),
+				testProjectSchema("B", "1", "customfield_1"),
+			},
+			wantIDs:      []string{"consolidated:1:"},
+			wantProjects: map[string][]string{"consolidated:1:": {"A", "B"}},
+		},
+		{
+			name: "diverging project keeps its own schema",
+			schemas: []projectTicketSchema{
+				testProjectSchema("A", "1", "customfield_1"),
+				testProjectSchema("B", "1", "customfield_1"),
+				testProjectSchema("C", "1", "customfield_1", "customfield_2"),
+			},
+			wantIDs:      []string{"consolidated:1:", "C:1"},
+			wantProjects: map[string][]string{"consolidated:1:": {"A", "B"}},
+		},
+		{
+			name: "issue types never merge",
+			schemas: []projectTicketSchema{
+				testProjectSchema("A", "1", "customfield_1"),
+				testProjectSchema("A", "2", "customfield_1"),
+			},
+			wantIDs: []string{"A:1", "A:2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schemas, err := consolidateTicketSchemas(tt.schemas)
+			if err != nil {
+				t.Fatalf("consolidateTicketSchemas: %v", err)
+			}
+
+			if len(schemas) != len(tt.wantIDs) {
+				t.Fatalf("got %d schemas, want %d", len(schemas), len(tt.wantIDs))
+			}
+			for i, schema := range schemas {
+				// Consolidated IDs end in a fingerprint, so only their prefix is compared.
+				wantID := tt.wantIDs[i]
+				idMatches := schema.GetId() == wantID
+				if strings.HasSuffix(wantID, ":") {
+					idMatches = strings.HasPrefix(schema.GetId(), wantID) && len(schema.GetId()) > len(wantID)
+				}
+				if !idMatches {
+					t.Errorf("schema %d ID = %q, want %q", i, schema.GetId(), tt.wantIDs[i])
+				}
+
+				wantProjects, consolidated := tt.wantProjects[tt.wantIDs[i]]
+				anno := getConsolidatedSchemaAnnotation(schema.GetAnnotations())
+				if consolidated != (anno != nil) {
+					t.Fatalf("schema %s consolidated = %v, want %v", schema.GetId(), anno != nil, consolidated)
+				}
+				if !consolidated {
+					if _, ok := schema.GetCustomFields()[ticketProjectFieldID]; ok {
+						t.Errorf("schema %s has a %s field", schema.GetId(), ticketProjectFieldID)
+					}
+					continue
+				}
+
+				var keys []string
+				for _, p := range anno.GetProjects() {
+					keys = append(keys, p.GetProjectKey())
+				}
+				if !slices.Equal(keys, wantProjects) {
+					t.Errorf("schema %s projects = %v, want %v", schema.GetId(), keys, wantProjects)
+				}
+
+				var allowed []string
+				for _, v := range schema.GetCustomFields()[ticketProjectFieldID].GetPickObjectValue().GetAllowedValues() {
+					allowed = append(allowed, v.GetId())
+				}
+				if !slices.Equal(allowed, wantProjects) {
+					t.Errorf("schema %s %s values = %v, want %v", schema.GetId(), ticketProjectFieldID, allowed, wantProjects)
+				}
+			}
+		})
+	}
+}
+
+// consolidationHandler serves projects A and B, whose Task issue type has the same
+// fields. It counts the project searches.
+func consolidationHandler(t *testing.T, searches *int, mu *sync.Mutex) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/3/project/search":
+			mu.Lock()
+			*searches++
+			mu.Unlock()
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[` +
+				`{"id":"10000","key":"A","name":"Project A","issueTypes":[{"id":"10001","name":"Task"}]},` +
+				`{"id":"10010","key":"B","name":"Project B","issueTypes":[{"id":"10001","name":"Task"}]}]}`))
+		case r.URL.Path == "/rest/api/2/project/A":
+			_, _ = w.Write([]byte(`{"id":"10000","key":"A","name":"Project A","issueTypes":[{"id":"10001","name":"Task"}]}`))
+		case r.URL.Path == "/rest/api/3/statuses/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"1","name":"Done"}]}`))
+		case strings.Contains(r.URL.Path, "/issue/createmeta/"):
+			_, _ = w.Write([]byte(`{"isLast":true,"fields":[{"required":true,"schema":{"type":"string","custom":"textfield"},"name":"Reason","key":"customfield_1","fieldId":"customfield_1"}]}`))
+		case strings.HasSuffix(r.URL.Path, "/issueLinkType"):
+			_, _ = w.Write([]byte(`{"issueLinkTypes":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestGetTicketSchemaConsolidated(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	searches := 0
+	j := newTestJira(t, consolidationHandler(t, &searches, &mu))
+	j.ticketConsolidateSchemas = true
+	// Without the ticket schema cache, consolidated schemas must still not be rebuilt
+	// for every lookup.
+	j.session.ticketSchemaTTL = 0
+
+	schemas, next, _, err := j.ListTicketSchemas(ctx, &pagination.Token{})
+	if err != nil {
+		t.Fatalf("ListTicketSchemas: %v", err)
+	}
+	if len(schemas) != 1 || next != "" {
+		t.Fatalf("ListTicketSchemas() = %d schemas, next %q; want 1 consolidated schema", len(schemas), next)
+	}
+	consolidatedID := schemas[0].GetId()
+	if !strings.HasPrefix(consolidatedID, consolidatedSchemaIDPrefix+"10001:") {
+		t.Fatalf("schema ID = %q, want a consolidated ID", consolidatedID)
+	}
+
+	for i := 0; i < 3; i++ {
+		schema, _, err := j.GetTicketSchema(ctx, consolidatedID)
+		if err != nil {
+			t.Fatalf("GetTicketSchema(%s): %v", consolidatedID, err)
+		}
+		if schema.GetId() != consolidatedID {
+			t.Errorf("GetTicketSchema() ID = %q, want %q", schema.GetId(), consolidatedID)
+		}
+	}
+	if searches != 1 {
+		t.Errorf("project searches = %d, want 1", searches)
+	}
+
+	// Schemas of consolidated projects stay resolvable by their legacy ID.
+	legacy, _, err := j.GetTicketSchema(ctx, "A:10001")
+	if err != nil {
+		t.Fatalf("GetTicketSchema(A:10001): %v", err)
+	}
+	if legacy.GetId() != "A:10001" {
+		t.Errorf("legacy schema ID = %q, want %q", legacy.GetId(), "A:10001")
+	}
+	if _, ok := legacy.GetCustomFields()["customfield_1"]; !ok {
+		t.Errorf("legacy schema lacks customfield_1")
+	}
+
+	// An ID that is not in the last listing lists the projects again.
+	_, _, err = j.GetTicketSchema(ctx, consolidatedSchemaIDPrefix+"10001:0000")
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("GetTicketSchema(unknown) error = %v, want NotFound", err)
+	}
+	if searches != 2 {
+		t.Errorf("project searches = %d, want 2", searches)
+	}
+}
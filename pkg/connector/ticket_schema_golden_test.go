@@ -0,0 +1,154 @@
+package connector
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// Run "go test ./pkg/connector -run TestTicketSchemaGolden -update" to regenerate the goldens.
+var updateGolden = flag.Bool("update", false, "regenerate the golden files in testdata/golden")
+
+func TestTicketSchemaGolden(t *testing.T) {
+	statuses := []*v2.TicketStatus{
+		{Id: "1", DisplayName: "To Do"},
+		{Id: "3", DisplayName: "In Progress"},
+		{Id: "10001", DisplayName: "Done"},
+	}
+
+	tests := []struct {
+		fixture   string
+		project   jira.Project
+		issueType jira.IssueType
+	}{
+		{
+			fixture:   "software",
+			project:   jira.Project{ID: "10000", Key: "SW", Name: "Example Software"},
+			issueType: jira.IssueType{ID: "10001", Name: "Task"},
+		},
+		{
+			fixture:   "jsm",
+			project:   jira.Project{ID: "10002", Key: "SD", Name: "Example Service Desk"},
+			issueType: jira.IssueType{ID: "10010", Name: "Service Request"},
+		},
+		{
+			fixture:   "team_managed",
+			project:   jira.Project{ID: "10003", Key: "TM", Name: "Example Team"},
+			issueType: jira.IssueType{ID: "10020", Name: "Task"},
+		},
+		{
+			fixture:   "cascading_user_picker",
+			project:   jira.Project{ID: "10004", Key: "AR", Name: "Example Access Requests"},
+			issueType: jira.IssueType{ID: "10030", Name: "Access Request"},
+		},
+	}
+
+	linkTypes := readTestdata(t, "issue_link_types.json")
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			createMeta := readTestdata(t, filepath.Join("createmeta", tt.fixture+".json"))
+
+			// Cascading select options come with the fields, not from a request per field.
+			createMetaRequests := 0
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.Contains(r.URL.Path, "/issue/createmeta/"+tt.project.ID+"/issuetypes/"+tt.issueType.ID):
+					createMetaRequests++
+					_, _ = w.Write(createMeta)
+				case strings.HasSuffix(r.URL.Path, "/issueLinkType"):
+					_, _ = w.Write(linkTypes)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			schema, err := j.schemaForProjectIssueType(context.Background(), &tt.project, &tt.issueType, statuses, true)
+			if err != nil {
+				t.Fatalf("schemaForProjectIssueType: %v", err)
+			}
+			if createMetaRequests != 1 {
+				t.Errorf("createmeta requests = %d, want 1", createMetaRequests)
+			}
+
+			// Fields without special handling must match convertMetadataFieldToCustomField.
+			var res createMetaResponse
+			if err := json.Unmarshal(createMeta, &res); err != nil {
+				t.Fatalf("decoding fixture: %v", err)
+			}
+			for _, field := range res.Fields {
+				if !syncedIssueField(field) || field.Schema.Type == typeCascadingSelect || field.Schema.Custom == sprintCustomType {
+					continue
+				}
+				got, ok := schema.GetCustomFields()[field.Key]
+				if !ok {
+					t.Errorf("field %s missing from the schema", field.Key)
+					continue
+				}
+				if want := convertMetadataFieldToCustomField(field); !proto.Equal(got, want) {
+					t.Errorf("field %s = %v, want %v", field.Key, got, want)
+				}
+			}
+
+			compareGolden(t, filepath.Join("golden", tt.fixture+".json"), schema)
+		})
+	}
+}
+
+type createMetaResponse struct {
+	Fields []*jira.MetaDataFields `json:"fields"`
+}
+
+func readTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+
+	b, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading testdata: %v", err)
+	}
+	return b
+}
+
+// compareGolden compares schema with the golden file, or rewrites the golden with -update.
+// protojson output is not stable, so the golden is compared as a proto, not as bytes.
+func compareGolden(t *testing.T, name string, schema *v2.TicketSchema) {
+	t.Helper()
+
+	if *updateGolden {
+		b, err := protojson.Marshal(schema)
+		if err != nil {
+			t.Fatalf("marshaling schema: %v", err)
+		}
+		var out bytes.Buffer
+		if err := json.Indent(&out, b, "", "  "); err != nil {
+			t.Fatalf("indenting schema: %v", err)
+		}
+		out.WriteString("\n")
+		if err := os.WriteFile(filepath.Join("testdata", name), out.Bytes(), 0o600); err != nil {
+			t.Fatalf("writing golden: %v", err)
+		}
+		return
+	}
+
+	want := &v2.TicketSchema{}
+	if err := protojson.Unmarshal(readTestdata(t, name), want); err != nil {
+		t.Fatalf("decoding golden: %v", err)
+	}
+	if !proto.Equal(schema, want) {
+		got, _ := protojson.Marshal(schema)
+		t.Errorf("schema does not match %s, rerun with -update if the change is intended:\n%s", name, got)
+	}
+}
@@ -0,0 +1,99 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// sprintCustomType is the custom type of the Jira Software sprint field. Its field
+	// ID, e.g. customfield_10020, differs between sites.
+	sprintCustomType = "com.pyxis.greenhopper.jira:gh-sprint"
+
+	// typeSprint marks the sprint field of a ticket schema, in its CustomField annotation.
+	typeSprint = "sprint"
+)
+
+// sprintCustomField takes a sprint ID as a string. The schema does not hold the active
+// sprint, which changes every sprint; tickets leaving the field empty get the sprint
+// active when they are created.
+func sprintCustomField(field *jira.MetaDataFields) *v2.TicketCustomField {
+	customField := sdkTicket.StringFieldSchema(field.Key, field.Name, field.Required)
+	customField.Annotations = annotations.New(&pbjira.CustomField{Type: typeSprint})
+
+	return customField
+}
+
+// ticketSprint returns the sprint a ticket is created in: the sprint ID it sets in the
+// sprint field, else the active sprint of the project, reported by active. Without
+// either the sprint is nil, and the ticket goes to the backlog.
+func (j *Jira) ticketSprint(ctx context.Context, projectKey string, field *v2.TicketCustomField) (*client.Sprint, bool, error) {
+	value, err := sdkTicket.GetStringValue(field)
+	if err != nil && !errors.Is(err, sdkTicket.ErrFieldNil) {
+		return nil, false, err
+	}
+
+	if value != "" {
+		sprintID, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, false, status.Errorf(codes.InvalidArgument, "baton-jira: invalid sprint ID %q for field %s", value, field.GetId())
+		}
+		return &client.Sprint{ID: sprintID}, false, nil
+	}
+
+	sprint, err := j.getActiveSprint(ctx, projectKey)
+	if err != nil {
+		// The sprint is optional, so the ticket is still created, in the backlog.
+		ctxzap.Extract(ctx).Warn("baton-jira: failed to get the active sprint of the project", zap.Error(err), zap.String("project_key", projectKey))
+		return nil, false, nil
+	}
+
+	return sprint, sprint != nil, nil
+}
+
+// getActiveSprint returns the active sprint of the first scrum board of the project that
+// has one, or nil if none has. It is read for each ticket, since sprints close. A board
+// whose sprints cannot be read, e.g. one the connector's user cannot see, is skipped.
+func (j *Jira) getActiveSprint(ctx context.Context, projectKey string) (*client.Sprint, error) {
+	l := ctxzap.Extract(ctx)
+
+	boardOffset := 0
+	for {
+		if err := client.PageContextErr(ctx, "listing project boards"); err != nil {
+			return nil, err
+		}
+
+		boards, lastPage, err := j.apiClient.ListProjectScrumBoards(ctx, projectKey, boardOffset, resourcePageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, board := range boards {
+			sprints, _, err := j.apiClient.ListActiveSprints(ctx, board.ID, 0, 1)
+			if err != nil {
+				l.Warn("baton-jira: failed to get the active sprints of a board, skipping it", zap.Error(err), zap.Int("board_id", board.ID))
+				continue
+			}
+			if len(sprints) > 0 {
+				return &sprints[0], nil
+			}
+		}
+
+		if lastPage {
+			return nil, nil
+		}
+		boardOffset += len(boards)
+	}
+}
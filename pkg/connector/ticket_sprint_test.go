@@ -0,0 +1,119 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// sprintHandler serves scrum boards 1, 2 and 3. Board 1 fails, board 2 has no active
+// sprint, and board 3 has sprint 42, unless boardsStatus fails the board listing.
+func sprintHandler(boardsStatus int, requests *int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/agile/1.0/board":
+			w.WriteHeader(boardsStatus)
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":1},{"id":2},{"id":3}]}`))
+		case strings.HasPrefix(r.URL.Path, "/rest/agile/1.0/board/1/"):
+			w.WriteHeader(http.StatusInternalServerError)
+		case strings.HasPrefix(r.URL.Path, "/rest/agile/1.0/board/2/"):
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[]}`))
+		case strings.HasPrefix(r.URL.Path, "/rest/agile/1.0/board/3/"):
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":42,"name":"Sprint 7","state":"active","originBoardId":3}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+func TestTicketSprint(t *testing.T) {
+	tests := []struct {
+		name         string
+		value        string
+		boardsStatus int
+		wantSprintID int
+		wantActive   bool
+		wantErr      bool
+		wantRequests bool
+	}{
+		{name: "sprint picked", value: "7", boardsStatus: http.StatusOK, wantSprintID: 7},
+		{name: "invalid sprint", value: "next", boardsStatus: http.StatusOK, wantErr: true},
+		{name: "active sprint, failing board skipped", boardsStatus: http.StatusOK, wantSprintID: 42, wantActive: true, wantRequests: true},
+		{name: "boards unavailable", boardsStatus: http.StatusForbidden, wantRequests: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := 0
+			j := newTestJira(t, sprintHandler(tt.boardsStatus, &requests))
+
+			var field = sdkTicket.StringField("customfield_10020", tt.value)
+			if tt.value == "" {
+				field = nil
+			}
+
+			sprint, active, err := j.ticketSprint(context.Background(), "PROJ", field)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			gotID := 0
+			if sprint != nil {
+				gotID = sprint.ID
+			}
+			if gotID != tt.wantSprintID || active != tt.wantActive {
+				t.Errorf("sprint = %d, active = %v, want %d, %v", gotID, active, tt.wantSprintID, tt.wantActive)
+			}
+			if (requests > 0) != tt.wantRequests {
+				t.Errorf("requests = %d, want requests %v", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestSprintFieldKeepsFingerprint(t *testing.T) {
+	issueFields := []*jira.MetaDataFields{
+		{
+			Key:  "customfield_10020",
+			Name: "Sprint",
+			Schema: jira.Schema{
+				Type:   "array",
+				Custom: sprintCustomType,
+			},
+		},
+	}
+
+	// The sprint field is built from the field metadata alone, so projects on different
+	// sprints keep the same fingerprint.
+	first := buildTicketSchema(&jira.Project{ID: "1", Key: "A"}, &jira.IssueType{ID: "10001"}, nil, false, customFieldsFromMetadata(issueFields, nil))
+	second := buildTicketSchema(&jira.Project{ID: "2", Key: "B"}, &jira.IssueType{ID: "10001"}, nil, false, customFieldsFromMetadata(issueFields, nil))
+
+	firstFingerprint, err := schemaFingerprint(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondFingerprint, err := schemaFingerprint(second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if firstFingerprint != secondFingerprint {
+		t.Errorf("fingerprints differ: %s and %s", firstFingerprint, secondFingerprint)
+	}
+
+	sprintField := first.GetCustomFields()["customfield_10020"]
+	if GeCustomFieldTypeAnnotation(sprintField.GetAnnotations()) != typeSprint {
+		t.Errorf("sprint field is not annotated as a sprint")
+	}
+	if sprintField.GetStringValue().GetDefaultValue() != "" {
+		t.Errorf("sprint field defaults to %q, want no default", sprintField.GetStringValue().GetDefaultValue())
+	}
+}
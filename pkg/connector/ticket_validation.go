@@ -0,0 +1,243 @@
+package connector
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxSuggestedValues bounds the allowed values listed in a validation error.
+const maxSuggestedValues = 5
+
+// normalizeTicketValues returns a copy of ticket whose pick values match the allowed
+// values of schema exactly. Callers often pass a pick string with another capitalization,
+// or the name of a pick object instead of its ID; a value matching exactly one allowed
+// value, ignoring case, is replaced by it. Anything else is left for validation to report.
+func normalizeTicketValues(schema *v2.TicketSchema, ticket *v2.Ticket) *v2.Ticket {
+	rv := proto.Clone(ticket).(*v2.Ticket)
+
+	for id, cf := range schema.GetCustomFields() {
+		ticketCf, ok := rv.GetCustomFields()[id]
+		if !ok {
+			continue
+		}
+
+		switch v := cf.GetValue().(type) {
+		case *v2.TicketCustomField_PickStringValue:
+			if tv, ok := ticketCf.GetValue().(*v2.TicketCustomField_PickStringValue); ok {
+				tv.PickStringValue.Value = matchPickString(tv.PickStringValue.GetValue(), v.PickStringValue.GetAllowedValues())
+			}
+		case *v2.TicketCustomField_PickMultipleStringValues:
+			if tv, ok := ticketCf.GetValue().(*v2.TicketCustomField_PickMultipleStringValues); ok {
+				for i, value := range tv.PickMultipleStringValues.GetValues() {
+					tv.PickMultipleStringValues.Values[i] = matchPickString(value, v.PickMultipleStringValues.GetAllowedValues())
+				}
+			}
+		case *v2.TicketCustomField_PickObjectValue:
+			if tv, ok := ticketCf.GetValue().(*v2.TicketCustomField_PickObjectValue); ok && tv.PickObjectValue.GetValue() != nil {
+				tv.PickObjectValue.Value = matchPickObject(tv.PickObjectValue.GetValue(), v.PickObjectValue.GetAllowedValues())
+			}
+		case *v2.TicketCustomField_PickMultipleObjectValues:
+			if tv, ok := ticketCf.GetValue().(*v2.TicketCustomField_PickMultipleObjectValues); ok {
+				for i, value := range tv.PickMultipleObjectValues.GetValues() {
+					tv.PickMultipleObjectValues.Values[i] = matchPickObject(value, v.PickMultipleObjectValues.GetAllowedValues())
+				}
+			}
+		}
+	}
+
+	return rv
+}
+
+// matchPickString returns the allowed value equal to value, or the only one equal to it
+// ignoring case, or value itself.
+func matchPickString(value string, allowedValues []string) string {
+	var match string
+	matches := 0
+	for _, allowed := range allowedValues {
+		if allowed == value {
+			return value
+		}
+		if strings.EqualFold(allowed, value) {
+			match = allowed
+			matches++
+		}
+	}
+
+	if matches == 1 {
+		return match
+	}
+
+	return value
+}
+
+// matchPickObject returns the allowed value with the ID of value, or the only one whose ID
+// or display name equals the ID or display name of value ignoring case, or value itself.
+func matchPickObject(value *v2.TicketCustomFieldObjectValue, allowedValues []*v2.TicketCustomFieldObjectValue) *v2.TicketCustomFieldObjectValue {
+	var match *v2.TicketCustomFieldObjectValue
+	matches := 0
+	for _, allowed := range allowedValues {
+		if allowed.GetId() == value.GetId() {
+			return value
+		}
+
+		for _, given := range []string{value.GetId(), value.GetDisplayName()} {
+			if given != "" && (strings.EqualFold(allowed.GetId(), given) || strings.EqualFold(allowed.GetDisplayName(), given)) {
+				match = allowed
+				matches++
+				break
+			}
+		}
+	}
+
+	if matches == 1 {
+		return match
+	}
+
+	return value
+}
+
+// suggestValues lists the allowed values closest to value: those containing it or
+// contained in it, ignoring case, or else the first few allowed values.
+func suggestValues(value string, allowedValues []string) string {
+	lower := strings.ToLower(value)
+
+	var rv []string
+	for _, allowed := range allowedValues {
+		lowerAllowed := strings.ToLower(allowed)
+		if lower != "" && (strings.Contains(lowerAllowed, lower) || strings.Contains(lower, lowerAllowed)) {
+			rv = append(rv, allowed)
+		}
+	}
+	if len(rv) == 0 {
+		rv = allowedValues
+	}
+	if len(rv) > maxSuggestedValues {
+		rv = rv[:maxSuggestedValues]
+	}
+
+	return strings.Join(rv, ", ")
+}
+
+func objectValueNames(values []*v2.TicketCustomFieldObjectValue) []string {
+	rv := make([]string, 0, len(values))
+	for _, value := range values {
+		rv = append(rv, fmt.Sprintf("%s (%s)", value.GetDisplayName(), value.GetId()))
+	}
+
+	return rv
+}
+
+func invalidTicketValue(fieldID string, value string, allowedValues []string) error {
+	return status.Errorf(
+		codes.InvalidArgument,
+		"baton-jira: invalid value %q for field %s, closest allowed values: %s",
+		value,
+		fieldID,
+		suggestValues(value, allowedValues),
+	)
+}
+
+// ticketValidationError explains why sdkTicket.ValidateTicket rejected a ticket, naming
+// the first field at fault, as an InvalidArgument error that also matches
+// sdkTicket.ErrTicketValidationError.
+func ticketValidationError(schema *v2.TicketSchema, ticket *v2.Ticket) error {
+	err := diagnoseTicket(schema, ticket)
+	if err == nil {
+		err = status.Error(codes.InvalidArgument, "baton-jira: unable to create ticket, ticket is invalid")
+	}
+
+	return errors.Join(err, sdkTicket.ErrTicketValidationError)
+}
+
+func diagnoseTicket(schema *v2.TicketSchema, ticket *v2.Ticket) error {
+	if ticket.GetStatus() != nil {
+		var statuses []string
+		found := false
+		for _, s := range schema.GetStatuses() {
+			statuses = append(statuses, s.GetId())
+			found = found || s.GetId() == ticket.GetStatus().GetId()
+		}
+		if !found {
+			return invalidTicketValue("status", ticket.GetStatus().GetId(), statuses)
+		}
+	}
+
+	// Fields are checked in a stable order, so the same ticket always reports the same field.
+	fieldIDs := make([]string, 0, len(schema.GetCustomFields()))
+	for id := range schema.GetCustomFields() {
+		fieldIDs = append(fieldIDs, id)
+	}
+	sort.Strings(fieldIDs)
+
+	for _, id := range fieldIDs {
+		cf := schema.GetCustomFields()[id]
+		ticketCf, ok := ticket.GetCustomFields()[id]
+		if !ok {
+			if cf.GetRequired() {
+				return status.Errorf(codes.InvalidArgument, "baton-jira: missing required field %s", id)
+			}
+			continue
+		}
+
+		value, err := sdkTicket.GetCustomFieldValueOrDefault(ticketCf)
+		if err != nil {
+			return status.Errorf(codes.InvalidArgument, "baton-jira: invalid value for field %s: %s", id, err)
+		}
+
+		switch v := cf.GetValue().(type) {
+		case *v2.TicketCustomField_PickStringValue:
+			given, ok := value.(string)
+			if !ok {
+				return status.Errorf(codes.InvalidArgument, "baton-jira: field %s expects a pick string value", id)
+			}
+			if given != "" && !slices.Contains(v.PickStringValue.GetAllowedValues(), given) {
+				return invalidTicketValue(id, given, v.PickStringValue.GetAllowedValues())
+			}
+		case *v2.TicketCustomField_PickMultipleStringValues:
+			given, ok := value.([]string)
+			if !ok {
+				return status.Errorf(codes.InvalidArgument, "baton-jira: field %s expects pick string values", id)
+			}
+			for _, g := range given {
+				if !slices.Contains(v.PickMultipleStringValues.GetAllowedValues(), g) {
+					return invalidTicketValue(id, g, v.PickMultipleStringValues.GetAllowedValues())
+				}
+			}
+		case *v2.TicketCustomField_PickObjectValue:
+			given, ok := value.(*v2.TicketCustomFieldObjectValue)
+			if !ok {
+				return status.Errorf(codes.InvalidArgument, "baton-jira: field %s expects a pick object value", id)
+			}
+			if given.GetId() != "" && !containsObject(v.PickObjectValue.GetAllowedValues(), given) {
+				return invalidTicketValue(id, given.GetId(), objectValueNames(v.PickObjectValue.GetAllowedValues()))
+			}
+		case *v2.TicketCustomField_PickMultipleObjectValues:
+			given, ok := value.([]*v2.TicketCustomFieldObjectValue)
+			if !ok {
+				return status.Errorf(codes.InvalidArgument, "baton-jira: field %s expects pick object values", id)
+			}
+			for _, g := range given {
+				if !containsObject(v.PickMultipleObjectValues.GetAllowedValues(), g) {
+					return invalidTicketValue(id, g.GetId(), objectValueNames(v.PickMultipleObjectValues.GetAllowedValues()))
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func containsObject(values []*v2.TicketCustomFieldObjectValue, value *v2.TicketCustomFieldObjectValue) bool {
+	return slices.ContainsFunc(values, func(v *v2.TicketCustomFieldObjectValue) bool {
+		return v.GetId() == value.GetId()
+	})
+}
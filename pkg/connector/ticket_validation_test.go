@@ -0,0 +1,162 @@
+package connector
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func testValidationSchema() *v2.TicketSchema {
+	teams := []*v2.TicketCustomFieldObjectValue{
+		{Id: "10", DisplayName: "Platform"},
+		{Id: "11", DisplayName: "Payments"},
+	}
+
+	return &v2.TicketSchema{
+		Statuses: []*v2.TicketStatus{{Id: "1"}, {Id: "3"}},
+		CustomFields: map[string]*v2.TicketCustomField{
+			"priority":    sdkTicket.PickStringFieldSchema("priority", "Priority", true, []string{"Low", "Medium", "High"}),
+			"labels":      sdkTicket.PickMultipleStringsFieldSchema("labels", "Labels", false, []string{"access", "urgent"}),
+			"team":        sdkTicket.PickObjectValueFieldSchema("team", "Team", false, teams),
+			"watch_teams": sdkTicket.PickMultipleObjectValuesFieldSchema("watch_teams", "Watch teams", false, teams),
+		},
+	}
+}
+
+func TestNormalizeTicketValues(t *testing.T) {
+	tests := []struct {
+		name  string
+		field *v2.TicketCustomField
+		want  *v2.TicketCustomField
+	}{
+		{
+			name:  "pick string capitalization",
+			field: sdkTicket.PickStringField("priority", "high"),
+			want:  sdkTicket.PickStringField("priority", "High"),
+		},
+		{
+			name:  "exact pick string",
+			field: sdkTicket.PickStringField("priority", "Low"),
+			want:  sdkTicket.PickStringField("priority", "Low"),
+		},
+		{
+			name:  "unknown pick string",
+			field: sdkTicket.PickStringField("priority", "Critical"),
+			want:  sdkTicket.PickStringField("priority", "Critical"),
+		},
+		{
+			name:  "pick strings capitalization",
+			field: sdkTicket.PickMultipleStringsField("labels", []string{"ACCESS", "urgent"}),
+			want:  sdkTicket.PickMultipleStringsField("labels", []string{"access", "urgent"}),
+		},
+		{
+			name:  "pick object by display name",
+			field: sdkTicket.PickObjectValueField("team", &v2.TicketCustomFieldObjectValue{Id: "payments"}),
+			want:  sdkTicket.PickObjectValueField("team", &v2.TicketCustomFieldObjectValue{Id: "11", DisplayName: "Payments"}),
+		},
+		{
+			name:  "pick objects by display name",
+			field: sdkTicket.PickMultipleObjectValuesField("watch_teams", []*v2.TicketCustomFieldObjectValue{{DisplayName: "platform"}, {Id: "11"}}),
+			want: sdkTicket.PickMultipleObjectValuesField("watch_teams", []*v2.TicketCustomFieldObjectValue{
+				{Id: "10", DisplayName: "Platform"},
+				{Id: "11"},
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := &v2.Ticket{CustomFields: map[string]*v2.TicketCustomField{tt.field.GetId(): tt.field}}
+			original := proto.Clone(ticket)
+
+			got := normalizeTicketValues(testValidationSchema(), ticket)
+			if !proto.Equal(got.GetCustomFields()[tt.field.GetId()], tt.want) {
+				t.Errorf("normalized field = %v, want %v", got.GetCustomFields()[tt.field.GetId()], tt.want)
+			}
+			if !proto.Equal(ticket, original) {
+				t.Errorf("normalizeTicketValues modified the given ticket")
+			}
+		})
+	}
+}
+
+func TestTicketValidationError(t *testing.T) {
+	tests := []struct {
+		name         string
+		ticket       *v2.Ticket
+		wantMessages []string
+	}{
+		{
+			name: "unknown status",
+			ticket: &v2.Ticket{
+				Status:       &v2.TicketStatus{Id: "7"},
+				CustomFields: map[string]*v2.TicketCustomField{"priority": sdkTicket.PickStringField("priority", "Low")},
+			},
+			wantMessages: []string{`"7"`, "field status", "1, 3"},
+		},
+		{
+			name:         "missing required field",
+			ticket:       &v2.Ticket{},
+			wantMessages: []string{"missing required field priority"},
+		},
+		{
+			name:         "pick string suggestions",
+			ticket:       &v2.Ticket{CustomFields: map[string]*v2.TicketCustomField{"priority": sdkTicket.PickStringField("priority", "Highest")}},
+			wantMessages: []string{`"Highest"`, "field priority", "closest allowed values: High"},
+		},
+		{
+			name: "pick strings",
+			ticket: &v2.Ticket{CustomFields: map[string]*v2.TicketCustomField{
+				"priority": sdkTicket.PickStringField("priority", "Low"),
+				"labels":   sdkTicket.PickMultipleStringsField("labels", []string{"access", "blocked"}),
+			}},
+			wantMessages: []string{`"blocked"`, "field labels", "access, urgent"},
+		},
+		{
+			name: "pick object",
+			ticket: &v2.Ticket{CustomFields: map[string]*v2.TicketCustomField{
+				"priority": sdkTicket.PickStringField("priority", "Low"),
+				"team":     sdkTicket.PickObjectValueField("team", &v2.TicketCustomFieldObjectValue{Id: "12"}),
+			}},
+			wantMessages: []string{`"12"`, "field team", "Platform (10), Payments (11)"},
+		},
+		{
+			name: "fields are reported in order",
+			ticket: &v2.Ticket{CustomFields: map[string]*v2.TicketCustomField{
+				"priority":    sdkTicket.PickStringField("priority", "Critical"),
+				"labels":      sdkTicket.PickMultipleStringsField("labels", []string{"blocked"}),
+				"watch_teams": sdkTicket.PickMultipleObjectValuesField("watch_teams", []*v2.TicketCustomFieldObjectValue{{Id: "12"}}),
+			}},
+			wantMessages: []string{"field labels"},
+		},
+		{
+			name:         "nothing to diagnose",
+			ticket:       &v2.Ticket{CustomFields: map[string]*v2.TicketCustomField{"priority": sdkTicket.PickStringField("priority", "Low")}},
+			wantMessages: []string{"ticket is invalid"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ticketValidationError(testValidationSchema(), tt.ticket)
+			if !errors.Is(err, sdkTicket.ErrTicketValidationError) {
+				t.Errorf("error %v does not match sdkTicket.ErrTicketValidationError", err)
+			}
+			// The diagnosis comes first in the joined error, as an InvalidArgument status.
+			if joined, ok := err.(interface{ Unwrap() []error }); !ok || status.Code(joined.Unwrap()[0]) != codes.InvalidArgument {
+				t.Errorf("error %v is not joined with an %v status", err, codes.InvalidArgument)
+			}
+			for _, want := range tt.wantMessages {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not contain %q", err, want)
+				}
+			}
+		})
+	}
+}
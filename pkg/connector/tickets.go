@@ -4,25 +4,43 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"path"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	sdkTicket "github.com/conductorone/baton-sdk/pkg/types/ticket"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/anypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	jira "github.com/conductorone/go-jira/v2/cloud"
 )
 
+const (
+	issueLinkTypeFieldID   = "issue_link_type"
+	issueLinkTargetFieldID = "issue_link_target"
+)
+
+// schemaIDPattern matches a "projectKey:issueTypeID" schema ID. Jira project keys start with
+// an uppercase letter followed by uppercase letters, digits or underscores.
+var schemaIDPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*:[0-9]+$`)
+
+// issueKeyPattern matches a Jira issue key such as "PROJ-123".
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9]+-[0-9]+$`)
+
 var ignoreRequiredSystem = map[string]bool{
 	"issuetype": true,
 	"project":   true,
@@ -58,6 +76,33 @@ func (p *ProjectKeyIssueTypeIDSchemaID) Parse(schemaID string) error {
 	return nil
 }
 
+// Jira's schema type for cascading select custom fields.
+const typeCascadingSelect = client.CascadingSelectType
+
+// Cascading select options are flattened into one pick list. Child options use
+// "parentID:childID" as their ID so both levels can be sent back to Jira.
+func cascadingOptionID(parentID string, childID string) string {
+	return fmt.Sprintf("%s:%s", parentID, childID)
+}
+
+// cascadingSelectValue is the value Jira expects for a cascading select field.
+type cascadingSelectValue struct {
+	Id    string                `json:"id"`
+	Child *cascadingSelectValue `json:"child,omitempty"`
+}
+
+func cascadingSelectValueFromID(id string) *cascadingSelectValue {
+	parentID, childID, ok := strings.Cut(id, ":")
+	if !ok || childID == "" {
+		return &cascadingSelectValue{Id: parentID}
+	}
+
+	return &cascadingSelectValue{
+		Id:    parentID,
+		Child: &cascadingSelectValue{Id: childID},
+	}
+}
+
 type JiraName struct {
 	Name string `json:"name,omitempty"`
 }
@@ -113,6 +158,10 @@ func (j *Jira) customFieldSchemaToMetaField(field *v2.TicketCustomField) (interf
 		return v.PickMultipleStringValues.GetValues(), nil
 	case *v2.TicketCustomField_PickObjectValue:
 		if v.PickObjectValue.GetValue() != nil {
+			if GeCustomFieldTypeAnnotation(field.Annotations) == typeCascadingSelect {
+				return cascadingSelectValueFromID(v.PickObjectValue.GetValue().GetId()), nil
+			}
+
 			return &JiraPickerStruct{
 				Id: v.PickObjectValue.GetValue().GetId(),
 			}, nil
@@ -136,36 +185,61 @@ func (j *Jira) getJiraStatusesForProject(ctx context.Context, projectId string)
 	statusMaxResults := 100
 
 	for {
+		if err := client.PageContextErr(ctx, "listing project statuses"); err != nil {
+			return nil, err
+		}
+
 		// Fetch statuses here and pass in to schemaForProject
-		statuses, resp, err := j.client.Status.SearchStatusesPaginated(ctx,
-			jira.WithStartAt(statusOffset),
-			jira.WithMaxResults(statusMaxResults),
-			jira.WithStatusCategory("DONE"),
-			jira.WithProjectId(projectId))
+		statuses, lastPage, err := j.apiClient.SearchProjectStatuses(ctx, projectId, "DONE", statusOffset, statusMaxResults)
 		if err != nil {
 			return nil, err
 		}
 
 		jiraStatuses = append(jiraStatuses, statuses...)
 
-		statusOffset += resp.MaxResults
-		if statusOffset >= resp.Total {
+		if lastPage {
 			break
 		}
+		statusOffset += len(statuses)
 	}
 
 	return jiraStatuses, nil
 }
 
 func (j *Jira) schemaForProjectIssueType(ctx context.Context, project *jira.Project, issueType *jira.IssueType, statuses []*v2.TicketStatus, includeProjectInName bool) (*v2.TicketSchema, error) {
-	customFieldsMap := make(map[string]*v2.TicketCustomField)
-
-	issueTypeCustomFields, err := j.getCustomFieldsForIssueType(ctx, project.ID, issueType)
+	issueFields, cascadingOptions, err := j.GetIssueTypeFields(ctx, project.ID, issueType.ID, &jira.GetQueryIssueTypeOptions{
+		MaxResults: 100,
+		StartAt:    0,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	for _, cf := range issueTypeCustomFields {
+	customFields := customFieldsFromMetadata(issueFields, cascadingOptions)
+
+	// Issue links are optional, so a schema without them beats no schema, e.g. when links
+	// are disabled on the site.
+	linkTypes, err := j.session.getIssueLinkTypes(ctx, j.apiClient)
+	if err != nil {
+		ctxzap.Extract(ctx).Warn("baton-jira: failed to get issue link types, leaving out the issue link fields", zap.Error(err))
+	} else {
+		customFields = append(customFields, issueLinkFields(linkTypes)...)
+	}
+
+	return buildTicketSchema(project, issueType, statuses, includeProjectInName, customFields), nil
+}
+
+// buildTicketSchema builds the schema of an issue type of a project from its fields,
+// without calling Jira.
+func buildTicketSchema(
+	project *jira.Project,
+	issueType *jira.IssueType,
+	statuses []*v2.TicketStatus,
+	includeProjectInName bool,
+	customFields []*v2.TicketCustomField,
+) *v2.TicketSchema {
+	customFieldsMap := make(map[string]*v2.TicketCustomField)
+	for _, cf := range customFields {
 		customFieldsMap[cf.GetId()] = cf
 	}
 
@@ -187,68 +261,146 @@ func (j *Jira) schemaForProjectIssueType(ctx context.Context, project *jira.Proj
 		ProjectKey:  project.Key,
 	}
 
-	ret := &v2.TicketSchema{
+	return &v2.TicketSchema{
 		Id:           schemaId,
 		DisplayName:  displayName,
 		CustomFields: customFieldsMap,
 		Annotations:  annotations.New(projectAnno),
 		Statuses:     statuses,
 	}
+}
 
-	return ret, nil
+// syncedIssueField reports whether a create metadata field is part of the ticket schema:
+// required fields other than the ones the connector fills in, and optional custom fields
+// and components.
+func syncedIssueField(field *jira.MetaDataFields) bool {
+	// TODO(lauren) remove custom?
+	if !field.Required {
+		return field.Schema.Custom != "" || field.FieldId == "components"
+	}
+
+	_, ignored := ignoreRequiredSystem[field.FieldId]
+	return !ignored
 }
 
-func (j *Jira) getCustomFieldsForIssueType(ctx context.Context, projectId string, issueType *jira.IssueType) ([]*v2.TicketCustomField, error) {
+// customFieldsFromMetadata converts the create metadata fields of an issue type. Cascading
+// selects take their options from cascadingOptions, by field key, since go-jira drops the
+// child options from the metadata.
+func customFieldsFromMetadata(issueFields []*jira.MetaDataFields, cascadingOptions map[string][]client.CascadingOption) []*v2.TicketCustomField {
 	customFields := make([]*v2.TicketCustomField, 0)
 
-	issueFields, err := j.GetIssueTypeFields(ctx, projectId, issueType.ID, &jira.GetQueryIssueTypeOptions{
-		MaxResults: 100,
-		StartAt:    0,
-	})
-	if err != nil {
-		return nil, err
-	}
-
 	for _, field := range issueFields {
-		// TODO(lauren) remove custom?
-		if !field.Required {
-			if field.Schema.Custom == "" && field.FieldId != "components" {
-				continue
-			}
-		} else {
-			if _, ok := ignoreRequiredSystem[field.FieldId]; ok {
-				continue
-			}
+		if !syncedIssueField(field) {
+			continue
 		}
+
+		if field.Schema.Type == typeCascadingSelect {
+			customFields = append(customFields, cascadingSelectCustomField(field, cascadingOptions[field.Key]))
+			continue
+		}
+
+		if field.Schema.Custom == sprintCustomType {
+			customFields = append(customFields, sprintCustomField(field))
+			continue
+		}
+
 		customField := convertMetadataFieldToCustomField(field)
 		customFields = append(customFields, customField)
 	}
 
-	return customFields, nil
+	return customFields
+}
+
+// cascadingSelectCustomField offers every parent option on its own and every parent and child pair.
+func cascadingSelectCustomField(field *jira.MetaDataFields, options []client.CascadingOption) *v2.TicketCustomField {
+	var allowedValues []*v2.TicketCustomFieldObjectValue
+	for _, parent := range options {
+		allowedValues = append(allowedValues, &v2.TicketCustomFieldObjectValue{
+			Id:          parent.ID,
+			DisplayName: parent.Value,
+		})
+
+		for _, child := range parent.Children {
+			allowedValues = append(allowedValues, &v2.TicketCustomFieldObjectValue{
+				Id:          cascadingOptionID(parent.ID, child.ID),
+				DisplayName: fmt.Sprintf("%s - %s", parent.Value, child.Value),
+			})
+		}
+	}
+
+	customField := sdkTicket.PickObjectValueFieldSchema(field.Key, field.Name, field.Required, allowedValues)
+	customField.Annotations = annotations.New(&pbjira.CustomField{Type: typeCascadingSelect})
+
+	return customField
+}
+
+// issueLinkFields exposes the site's issue link types, so a ticket can be created
+// already linked to another issue (e.g. "Blocks" or "Relates").
+func issueLinkFields(linkTypes []jira.IssueLinkType) []*v2.TicketCustomField {
+	if len(linkTypes) == 0 {
+		return nil
+	}
+
+	allowedValues := make([]*v2.TicketCustomFieldObjectValue, 0, len(linkTypes))
+	for _, linkType := range linkTypes {
+		allowedValues = append(allowedValues, &v2.TicketCustomFieldObjectValue{
+			Id:          linkType.ID,
+			DisplayName: linkType.Name,
+		})
+	}
+
+	return []*v2.TicketCustomField{
+		sdkTicket.PickObjectValueFieldSchema(issueLinkTypeFieldID, "Issue link type", false, allowedValues),
+		sdkTicket.StringFieldSchema(issueLinkTargetFieldID, "Linked issue key", false),
+	}
 }
 
-func (j *Jira) GetIssueTypeFields(ctx context.Context, projectKey, issueTypeId string, opts *jira.GetQueryIssueTypeOptions) ([]*jira.MetaDataFields, error) {
+// GetIssueTypeFields returns the create metadata fields of an issue type of a project, and
+// the options of its cascading selects by field key.
+func (j *Jira) GetIssueTypeFields(
+	ctx context.Context,
+	projectKey string,
+	issueTypeId string,
+	opts *jira.GetQueryIssueTypeOptions,
+) ([]*jira.MetaDataFields, map[string][]client.CascadingOption, error) {
 	l := ctxzap.Extract(ctx)
 
+	startAt := 0
+	maxResults := 100
+	if opts != nil {
+		startAt = opts.StartAt
+		if opts.MaxResults > 0 {
+			maxResults = opts.MaxResults
+		}
+	}
+
 	allMetaFields := make([]*jira.MetaDataFields, 0)
+	allCascadingOptions := make(map[string][]client.CascadingOption)
 
 	for {
-		issueFields, resp, err := j.client.Issue.GetCreateMetaIssueType(ctx, projectKey, issueTypeId, opts)
+		if err := client.PageContextErr(ctx, "listing issue type fields"); err != nil {
+			return nil, nil, err
+		}
+
+		issueFields, cascadingOptions, lastPage, err := j.apiClient.GetCreateMetaFields(ctx, projectKey, issueTypeId, startAt, maxResults)
 		if err != nil {
 			l.Error("error getting issue type fields", zap.Error(err))
-			return nil, err
+			return nil, nil, err
 		}
 
 		allMetaFields = append(allMetaFields, issueFields...)
+		for key, options := range cascadingOptions {
+			allCascadingOptions[key] = options
+		}
 
-		if len(allMetaFields) >= resp.Total || opts == nil {
+		if lastPage {
 			break
 		}
 
-		opts.StartAt += len(allMetaFields)
+		startAt += len(issueFields)
 	}
 
-	return allMetaFields, nil
+	return allMetaFields, allCascadingOptions, nil
 }
 
 func convertMetadataFieldToCustomField(metaDataField *jira.MetaDataFields) *v2.TicketCustomField {
@@ -308,21 +460,52 @@ func convertMetadataFieldToCustomField(metaDataField *jira.MetaDataFields) *v2.T
 }
 
 func (j *Jira) ListTicketSchemas(ctx context.Context, p *pagination.Token) ([]*v2.TicketSchema, string, annotations.Annotations, error) {
-	var ret []*v2.TicketSchema
+	pageToken := ""
+	pageSize := resourcePageSize
+	if p != nil {
+		pageToken = p.Token
+		if p.Size > 0 {
+			pageSize = p.Size
+		}
+	}
 
-	offset := 0
-	// get offset from page token if its not empty
-	if p != nil && p.Token != "" {
-		var err error
-		offset, err = strconv.Atoi(p.Token)
+	// Identical schemas can only be found once every project is read, so consolidated
+	// schemas are returned in a single page.
+	if j.ticketConsolidateSchemas {
+		ret, err := j.listConsolidatedTicketSchemas(ctx, pageSize)
 		if err != nil {
 			return nil, "", nil, err
 		}
+		return ret, "", nil, nil
 	}
 
-	projects, resp, err := j.client.Project.Find(ctx, jira.WithStartAt(offset), jira.WithMaxResults(p.Size), jira.WithExpand("issueTypes"))
+	projectSchemas, nextPageToken, err := j.listProjectTicketSchemas(ctx, pageToken, pageSize)
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to get projects")
+		return nil, "", nil, err
+	}
+
+	ret := make([]*v2.TicketSchema, 0, len(projectSchemas))
+	for _, projectSchema := range projectSchemas {
+		ret = append(ret, projectSchema.schema)
+	}
+
+	return ret, nextPageToken, nil, nil
+}
+
+// projectTicketSchema is the schema of an issue type of a project.
+type projectTicketSchema struct {
+	project   *jira.Project
+	issueType *jira.IssueType
+	schema    *v2.TicketSchema
+}
+
+// listProjectTicketSchemas returns the schemas of the issue types of a page of projects.
+func (j *Jira) listProjectTicketSchemas(ctx context.Context, pageToken string, pageSize int) ([]projectTicketSchema, string, error) {
+	var ret []projectTicketSchema
+
+	projects, nextPageToken, err := findProjectsPage(ctx, j.apiClient, j.projectKeys, pageToken, pageSize, []string{"issueTypes"})
+	if err != nil {
+		return nil, "", err
 	}
 
 	multipleProjects := false
@@ -330,37 +513,70 @@ func (j *Jira) ListTicketSchemas(ctx context.Context, p *pagination.Token) ([]*v
 		multipleProjects = true
 	}
 
-	for _, project := range projects {
+	l := ctxzap.Extract(ctx)
+
+	// A project the account cannot read the create metadata of is skipped, so one
+	// misconfigured project does not stop every other schema from being listed.
+	skipProject := func(project *jira.Project, err error) bool {
+		denied := status.Code(client.ClassifyError(err)) == codes.PermissionDenied
+		if !denied && !client.IsNotFound(err) {
+			return false
+		}
+
+		l.Warn("skipping project in ticket schemas", zap.String("project_key", project.Key), zap.Error(err))
+		if denied {
+			j.permissionGaps.record(j.siteName(), gapTicketSchema, project.Key, err)
+		}
+		return true
+	}
+
+projects:
+	for i := range projects {
+		project := &projects[i]
 		statuses, err := j.getTicketStatuses(ctx, project.ID)
 		if err != nil {
-			return nil, "", nil, err
+			if skipProject(project, err) {
+				continue
+			}
+			return nil, "", err
 		}
-		for _, issueType := range project.IssueTypes {
+
+		var projectSchemas []projectTicketSchema
+		for k := range project.IssueTypes {
+			issueType := &project.IssueTypes[k]
 			if issueType.Name == "Epic" || issueType.Name == "Bug" {
 				continue
 			}
 
-			if issueType.Subtask {
+			if issueType.Subtask || j.issueTypeExcluded(issueType.Name) {
 				continue
 			}
 
-			schema, err := j.schemaForProjectIssueType(ctx, &project, &issueType, statuses, multipleProjects)
+			schema, err := j.schemaForProjectIssueType(ctx, project, issueType, statuses, multipleProjects)
 			if err != nil {
-				return nil, "", nil, err
+				if skipProject(project, err) {
+					continue projects
+				}
+				return nil, "", err
 			}
-			ret = append(ret, schema)
+			projectSchemas = append(projectSchemas, projectTicketSchema{
+				project:   project,
+				issueType: issueType,
+				schema:    schema,
+			})
 		}
-	}
 
-	nextPageToken := ""
-	if offset < resp.Total {
-		nextPageToken = fmt.Sprintf("%d", offset+len(ret))
+		ret = append(ret, projectSchemas...)
 	}
 
-	return ret, nextPageToken, nil, nil
+	return ret, nextPageToken, nil
 }
 
 func (j *Jira) getTicketStatuses(ctx context.Context, projectID string) ([]*v2.TicketStatus, error) {
+	if cached, ok := j.session.getTicketStatuses(projectID); ok {
+		return cached, nil
+	}
+
 	statuses, err := j.getJiraStatusesForProject(ctx, projectID)
 	if err != nil {
 		return nil, err
@@ -372,18 +588,35 @@ func (j *Jira) getTicketStatuses(ctx context.Context, projectID string) ([]*v2.T
 			DisplayName: status.Name,
 		})
 	}
+	j.session.setTicketStatuses(projectID, ret)
 
 	return ret, nil
 }
 
+// GetTicketSchema is cached per schema ID for the ticket schema TTL, since the platform
+// refreshes schemas often and each one costs a project, statuses and createmeta walk.
+// The "projectKey:issueTypeID" schemas of consolidated projects stay resolvable, so
+// tickets and configs created before consolidation keep working.
 func (j *Jira) GetTicketSchema(ctx context.Context, schemaID string) (*v2.TicketSchema, annotations.Annotations, error) {
+	if cached, ok := j.session.getTicketSchema(schemaID); ok {
+		return cached, nil, nil
+	}
+
+	if strings.HasPrefix(schemaID, consolidatedSchemaIDPrefix) {
+		ret, err := j.getConsolidatedTicketSchema(ctx, schemaID)
+		if err != nil {
+			return nil, nil, err
+		}
+		return ret, nil, nil
+	}
+
 	projectKeyIssueTypeID := &ProjectKeyIssueTypeIDSchemaID{}
 	err := projectKeyIssueTypeID.Parse(schemaID)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	project, _, err := j.client.Project.Get(ctx, projectKeyIssueTypeID.ProjectKey)
+	project, err := j.session.getProject(ctx, j.client, projectKeyIssueTypeID.ProjectKey)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -402,18 +635,19 @@ func (j *Jira) GetTicketSchema(ctx context.Context, schemaID string) (*v2.Ticket
 	if err != nil {
 		return nil, nil, err
 	}
+	j.session.setTicketSchema(schemaID, project.ID, ret)
 
 	return ret, nil, nil
 }
 
-func (j *Jira) issueToTicket(ctx context.Context, issue *jira.Issue) (*v2.Ticket, error) {
+func (j *Jira) issueToTicket(ctx context.Context, issue *jira.Issue) (*v2.Ticket, annotations.Annotations, error) {
 	if issue.Fields == nil {
-		return nil, errors.New("issue has no fields")
+		return nil, nil, errors.New("issue has no fields")
 	}
 
 	issueURL, err := j.generateIssueURL(issue.Key)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	ret := &v2.Ticket{
@@ -446,11 +680,78 @@ func (j *Jira) issueToTicket(ctx context.Context, issue *jira.Issue) (*v2.Ticket
 		}
 	}
 
+	var annos annotations.Annotations
+	if links := issueLinksAnnotation(issue); links != nil {
+		annos.Update(links)
+	}
+
+	return ret, annos, nil
+}
+
+// issueWatchersAnnotation returns nil when the watcher list is hidden from the connector's user.
+func (j *Jira) issueWatchersAnnotation(ctx context.Context, issueKey string) (*pbjira.JiraIssueWatchers, error) {
+	l := ctxzap.Extract(ctx)
+
+	watchers, resp, err := j.apiClient.GetIssueWatchers(ctx, issueKey)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusForbidden {
+			l.Debug("watchers are hidden, skipping", zap.String("issue", issueKey))
+			return nil, nil
+		}
+		return nil, client.WrapError(err, "failed to get issue watchers")
+	}
+
+	ret := &pbjira.JiraIssueWatchers{
+		WatchCount: int64(watchers.WatchCount),
+	}
+	for _, watcher := range watchers.Watchers {
+		if watcher.AccountID != "" {
+			ret.AccountIds = append(ret.AccountIds, watcher.AccountID)
+		}
+	}
+
 	return ret, nil
 }
 
+// v2.Ticket has no room for relationships, so the issue links are returned as an annotation.
+func issueLinksAnnotation(issue *jira.Issue) *pbjira.JiraIssueLinks {
+	if len(issue.Fields.IssueLinks) == 0 {
+		return nil
+	}
+
+	ret := &pbjira.JiraIssueLinks{}
+	for _, link := range issue.Fields.IssueLinks {
+		if link == nil {
+			continue
+		}
+
+		issueLink := &pbjira.JiraIssueLink{
+			Id:       link.ID,
+			TypeId:   link.Type.ID,
+			TypeName: link.Type.Name,
+		}
+
+		switch {
+		case link.OutwardIssue != nil:
+			issueLink.Direction = "outward"
+			issueLink.IssueId = link.OutwardIssue.ID
+			issueLink.IssueKey = link.OutwardIssue.Key
+		case link.InwardIssue != nil:
+			issueLink.Direction = "inward"
+			issueLink.IssueId = link.InwardIssue.ID
+			issueLink.IssueKey = link.InwardIssue.Key
+		default:
+			continue
+		}
+
+		ret.Links = append(ret.Links, issueLink)
+	}
+
+	return ret
+}
+
 func (j *Jira) GetTicket(ctx context.Context, ticketId string) (*v2.Ticket, annotations.Annotations, error) {
-	issue, _, err := j.client.Issue.Get(ctx, ticketId, nil)
+	issue, _, err := j.client.Issue.Get(ctx, parseTicketIdentifier(ticketId), nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -459,33 +760,96 @@ func (j *Jira) GetTicket(ctx context.Context, ticketId string) (*v2.Ticket, anno
 		return nil, nil, errors.New("issue not found")
 	}
 
-	ret, err := j.issueToTicket(ctx, issue)
+	ret, annos, err := j.issueToTicket(ctx, issue)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return ret, nil, nil
+	// Watchers are extra detail, so failing to read them does not fail the ticket.
+	if j.ticketIncludeWatchers {
+		watchers, err := j.issueWatchersAnnotation(ctx, issue.Key)
+		if err != nil {
+			ctxzap.Extract(ctx).Warn("failed to get issue watchers", zap.Error(err), zap.String("issue", issue.Key))
+		} else if watchers != nil {
+			annos.Update(watchers)
+		}
+	}
+
+	return ret, annos, nil
+}
+
+// parseTicketIdentifier normalises a ticket ID to what the get issue endpoint accepts: a numeric
+// issue ID or an issue key are kept as is, and the issue key is taken out of a browse URL such as
+// "https://example.atlassian.net/browse/PROJ-123". Anything else is returned unchanged.
+func parseTicketIdentifier(input string) string {
+	input = strings.TrimSpace(input)
+	if _, err := strconv.ParseInt(input, 10, 64); err == nil || issueKeyPattern.MatchString(input) {
+		return input
+	}
+
+	u, err := url.Parse(input)
+	if err != nil || u.Host == "" {
+		return input
+	}
+
+	if key := u.Query().Get("selectedIssue"); issueKeyPattern.MatchString(key) {
+		return key
+	}
+
+	if dir, key := path.Split(strings.TrimSuffix(u.Path, "/")); path.Base(dir) == "browse" && issueKeyPattern.MatchString(key) {
+		return key
+	}
+
+	return input
+}
+
+// validateSchemaID rejects a malformed schema ID before any request is sent to Jira.
+func validateSchemaID(schemaID string) error {
+	if !schemaIDPattern.MatchString(schemaID) {
+		return status.Errorf(
+			codes.InvalidArgument,
+			"baton-jira: invalid schema id %q, expected 'projectKey:issueTypeID' such as 'PROJ:10001'",
+			schemaID,
+		)
+	}
+	return nil
 }
 
 // This is returning nil for annotations.
 func (j *Jira) CreateTicket(ctx context.Context, ticket *v2.Ticket, schema *v2.TicketSchema) (*v2.Ticket, annotations.Annotations, error) {
+	ticket = normalizeTicketValues(schema, ticket)
+
 	ticketOptions := []FieldOption{
-		WithStatus(ticket.GetStatus().GetId()),
 		WithDescription(ticket.GetDescription()),
 		WithLabels(ticket.GetLabels()...),
 	}
 
 	ticketFields := ticket.GetCustomFields()
 
+	// activeSprint is the sprint of a ticket that did not pick one.
+	var activeSprint *client.Sprint
+
 	var projectKey string
 	var issueTypeID string
 
+	consolidatedAnno := getConsolidatedSchemaAnnotation(schema.Annotations)
 	projectAnno := GetProjectAnnotation(schema.Annotations)
-	if projectAnno == nil {
+	switch {
+	case consolidatedAnno != nil:
+		var err error
+		projectKey, err = consolidatedProjectKey(consolidatedAnno, ticketFields[ticketProjectFieldID])
+		if err != nil {
+			return nil, nil, err
+		}
+		issueTypeID = consolidatedAnno.GetIssueTypeId()
+	case projectAnno == nil:
 		// If no projectAnnotation assume schema id is project
 		// Because the config schema may have not been updated
 		projectKey = schema.Id
-	} else {
+	default:
+		if err := validateSchemaID(schema.Id); err != nil {
+			return nil, nil, err
+		}
 		projectKeyIssueTypeID := &ProjectKeyIssueTypeIDSchemaID{}
 		err := projectKeyIssueTypeID.Parse(schema.Id)
 		if err != nil {
@@ -499,7 +863,7 @@ func (j *Jira) CreateTicket(ctx context.Context, ticket *v2.Ticket, schema *v2.T
 
 	for id, cf := range schema.GetCustomFields() {
 		switch id {
-		case "project":
+		case "project", ticketProjectFieldID:
 			continue
 		case "components":
 			comps, err := sdkTicket.GetPickMultipleObjectValues(ticketFields[id])
@@ -515,6 +879,29 @@ func (j *Jira) CreateTicket(ctx context.Context, ticket *v2.Ticket, schema *v2.T
 				componentIDs = append(componentIDs, component.GetId())
 			}
 			ticketOptions = append(ticketOptions, WithComponents(componentIDs...))
+		case issueLinkTypeFieldID:
+			linkType, err := sdkTicket.GetPickObjectValue(ticketFields[id])
+			if err != nil {
+				if errors.Is(err, sdkTicket.ErrFieldNil) {
+					continue
+				}
+				return nil, nil, err
+			}
+
+			target, err := sdkTicket.GetStringValue(ticketFields[issueLinkTargetFieldID])
+			if err != nil {
+				if errors.Is(err, sdkTicket.ErrFieldNil) {
+					continue
+				}
+				return nil, nil, err
+			}
+
+			if linkType.GetId() != "" && target != "" {
+				ticketOptions = append(ticketOptions, WithIssueLink(linkType.GetId(), target))
+			}
+		case issueLinkTargetFieldID:
+			// Handled together with the issue link type
+			continue
 		case "issue_type":
 			// If issueTypeID is empty, the config has not been updated to use issue type as schema
 			// So issue type is still stored in the custom fields
@@ -526,6 +913,20 @@ func (j *Jira) CreateTicket(ctx context.Context, ticket *v2.Ticket, schema *v2.T
 				issueTypeID = issueType.GetId()
 			}
 		default:
+			if GeCustomFieldTypeAnnotation(cf.GetAnnotations()) == typeSprint {
+				sprint, active, err := j.ticketSprint(ctx, projectKey, ticketFields[id])
+				if err != nil {
+					return nil, nil, err
+				}
+				if sprint != nil {
+					ticketOptions = append(ticketOptions, WithSprint(cf.GetId(), sprint.ID))
+				}
+				if active {
+					activeSprint = sprint
+				}
+				continue
+			}
+
 			metaFieldValue, err := j.customFieldSchemaToMetaField(ticketFields[id])
 			if err != nil {
 				return nil, nil, err
@@ -551,39 +952,49 @@ func (j *Jira) CreateTicket(ctx context.Context, ticket *v2.Ticket, schema *v2.T
 		return nil, nil, err
 	}
 	if !valid {
-		return nil, nil, errors.Join(errors.New("error: unable to create ticket, ticket is invalid"), sdkTicket.ErrTicketValidationError)
+		return nil, nil, ticketValidationError(schema, ticket)
 	}
 
-	iss, err := j.createIssue(ctx, projectKey, ticket.GetDisplayName(), ticketOptions...)
+	iss, droppedFields, err := j.createIssue(ctx, projectKey, ticket.GetDisplayName(), ticketOptions...)
 	if err != nil {
+		// Jira rejecting a field usually means the schema changed since it was cached.
+		if status.Code(err) == codes.InvalidArgument {
+			j.session.invalidateTicketSchema(schema.Id)
+		}
 		return nil, nil, err
 	}
+	if len(droppedFields) > 0 {
+		j.session.invalidateTicketSchema(schema.Id)
+	}
+
+	j.transitionIssue(ctx, iss.ID, ticket.GetStatus().GetId())
 
 	fullIss, _, err := j.client.Issue.Get(ctx, iss.ID, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ret, err := j.issueToTicket(ctx, fullIss)
+	ret, annos, err := j.issueToTicket(ctx, fullIss)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return ret, nil, nil
+	if len(droppedFields) > 0 {
+		annos.Append(&pbjira.JiraDroppedFields{Fields: droppedFields})
+	}
+	if activeSprint != nil {
+		annos.Update(&pbjira.JiraActiveSprint{
+			SprintId:   int64(activeSprint.ID),
+			SprintName: activeSprint.Name,
+			BoardId:    int64(activeSprint.OriginBoardID),
+		})
+	}
+
+	return ret, annos, nil
 }
 
 type FieldOption func(issue *jira.Issue)
 
-func WithStatus(statusId string) FieldOption {
-	return func(issue *jira.Issue) {
-		if statusId != "" {
-			issue.Fields.Status = &jira.Status{
-				ID: statusId,
-			}
-		}
-	}
-}
-
 func WithDescription(description string) FieldOption {
 	return func(issue *jira.Issue) {
 		issue.Fields.Description = description
@@ -616,6 +1027,11 @@ func WithCustomField(id string, value interface{}) FieldOption {
 	}
 }
 
+// WithSprint adds the issue to a sprint, through the site's sprint custom field.
+func WithSprint(fieldID string, sprintID int) FieldOption {
+	return WithCustomField(fieldID, sprintID)
+}
+
 func WithComponents(componentIDs ...string) FieldOption {
 	return func(issue *jira.Issue) {
 		if len(issue.Fields.Components) == 0 {
@@ -627,7 +1043,23 @@ func WithComponents(componentIDs ...string) FieldOption {
 	}
 }
 
-func (j *Jira) createIssue(ctx context.Context, projectKey string, summary string, opts ...FieldOption) (*jira.Issue, error) {
+func WithIssueLink(linkTypeId string, targetIssueKey string) FieldOption {
+	return func(issue *jira.Issue) {
+		issue.Fields.IssueLinks = append(issue.Fields.IssueLinks, &jira.IssueLink{
+			Type: jira.IssueLinkType{
+				ID: linkTypeId,
+			},
+			OutwardIssue: &jira.Issue{
+				Key: targetIssueKey,
+			},
+		})
+	}
+}
+
+// createIssue creates an issue. With ticketDropInvalidFields set, an issue Jira rejects
+// only for some of its custom fields is created again without them, and those fields
+// are returned.
+func (j *Jira) createIssue(ctx context.Context, projectKey string, summary string, opts ...FieldOption) (*jira.Issue, []string, error) {
 	l := ctxzap.Extract(ctx)
 
 	i := &jira.Issue{
@@ -652,14 +1084,145 @@ func (j *Jira) createIssue(ctx context.Context, projectKey string, summary strin
 
 	l.Info("creating issue", zap.Any("issue", i))
 
-	issue, resp, err := j.client.Issue.Create(ctx, i)
+	issue, resp, err := j.createIssueWithRetry(ctx, i)
+	if err == nil || !j.ticketDropInvalidFields {
+		if err != nil {
+			return nil, nil, j.createIssueError(ctx, resp, err)
+		}
+		return issue, nil, nil
+	}
+
+	jerr := jira.NewJiraError(resp, err)
+	dropped := droppableFields(resp, jerr, i.Fields.Unknowns)
+	if len(dropped) == 0 {
+		return nil, nil, j.createIssueError(ctx, resp, err)
+	}
+
+	l.Warn("retrying issue creation without the fields jira rejected", zap.Strings("fields", dropped), zap.Error(jerr))
+	for _, field := range dropped {
+		delete(i.Fields.Unknowns, field)
+	}
+
+	issue, resp, err = j.createIssueWithRetry(ctx, i)
 	if err != nil {
-		jerr := jira.NewJiraError(resp, err)
-		l.Error("error creating issue", zap.Error(jerr))
-		return nil, jerr
+		return nil, nil, j.createIssueError(ctx, resp, err)
+	}
+
+	return issue, dropped, nil
+}
+
+// issueTypeExcluded reports whether the issue type is left out of ticket schemas. Custom
+// subtask-like types are not always flagged as subtasks, so they are excluded by name.
+func (j *Jira) issueTypeExcluded(name string) bool {
+	for _, excluded := range j.excludedIssueTypes {
+		if strings.EqualFold(excluded, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// transitionIssue moves a new issue to statusID, as Jira does not take a status on
+// creation. The issue was created either way, so an issue no transition leads to
+// statusID, or a failed transition, keeps its default status with a warning.
+func (j *Jira) transitionIssue(ctx context.Context, issueID string, statusID string) {
+	if statusID == "" {
+		return
+	}
+
+	l := ctxzap.Extract(ctx).With(zap.String("issue_id", issueID), zap.String("status_id", statusID))
+
+	transitions, resp, err := j.client.Issue.GetTransitions(ctx, issueID)
+	if err != nil {
+		l.Warn("failed to list the transitions of the new issue, keeping its default status", zap.Error(jira.NewJiraError(resp, err)))
+		return
+	}
+
+	for _, transition := range transitions {
+		if transition.To.ID != statusID {
+			continue
+		}
+
+		resp, err := j.client.Issue.DoTransition(ctx, issueID, transition.ID)
+		if err != nil {
+			l.Warn("failed to transition the new issue, keeping its default status", zap.String("transition_id", transition.ID), zap.Error(jira.NewJiraError(resp, err)))
+		}
+		if resp != nil {
+			resp.Body.Close()
+		}
+		return
+	}
+
+	l.Warn("no transition of the new issue leads to the requested status, keeping its default status")
+}
+
+// Conflicting creates are attempted this many times in total.
+const createIssueAttempts = 3
+
+// createIssueBackoff is the wait before the first retry of a conflicting create, doubled
+// before each next one.
+var createIssueBackoff = time.Second
+
+// createIssueWithRetry retries conflicts, which Jira returns when a concurrent change
+// holds a lock the creation needs, so the issue was not created. Other failures, a 503
+// included, may come after the issue was committed and are not retried, as that could
+// create it twice. Each attempt is bounded by the ticket create timeout.
+func (j *Jira) createIssueWithRetry(ctx context.Context, i *jira.Issue) (*jira.Issue, *jira.Response, error) {
+	backoff := createIssueBackoff
+	for attempt := 1; ; attempt++ {
+		createCtx, cancel := withTimeout(ctx, j.timeouts.TicketCreate)
+		issue, resp, err := j.client.Issue.Create(createCtx, i)
+		cancel()
+
+		conflict := resp != nil && resp.StatusCode == http.StatusConflict
+		if err == nil || !conflict || attempt == createIssueAttempts {
+			return issue, resp, err
+		}
+
+		ctxzap.Extract(ctx).Warn("retrying issue creation", zap.Int("status_code", resp.StatusCode), zap.Int("attempt", attempt))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, resp, ctx.Err()
+		case <-timer.C:
+		}
+		backoff *= 2
+	}
+}
+
+func (j *Jira) createIssueError(ctx context.Context, resp *jira.Response, err error) error {
+	jerr := jira.NewJiraError(resp, err)
+	ctxzap.Extract(ctx).Error("error creating issue", zap.Error(jerr))
+	return client.WrapError(jerr, "failed to create issue")
+}
+
+// droppableFields returns the custom fields a 400 from issue creation rejects, if
+// leaving them out may let the creation succeed: every rejected field must be one of
+// the custom fields sent. Errors on the project, the issue type, the summary or
+// anything else fail the creation as is.
+func droppableFields(resp *jira.Response, err error, customFields map[string]interface{}) []string {
+	if resp == nil || resp.StatusCode != http.StatusBadRequest {
+		return nil
+	}
+
+	var jiraErr *jira.Error
+	if !errors.As(err, &jiraErr) || len(jiraErr.Errors) == 0 {
+		return nil
+	}
+
+	rv := make([]string, 0, len(jiraErr.Errors))
+	for field := range jiraErr.Errors {
+		if _, ok := customFields[field]; !ok {
+			return nil
+		}
+		rv = append(rv, field)
 	}
+	sort.Strings(rv)
 
-	return issue, nil
+	return rv
 }
 
 func (j *Jira) generateIssueURL(issueKey string) (string, error) {
@@ -0,0 +1,768 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCreateIssueRetries(t *testing.T) {
+	createIssueBackoff = 0
+
+	created := `{"id":"10000","key":"PROJ-1"}`
+	tests := []struct {
+		name              string
+		dropInvalidFields bool
+		// responses are the status and body of each create, in order.
+		responses    []string
+		statuses     []int
+		wantErr      bool
+		wantAttempts int
+		wantDropped  []string
+		// wantLastFields are the custom fields the last create sent.
+		wantLastFields []string
+	}{
+		{
+			name:           "created at once",
+			statuses:       []int{http.StatusCreated},
+			responses:      []string{created},
+			wantAttempts:   1,
+			wantLastFields: []string{"customfield_1", "customfield_2"},
+		},
+		{
+			name:           "conflict is retried",
+			statuses:       []int{http.StatusConflict, http.StatusCreated},
+			responses:      []string{`{}`, created},
+			wantAttempts:   2,
+			wantLastFields: []string{"customfield_1", "customfield_2"},
+		},
+		{
+			name:         "unavailable is not retried",
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusCreated},
+			responses:    []string{`{}`, created},
+			wantErr:      true,
+			wantAttempts: 1,
+		},
+		{
+			name:         "conflicts give up after the last attempt",
+			statuses:     []int{http.StatusConflict, http.StatusConflict, http.StatusConflict, http.StatusCreated},
+			responses:    []string{`{}`, `{}`, `{}`, created},
+			wantErr:      true,
+			wantAttempts: createIssueAttempts,
+		},
+		{
+			name:              "rejected custom field is dropped",
+			dropInvalidFields: true,
+			statuses:          []int{http.StatusBadRequest, http.StatusCreated},
+			responses:         []string{`{"errors":{"customfield_2":"invalid"}}`, created},
+			wantAttempts:      2,
+			wantDropped:       []string{"customfield_2"},
+			wantLastFields:    []string{"customfield_1"},
+		},
+		{
+			name:              "rejected field without the option fails",
+			dropInvalidFields: false,
+			statuses:          []int{http.StatusBadRequest, http.StatusCreated},
+			responses:         []string{`{"errors":{"customfield_2":"invalid"}}`, created},
+			wantErr:           true,
+			wantAttempts:      1,
+		},
+		{
+			name:              "hard failure is not retried",
+			dropInvalidFields: true,
+			statuses:          []int{http.StatusBadRequest, http.StatusCreated},
+			responses:         []string{`{"errors":{"summary":"required"}}`, created},
+			wantErr:           true,
+			wantAttempts:      1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			attempts := 0
+			var lastFields []string
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				var body struct {
+					Fields map[string]json.RawMessage `json:"fields"`
+				}
+				_ = json.NewDecoder(r.Body).Decode(&body)
+				lastFields = nil
+				for _, id := range []string{"customfield_1", "customfield_2"} {
+					if _, ok := body.Fields[id]; ok {
+						lastFields = append(lastFields, id)
+					}
+				}
+
+				i := attempts
+				attempts++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statuses[i])
+				_, _ = w.Write([]byte(tt.responses[i]))
+			}))
+			j.ticketDropInvalidFields = tt.dropInvalidFields
+
+			issue, dropped, err := j.createIssue(
+				context.Background(),
+				"PROJ",
+				"summary",
+				WithType("10001"),
+				WithCustomField("customfield_1", "a"),
+				WithCustomField("customfield_2", "b"),
+			)
+
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr {
+				return
+			}
+			if issue.Key != "PROJ-1" {
+				t.Errorf("issue key = %q, want PROJ-1", issue.Key)
+			}
+			if !reflect.DeepEqual(dropped, tt.wantDropped) {
+				t.Errorf("dropped = %v, want %v", dropped, tt.wantDropped)
+			}
+			if !reflect.DeepEqual(lastFields, tt.wantLastFields) {
+				t.Errorf("last create sent %v, want %v", lastFields, tt.wantLastFields)
+			}
+		})
+	}
+}
+
+func TestGetTicketWatchers(t *testing.T) {
+	tests := []struct {
+		name            string
+		includeWatchers bool
+		watchersStatus  int
+		wantWatchers    bool
+		wantRequests    int
+	}{
+		{name: "watchers off", includeWatchers: false, watchersStatus: http.StatusOK, wantRequests: 0},
+		{name: "watchers read", includeWatchers: true, watchersStatus: http.StatusOK, wantWatchers: true, wantRequests: 1},
+		{name: "watchers hidden", includeWatchers: true, watchersStatus: http.StatusForbidden, wantRequests: 1},
+		{name: "watchers failing", includeWatchers: true, watchersStatus: http.StatusInternalServerError, wantRequests: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			watcherRequests := 0
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if strings.HasSuffix(r.URL.Path, "/watchers") {
+					watcherRequests++
+					w.WriteHeader(tt.watchersStatus)
+					_, _ = w.Write([]byte(`{"watchCount":1,"watchers":[{"accountId":"u1"}]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"id":"10000","key":"PROJ-1","fields":{"summary":"s","issuetype":{"id":"1","name":"Task"},"status":{"id":"1","name":"To Do"}}}`))
+			}))
+			j.ticketIncludeWatchers = tt.includeWatchers
+
+			ticket, annos, err := j.GetTicket(context.Background(), "PROJ-1")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if ticket.GetId() != "10000" {
+				t.Errorf("ticket id = %q, want 10000", ticket.GetId())
+			}
+			if watcherRequests != tt.wantRequests {
+				t.Errorf("watcher requests = %d, want %d", watcherRequests, tt.wantRequests)
+			}
+			if got := annos.Contains(&pbjira.JiraIssueWatchers{}); got != tt.wantWatchers {
+				t.Errorf("watchers annotation = %v, want %v", got, tt.wantWatchers)
+			}
+		})
+	}
+}
+
+func TestSchemaIssueLinkTypesFailure(t *testing.T) {
+	tests := []struct {
+		name           string
+		linkTypeStatus int
+		wantLinkFields bool
+	}{
+		{name: "link types listed", linkTypeStatus: http.StatusOK, wantLinkFields: true},
+		{name: "issue linking disabled", linkTypeStatus: http.StatusNotFound},
+		{name: "link types failing", linkTypeStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case strings.Contains(r.URL.Path, "/issue/createmeta/"):
+					_, _ = w.Write([]byte(`{"isLast":true,"fields":[{"key":"summary","fieldId":"summary","name":"Summary","required":true,"schema":{"type":"string","system":"summary"}}]}`))
+				case strings.HasSuffix(r.URL.Path, "/issueLinkType"):
+					w.WriteHeader(tt.linkTypeStatus)
+					_, _ = w.Write([]byte(`{"issueLinkTypes":[{"id":"10000","name":"Blocks","inward":"is blocked by","outward":"blocks"}]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+
+			schema, err := j.schemaForProjectIssueType(
+				context.Background(),
+				&jira.Project{ID: "10000", Key: "PRJ", Name: "Project"},
+				&jira.IssueType{ID: "10001", Name: "Task"},
+				nil,
+				false,
+			)
+			if err != nil {
+				t.Fatalf("schemaForProjectIssueType() error = %v", err)
+			}
+
+			_, linkType := schema.GetCustomFields()[issueLinkTypeFieldID]
+			_, linkTarget := schema.GetCustomFields()[issueLinkTargetFieldID]
+			if linkType != tt.wantLinkFields || linkTarget != tt.wantLinkFields {
+				t.Errorf("issue link fields present = %v, %v, want %v", linkType, linkTarget, tt.wantLinkFields)
+			}
+		})
+	}
+}
+
+// TestListTicketSchemasPaging walks three pages of projects contributing different numbers
+// of schemas, and checks every project is listed exactly once.
+func TestListTicketSchemasPaging(t *testing.T) {
+	type testProject struct {
+		id, key    string
+		issueTypes string
+	}
+	projects := []testProject{
+		{id: "10000", key: "A", issueTypes: `[{"id":"1","name":"Task"},{"id":"2","name":"Story"}]`},
+		{id: "10001", key: "B", issueTypes: `[{"id":"3","name":"Epic"},{"id":"4","name":"Bug"}]`},
+		{id: "10002", key: "C", issueTypes: `[{"id":"1","name":"Task"}]`},
+		{id: "10003", key: "D", issueTypes: `[{"id":"1","name":"Task"},{"id":"2","name":"Story"},{"id":"5","name":"Request"}]`},
+		// E's create metadata is gone, so it is skipped rather than failing its page.
+		{id: "10004", key: "E", issueTypes: `[{"id":"1","name":"Task"}]`},
+		{id: "10005", key: "F", issueTypes: `[{"id":"1","name":"Task"},{"id":"4","name":"Bug"}]`},
+	}
+
+	searches := 0
+	j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/3/project/search":
+			searches++
+			startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+			maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+			end := min(startAt+maxResults, len(projects))
+
+			var values []string
+			for _, p := range projects[startAt:end] {
+				values = append(values, fmt.Sprintf(`{"id":%q,"key":%q,"name":%q,"issueTypes":%s}`, p.id, p.key, p.key, p.issueTypes))
+			}
+			_, _ = fmt.Fprintf(w, `{"isLast":%t,"startAt":%d,"maxResults":%d,"total":%d,"values":[%s]}`,
+				end == len(projects), startAt, maxResults, len(projects), strings.Join(values, ","))
+		case r.URL.Path == "/rest/api/3/statuses/search":
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"1","name":"Done"}]}`))
+		case strings.Contains(r.URL.Path, "/issue/createmeta/10004/"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorMessages":["No project could be found"]}`))
+		case strings.Contains(r.URL.Path, "/issue/createmeta/"):
+			_, _ = w.Write([]byte(`{"isLast":true,"fields":[]}`))
+		case strings.HasSuffix(r.URL.Path, "/issueLinkType"):
+			_, _ = w.Write([]byte(`{"issueLinkTypes":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	var schemaIDs []string
+	pageToken := ""
+	for pages := 0; ; pages++ {
+		if pages > len(projects) {
+			t.Fatalf("ListTicketSchemas did not finish after %d pages", pages)
+		}
+
+		schemas, next, _, err := j.ListTicketSchemas(context.Background(), &pagination.Token{Token: pageToken, Size: 2})
+		if err != nil {
+			t.Fatalf("ListTicketSchemas(%q): %v", pageToken, err)
+		}
+		for _, schema := range schemas {
+			schemaIDs = append(schemaIDs, schema.GetId())
+		}
+
+		if next == "" {
+			break
+		}
+		pageToken = next
+	}
+
+	want := []string{"A:1", "A:2", "C:1", "D:1", "D:2", "D:5", "F:1"}
+	sort.Strings(schemaIDs)
+	if !slices.Equal(schemaIDs, want) {
+		t.Errorf("schema IDs = %v, want %v", schemaIDs, want)
+	}
+	if searches != 3 {
+		t.Errorf("project searches = %d, want 3", searches)
+	}
+}
+
+func TestCreateIssueFieldErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		wantViolations []string
+		wantMessage    string
+	}{
+		{
+			name:           "field errors",
+			body:           `{"errors":{"summary":"required","assignee":"User cannot be assigned"}}`,
+			wantViolations: []string{"assignee: User cannot be assigned", "summary: required"},
+			wantMessage:    "jira rejected the request: assignee: User cannot be assigned; summary: required",
+		},
+		{
+			name:           "error messages",
+			body:           `{"errorMessages":["Issue type is not valid"]}`,
+			wantViolations: []string{": Issue type is not valid"},
+			wantMessage:    "jira rejected the request: Issue type is not valid",
+		},
+		{
+			name:           "error messages and field errors",
+			body:           `{"errorMessages":["Issue type is not valid"],"errors":{"priority":"invalid"}}`,
+			wantViolations: []string{": Issue type is not valid", "priority: invalid"},
+			wantMessage:    "jira rejected the request: Issue type is not valid; priority: invalid",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+
+			_, _, err := j.createIssue(context.Background(), "PROJ", "summary", WithType("10001"))
+			if err == nil {
+				t.Fatal("createIssue() succeeded, want an error")
+			}
+
+			st, ok := status.FromError(err)
+			if !ok {
+				t.Fatalf("error %v carries no status", err)
+			}
+			if st.Code() != codes.InvalidArgument {
+				t.Errorf("code = %v, want %v", st.Code(), codes.InvalidArgument)
+			}
+			if !strings.HasSuffix(st.Message(), tt.wantMessage) {
+				t.Errorf("message = %q, want it to end with %q", st.Message(), tt.wantMessage)
+			}
+
+			var violations []string
+			for _, detail := range st.Details() {
+				badRequest, ok := detail.(*errdetails.BadRequest)
+				if !ok {
+					t.Fatalf("unexpected detail %T", detail)
+				}
+				for _, violation := range badRequest.GetFieldViolations() {
+					violations = append(violations, violation.GetField()+": "+violation.GetDescription())
+				}
+			}
+			if !reflect.DeepEqual(violations, tt.wantViolations) {
+				t.Errorf("violations = %v, want %v", violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+// ticketSchemaHandler serves project SW with the Task issue type 10001, its statuses and
+// its createmeta, and counts the requests of each.
+func ticketSchemaHandler(t *testing.T, mu *sync.Mutex, requests map[string]int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/2/project/SW":
+			requests["project"]++
+			_, _ = w.Write([]byte(`{"id":"10000","key":"SW","name":"Software","issueTypes":[{"id":"10001","name":"Task"}]}`))
+		case r.URL.Path == "/rest/api/3/statuses/search":
+			requests["statuses"]++
+			_, _ = w.Write([]byte(`{"isLast":true,"values":[{"id":"1","name":"To Do"},{"id":"2","name":"Done"}]}`))
+		case r.URL.Path == "/rest/api/3/issue/createmeta/10000/issuetypes/10001":
+			requests["createmeta"]++
+			_, _ = w.Write([]byte(`{"isLast":true,"fields":[{"fieldId":"summary","name":"Summary","required":true,"schema":{"type":"string","system":"summary"}}]}`))
+		case strings.HasSuffix(r.URL.Path, "/issueLinkType"):
+			_, _ = w.Write([]byte(`{"issueLinkTypes":[]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+}
+
+// TestGetTicketSchemaCache requests a schema from many goroutines at once, and checks
+// only the first request walks the project, statuses and createmeta while the cache is on.
+func TestGetTicketSchemaCache(t *testing.T) {
+	const callers = 20
+
+	tests := []struct {
+		name            string
+		ttl             time.Duration
+		wantCreateMetas int
+		wantStatuses    int
+	}{
+		{name: "cached", ttl: 10 * time.Minute, wantCreateMetas: 1, wantStatuses: 1},
+		{name: "cache disabled", ttl: 0, wantCreateMetas: callers + 1, wantStatuses: callers + 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := make(map[string]int)
+			j := newTestJira(t, ticketSchemaHandler(t, &mu, requests))
+			j.session.ticketSchemaTTL = tt.ttl
+
+			first, _, err := j.GetTicketSchema(context.Background(), "SW:10001")
+			if err != nil {
+				t.Fatalf("GetTicketSchema: %v", err)
+			}
+			// Callers get copies, so changing one leaves the cache as it was.
+			first.DisplayName = "changed"
+
+			var wg sync.WaitGroup
+			schemas := make([]*v2.TicketSchema, callers)
+			errs := make([]error, callers)
+			for i := 0; i < callers; i++ {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					schemas[i], _, errs[i] = j.GetTicketSchema(context.Background(), "SW:10001")
+				}(i)
+			}
+			wg.Wait()
+
+			for i := range schemas {
+				if errs[i] != nil {
+					t.Fatalf("GetTicketSchema: %v", errs[i])
+				}
+				if schemas[i].GetId() != "SW:10001" || schemas[i].GetDisplayName() == "changed" {
+					t.Errorf("schema = %s %q, want SW:10001 as fetched", schemas[i].GetId(), schemas[i].GetDisplayName())
+				}
+				if len(schemas[i].GetStatuses()) != 2 {
+					t.Errorf("schema has %d statuses, want 2", len(schemas[i].GetStatuses()))
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if requests["createmeta"] != tt.wantCreateMetas {
+				t.Errorf("createmeta requested %d times, want %d", requests["createmeta"], tt.wantCreateMetas)
+			}
+			if requests["statuses"] != tt.wantStatuses {
+				t.Errorf("statuses requested %d times, want %d", requests["statuses"], tt.wantStatuses)
+			}
+		})
+	}
+}
+
+// TestCreateTicketInvalidatesSchema checks a field validation failure of a ticket create
+// evicts its cached schema, as the schema likely changed, and other failures keep it.
+func TestCreateTicketInvalidatesSchema(t *testing.T) {
+	tests := []struct {
+		name            string
+		createStatus    int
+		createBody      string
+		wantCreateMetas int
+	}{
+		{name: "field error", createStatus: http.StatusBadRequest, createBody: `{"errors":{"priority":"invalid"}}`, wantCreateMetas: 2},
+		{name: "forbidden", createStatus: http.StatusForbidden, createBody: `{"errorMessages":["forbidden"]}`, wantCreateMetas: 1},
+		{name: "server error", createStatus: http.StatusInternalServerError, createBody: `{}`, wantCreateMetas: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := make(map[string]int)
+			schemaHandler := ticketSchemaHandler(t, &mu, requests)
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost && r.URL.Path == "/rest/api/2/issue" {
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(tt.createStatus)
+					_, _ = w.Write([]byte(tt.createBody))
+					return
+				}
+				schemaHandler.ServeHTTP(w, r)
+			}))
+			j.session.ticketSchemaTTL = 10 * time.Minute
+
+			ctx := context.Background()
+			schema, _, err := j.GetTicketSchema(ctx, "SW:10001")
+			if err != nil {
+				t.Fatalf("GetTicketSchema: %v", err)
+			}
+
+			_, _, err = j.CreateTicket(ctx, &v2.Ticket{DisplayName: "Access request"}, schema)
+			if err == nil {
+				t.Fatal("CreateTicket succeeded, want an error")
+			}
+
+			_, _, err = j.GetTicketSchema(ctx, "SW:10001")
+			if err != nil {
+				t.Fatalf("GetTicketSchema: %v", err)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if requests["createmeta"] != tt.wantCreateMetas {
+				t.Errorf("createmeta requested %d times, want %d", requests["createmeta"], tt.wantCreateMetas)
+			}
+		})
+	}
+}
+
+// TestCreateTicketErrorCodes creates a batch of tickets that Jira rejects for good or for
+// now, and checks the gRPC code of each failure tells the platform whether to retry it.
+func TestCreateTicketErrorCodes(t *testing.T) {
+	tests := []struct {
+		summary      string
+		createStatus int
+		createBody   string
+		wantCode     codes.Code
+		wantRetry    bool
+	}{
+		{summary: "rejected field", createStatus: http.StatusBadRequest, createBody: `{"errors":{"priority":"invalid"}}`, wantCode: codes.InvalidArgument},
+		{summary: "forbidden", createStatus: http.StatusForbidden, createBody: `{"errorMessages":["forbidden"]}`, wantCode: codes.PermissionDenied},
+		{summary: "throttled", createStatus: http.StatusTooManyRequests, createBody: `{}`, wantCode: codes.Unavailable, wantRetry: true},
+		{summary: "unavailable", createStatus: http.StatusServiceUnavailable, createBody: `{}`, wantCode: codes.Unavailable, wantRetry: true},
+	}
+
+	var mu sync.Mutex
+	requests := make(map[string]int)
+	schemaHandler := ticketSchemaHandler(t, &mu, requests)
+	j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue" {
+			schemaHandler.ServeHTTP(w, r)
+			return
+		}
+
+		var body struct {
+			Fields struct {
+				Summary string `json:"summary"`
+			} `json:"fields"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		for _, tt := range tests {
+			if tt.summary == body.Fields.Summary {
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.createStatus)
+				_, _ = w.Write([]byte(tt.createBody))
+				return
+			}
+		}
+		t.Errorf("unexpected ticket %q", body.Fields.Summary)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+
+	schema, _, err := j.GetTicketSchema(context.Background(), "SW:10001")
+	if err != nil {
+		t.Fatalf("GetTicketSchema: %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.summary, func(t *testing.T) {
+			_, _, err := j.CreateTicket(context.Background(), &v2.Ticket{DisplayName: tt.summary}, schema)
+			if err == nil {
+				t.Fatal("CreateTicket succeeded, want an error")
+			}
+
+			code := status.Code(err)
+			if code != tt.wantCode {
+				t.Errorf("code = %v, want %v", code, tt.wantCode)
+			}
+			if retry := code == codes.Unavailable || code == codes.DeadlineExceeded; retry != tt.wantRetry {
+				t.Errorf("retryable = %v, want %v", retry, tt.wantRetry)
+			}
+		})
+	}
+}
+
+// TestPagingStopsOnCancel serves endless pages and cancels the sync while the third one is
+// requested, checking the loop stops with at most one request after the cancel.
+func TestPagingStopsOnCancel(t *testing.T) {
+	const cancelAt = 3
+
+	tests := []struct {
+		name string
+		path string
+		page string
+		list func(ctx context.Context, j *Jira) error
+	}{
+		{
+			name: "project statuses",
+			path: "/rest/api/3/statuses/search",
+			page: `{"isLast":false,"values":[{"id":"1","name":"Done"}]}`,
+			list: func(ctx context.Context, j *Jira) error {
+				_, err := j.getJiraStatusesForProject(ctx, "10000")
+				return err
+			},
+		},
+		{
+			name: "issue type fields",
+			path: "/rest/api/3/issue/createmeta/10000/issuetypes/10001",
+			page: `{"isLast":false,"fields":[{"fieldId":"summary","name":"Summary","schema":{"type":"string"}}]}`,
+			list: func(ctx context.Context, j *Jira) error {
+				_, _, err := j.GetIssueTypeFields(ctx, "10000", "10001", &jira.GetQueryIssueTypeOptions{MaxResults: 1})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var mu sync.Mutex
+			requests := 0
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != tt.path {
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				mu.Lock()
+				requests++
+				if requests == cancelAt {
+					cancel()
+				}
+				mu.Unlock()
+
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(tt.page))
+			}))
+
+			err := tt.list(ctx, j)
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("err = %v, want %v", err, context.Canceled)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if requests < cancelAt || requests > cancelAt+1 {
+				t.Errorf("requests = %d, want %d or %d", requests, cancelAt, cancelAt+1)
+			}
+		})
+	}
+}
+
+// TestSchemaPagingFromEnvelopes serves 5 statuses and 5 issue type fields, at most 2 at
+// once, and checks both are paged to the end from what each envelope reports.
+func TestSchemaPagingFromEnvelopes(t *testing.T) {
+	const total, clamp = 5, 2
+
+	tests := []struct {
+		name string
+		// envelope returns the paging fields of the page starting at startAt and ending at end.
+		envelope func(startAt, end int) string
+	}{
+		{
+			name: "is last",
+			envelope: func(startAt, end int) string {
+				return fmt.Sprintf(`"startAt":%d,"maxResults":%d,"isLast":%t`, startAt, clamp, end >= total)
+			},
+		},
+		{
+			name: "total",
+			envelope: func(startAt, _ int) string {
+				return fmt.Sprintf(`"startAt":%d,"maxResults":%d,"total":%d`, startAt, clamp, total)
+			},
+		},
+		{
+			name: "next page link",
+			envelope: func(startAt, end int) string {
+				if end >= total {
+					return fmt.Sprintf(`"startAt":%d,"maxResults":%d`, startAt, clamp)
+				}
+				return fmt.Sprintf(`"startAt":%d,"maxResults":%d,"nextPage":"https://example.atlassian.net/next?startAt=%d"`, startAt, clamp, end)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			startAts := map[string][]int{}
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+				end := min(startAt+clamp, total)
+
+				mu.Lock()
+				startAts[r.URL.Path] = append(startAts[r.URL.Path], startAt)
+				if len(startAts[r.URL.Path]) > total {
+					mu.Unlock()
+					t.Errorf("paging %s did not end", r.URL.Path)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				mu.Unlock()
+
+				var values []string
+				for i := startAt; i < end; i++ {
+					values = append(values, fmt.Sprintf(`{"id":"%d","name":"Status %d","fieldId":"customfield_%d","key":"customfield_%d","schema":{"type":"string"}}`, i, i, i, i))
+				}
+
+				w.Header().Set("Content-Type", "application/json")
+				switch r.URL.Path {
+				case "/rest/api/3/statuses/search":
+					_, _ = fmt.Fprintf(w, `{%s,"values":[%s]}`, tt.envelope(startAt, end), strings.Join(values, ","))
+				case "/rest/api/3/issue/createmeta/10000/issuetypes/10001":
+					_, _ = fmt.Fprintf(w, `{%s,"fields":[%s]}`, tt.envelope(startAt, end), strings.Join(values, ","))
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			ctx := context.Background()
+
+			statuses, err := j.getJiraStatusesForProject(ctx, "10000")
+			if err != nil {
+				t.Fatalf("getJiraStatusesForProject: %v", err)
+			}
+			fields, _, err := j.GetIssueTypeFields(ctx, "10000", "10001", nil)
+			if err != nil {
+				t.Fatalf("GetIssueTypeFields: %v", err)
+			}
+			if len(statuses) != total || len(fields) != total {
+				t.Errorf("listed %d statuses and %d fields, want %d of each", len(statuses), len(fields), total)
+			}
+
+			want := []int{0, 2, 4}
+			for _, path := range []string{"/rest/api/3/statuses/search", "/rest/api/3/issue/createmeta/10000/issuetypes/10001"} {
+				if !slices.Equal(startAts[path], want) {
+					t.Errorf("%s requested from %v, want %v", path, startAts[path], want)
+				}
+			}
+		})
+	}
+}
@@ -2,15 +2,34 @@ package connector
 
 import (
 	"context"
+	"encoding/json"
+	"slices"
 	"strings"
+	"time"
 
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
 	jira "github.com/conductorone/go-jira/v2/cloud"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
+// Products given to created accounts when the account info does not list any.
+var defaultProducts = []string{"jira-software"}
+
+// knownProducts are the product slugs Jira Cloud accepts when creating a user.
+var knownProducts = []string{"jira-core", "jira-software", "jira-servicedesk", "jira-product-discovery"}
+
 var (
 	// TODO: check if this is the correct way to define the resource type
 	resourceTypeUser = &v2.ResourceType{
@@ -27,6 +46,22 @@ type (
 	userResourceType struct {
 		resourceType *v2.ResourceType
 		client       *jira.Client
+		apiClient    *client.Client
+
+		// sendInvitation asks Data Center to email the invitation to created users.
+		sendInvitation bool
+		// syncUserProperties fetches the entity properties of every user, at least one request per user.
+		syncUserProperties bool
+
+		// atlassianClient is nil unless the org admin API is configured.
+		atlassianClient *atlassianclient.AtlassianClient
+		session         *sessionStore
+
+		// claimStatusFilter skips users whose org directory claim status is not listed. Nil syncs every user.
+		claimStatusFilter *claimStatusFilter
+
+		// listTimeout bounds each page of users. Zero disables it.
+		listTimeout time.Duration
 	}
 )
 
@@ -37,17 +72,90 @@ func getResourceTypeAnnotation() annotations.Annotations {
 	return annotations
 }
 
-func userResource(ctx context.Context, user *jira.User) (*v2.Resource, error) {
+// userResourceOption adds data from outside the Jira user, e.g. the org directory, to the resource.
+type userResourceOption func(profile map[string]interface{}) []proto.Message
+
+func withLastActive(lastActive *atlassianclient.UserLastActive) userResourceOption {
+	return func(profile map[string]interface{}) []proto.Message {
+		if lastActive == nil {
+			return nil
+		}
+
+		if v := lastActive.LastActive(); v != "" {
+			profile["last_active"] = v
+		}
+		if lastActive.AddedToOrg != "" {
+			profile["added_to_org"] = lastActive.AddedToOrg
+		}
+
+		return nil
+	}
+}
+
+// withOrgUser adds the org directory claim status, so managed accounts can be told apart.
+func withOrgUser(orgUser *atlassianclient.User) userResourceOption {
+	return func(profile map[string]interface{}) []proto.Message {
+		if orgUser == nil || orgUser.ClaimStatus == "" {
+			return nil
+		}
+
+		profile["claim_status"] = orgUser.ClaimStatus
+		profile["managed"] = orgUser.Managed()
+
+		return nil
+	}
+}
+
+// withInvitationPending marks an account created by CreateAccount, which stays inactive
+// until its invitation email is accepted. Synced inactive users may as well be deactivated.
+func withInvitationPending() userResourceOption {
+	return func(profile map[string]interface{}) []proto.Message {
+		profile["invitation_pending"] = true
+		return nil
+	}
+}
+
+// withUserProperties attaches the user's entity properties as a JiraUserProperties annotation.
+func withUserProperties(properties map[string]interface{}) userResourceOption {
+	return func(_ map[string]interface{}) []proto.Message {
+		if len(properties) == 0 {
+			return nil
+		}
+
+		encoded := make(map[string]string, len(properties))
+		for key, value := range properties {
+			data, err := json.Marshal(value)
+			if err != nil {
+				continue
+			}
+			encoded[key] = string(data)
+		}
+
+		return []proto.Message{
+			&pbjira.JiraUserProperties{
+				Properties: encoded,
+			},
+		}
+	}
+}
+
+func userResource(ctx context.Context, user *jira.User, opts ...userResourceOption) (*v2.Resource, error) {
 	names := strings.Split(user.DisplayName, " ")
 	profile := map[string]interface{}{
 		"login":      user.EmailAddress,
 		"first_name": names[0],
 		"user_id":    user.AccountID,
+		"username":   user.Name,
 	}
 	if len(names) > 1 {
 		profile["last_name"] = names[1]
 	}
 
+	var annos []proto.Message
+	for _, opt := range opts {
+		annos = append(annos, opt(profile)...)
+	}
+
 	var userStatus v2.UserTrait_Status_Status
 	if user.Active {
 		userStatus = v2.UserTrait_Status_STATUS_ENABLED
@@ -65,7 +173,13 @@ func userResource(ctx context.Context, user *jira.User) (*v2.Resource, error) {
 		userTraitOptions = append(userTraitOptions, rs.WithEmail(user.EmailAddress, true))
 	}
 
-	resource, err := rs.NewUserResource(user.DisplayName, resourceTypeUser, user.AccountID, userTraitOptions)
+	// Data Center users have no account ID, their key is the stable identifier there.
+	userID := user.AccountID
+	if userID == "" {
+		userID = user.Key
+	}
+
+	resource, err := rs.NewUserResource(user.DisplayName, resourceTypeUser, userID, userTraitOptions, rs.WithAnnotation(annos...))
 	if err != nil {
 		return nil, err
 	}
@@ -88,13 +202,91 @@ func (u *userResourceType) ResourceType(_ context.Context) *v2.ResourceType {
 	return u.resourceType
 }
 
-func userBuilder(client *jira.Client) *userResourceType {
+func userBuilder(
+	jiraClient *jira.Client,
+	apiClient *client.Client,
+	sendInvitation bool,
+	syncUserProperties bool,
+	atlassianClient *atlassianclient.AtlassianClient,
+	session *sessionStore,
+	claimStatusFilter *claimStatusFilter,
+	listTimeout time.Duration,
+) *userResourceType {
 	return &userResourceType{
-		resourceType: resourceTypeUser,
-		client:       client,
+		resourceType:       resourceTypeUser,
+		client:             jiraClient,
+		apiClient:          apiClient,
+		sendInvitation:     sendInvitation,
+		syncUserProperties: syncUserProperties,
+		atlassianClient:    atlassianClient,
+		session:            session,
+		claimStatusFilter:  claimStatusFilter,
+		listTimeout:        listTimeout,
 	}
 }
 
+// claimStatusFilter skips users whose org directory claim status is not listed. It is
+// applied to the grants to users as well as to the user listing, so that no grant
+// references a skipped user. A nil filter keeps every user.
+type claimStatusFilter struct {
+	statuses        []string
+	atlassianClient *atlassianclient.AtlassianClient
+	session         *sessionStore
+}
+
+// newClaimStatusFilter returns nil when there is nothing to filter. Claim statuses are only
+// known through the org admin API.
+func newClaimStatusFilter(statuses []string, atlassianClient *atlassianclient.AtlassianClient, session *sessionStore) *claimStatusFilter {
+	if len(statuses) == 0 || atlassianClient == nil {
+		return nil
+	}
+
+	return &claimStatusFilter{
+		statuses:        statuses,
+		atlassianClient: atlassianClient,
+		session:         session,
+	}
+}
+
+// allowed reports whether a user with the claim status passes the filter. Users outside the
+// org directory have no claim status, and only pass a nil filter.
+func (f *claimStatusFilter) allowed(claimStatus string) bool {
+	if f == nil {
+		return true
+	}
+
+	for _, allowed := range f.statuses {
+		if strings.EqualFold(allowed, claimStatus) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterGrants drops the grants to users that do not pass the filter.
+func (f *claimStatusFilter) filterGrants(ctx context.Context, grants []*v2.Grant) ([]*v2.Grant, error) {
+	if f == nil {
+		return grants, nil
+	}
+
+	orgUsers, err := f.session.getOrgUsers(ctx, f.atlassianClient)
+	if err != nil {
+		return nil, client.WrapError(err, "failed to list org directory users")
+	}
+
+	rv := make([]*v2.Grant, 0, len(grants))
+	for _, g := range grants {
+		principal := g.GetPrincipal().GetId()
+		if principal.GetResourceType() == resourceTypeUser.Id && !f.allowed(orgUsers[principal.GetResource()].ClaimStatus) {
+			continue
+		}
+		rv = append(rv, g)
+	}
+
+	return rv, nil
+}
+
 func (u *userResourceType) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
 	return nil, "", nil, nil
 }
@@ -109,21 +301,73 @@ func (u *userResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagina
 		return nil, "", nil, err
 	}
 
-	users, _, err := u.client.User.Find(ctx, "", jira.WithMaxResults(resourcePageSize), jira.WithStartAt(int(offset)))
+	listCtx, cancel := withTimeout(ctx, u.listTimeout)
+	users, err := u.apiClient.FindUsers(listCtx, int(offset), resourcePageSize)
+	cancel()
 	if err != nil {
-		return nil, "", nil, wrapError(err, "failed to list users")
+		return nil, "", nil, client.WrapError(err, "failed to list users")
+	}
+
+	var lastActive map[string]*atlassianclient.UserLastActive
+	var orgUsers map[string]atlassianclient.User
+	var source *syncSource
+	if u.atlassianClient != nil {
+		var sourceCtx context.Context
+		sourceCtx, source = withSyncSource(ctx)
+		orgUsers, err = u.session.getOrgUsers(sourceCtx, u.atlassianClient)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, "failed to list org directory users")
+		}
+
+		accountIDs := make([]string, 0, len(users))
+		for _, user := range users {
+			if u.claimStatusFilter.allowed(orgUsers[user.AccountID].ClaimStatus) {
+				accountIDs = append(accountIDs, user.AccountID)
+			}
+		}
+
+		lastActive, err = u.session.getUsersLastActive(sourceCtx, u.atlassianClient, accountIDs)
+		if err != nil {
+			return nil, "", nil, client.WrapError(err, "failed to get last active dates")
+		}
 	}
 
 	var resources []*v2.Resource
 	for i := range users {
-		resource, err := userResource(ctx, &users[i])
+		if !u.claimStatusFilter.allowed(orgUsers[users[i].AccountID].ClaimStatus) {
+			continue
+		}
+
+		opts := []userResourceOption{
+			withLastActive(lastActive[users[i].AccountID]),
+		}
+		if orgUser, ok := orgUsers[users[i].AccountID]; ok {
+			opts = append(opts, withOrgUser(&orgUser))
+		}
 
+		if u.syncUserProperties {
+			userID := users[i].AccountID
+			if userID == "" {
+				userID = users[i].Key
+			}
+
+			properties, err := u.apiClient.GetUserProperties(ctx, userID)
+			if err != nil {
+				return nil, "", nil, client.WrapError(err, "failed to get user properties")
+			}
+			opts = append(opts, withUserProperties(properties))
+		}
+
+		resource, err := userResource(ctx, &users[i], opts...)
 		if err != nil {
 			return nil, "", nil, err
 		}
 
 		resources = append(resources, resource)
 	}
+	if source != nil {
+		source.annotate(resources)
+	}
 
 	if isLastPage(len(users), resourcePageSize) {
 		return resources, "", nil, nil
@@ -136,3 +380,187 @@ func (u *userResourceType) List(ctx context.Context, _ *v2.ResourceId, p *pagina
 
 	return resources, nextPage, nil, nil
 }
+
+func getCreateInvitationBody(accountInfo *v2.AccountInfo) (*client.CreateUserBody, error) {
+	email := accountInfo.GetLogin()
+	for _, e := range accountInfo.GetEmails() {
+		if email == "" || e.GetIsPrimary() {
+			email = e.GetAddress()
+		}
+	}
+
+	if email == "" {
+		return nil, status.Error(codes.InvalidArgument, "baton-jira: an email address is required to create an account")
+	}
+
+	products, err := profileProducts(accountInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(products) == 0 {
+		products = defaultProducts
+	}
+
+	return &client.CreateUserBody{
+		EmailAddress: email,
+		Products:     products,
+		DisplayName:  accountInfo.GetProfile().GetFields()["display_name"].GetStringValue(),
+	}, nil
+}
+
+// profileProducts returns the validated product slugs of the account profile. Products are
+// either a list of slugs, a comma separated string of them, or a map from slug to a bool
+// saying whether the product is wanted. Slugs are trimmed and lowercased.
+func profileProducts(accountInfo *v2.AccountInfo) ([]string, error) {
+	v, ok := accountInfo.GetProfile().GetFields()["products"]
+	if !ok {
+		return nil, nil
+	}
+
+	var requested []string
+	switch value := v.GetKind().(type) {
+	case *structpb.Value_ListValue:
+		for _, product := range value.ListValue.GetValues() {
+			requested = append(requested, product.GetStringValue())
+		}
+	case *structpb.Value_StringValue:
+		requested = strings.Split(value.StringValue, ",")
+	case *structpb.Value_StructValue:
+		for product, wanted := range value.StructValue.GetFields() {
+			if wanted.GetBoolValue() {
+				requested = append(requested, product)
+			}
+		}
+		// Map iteration order is random, and the request body should not be.
+		slices.Sort(requested)
+	}
+
+	var rv []string
+	for _, product := range requested {
+		product = strings.ToLower(strings.TrimSpace(product))
+		if product == "" || slices.Contains(rv, product) {
+			continue
+		}
+
+		if !slices.Contains(knownProducts, product) {
+			return nil, status.Errorf(
+				codes.InvalidArgument,
+				"baton-jira: unknown product %q, expected one of %s",
+				product,
+				strings.Join(knownProducts, ", "),
+			)
+		}
+
+		rv = append(rv, product)
+	}
+
+	return rv, nil
+}
+
+// profileStrings returns the non-empty strings of a list field of the account profile.
+func profileStrings(accountInfo *v2.AccountInfo, field string) []string {
+	var rv []string
+	if v, ok := accountInfo.GetProfile().GetFields()[field]; ok {
+		for _, value := range v.GetListValue().GetValues() {
+			if value.GetStringValue() != "" {
+				rv = append(rv, value.GetStringValue())
+			}
+		}
+	}
+
+	return rv
+}
+
+// addToGroup adds a user to the group with this name. Groups are looked up by name, as
+// Cloud only adds members to a group by its ID.
+func (u *userResourceType) addToGroup(ctx context.Context, accountID string, group string) error {
+	groupID, err := u.session.getGroupIDByName(ctx, u.apiClient, group)
+	if err != nil {
+		return err
+	}
+	if groupID == "" {
+		return status.Errorf(codes.NotFound, "baton-jira: group %q not found", group)
+	}
+
+	return u.apiClient.AddGroupMember(ctx, groupID, accountID)
+}
+
+// addToGroups adds a created user to the groups named in the account profile. The account
+// exists already, so failures are reported as annotations instead of failing the creation.
+func (u *userResourceType) addToGroups(ctx context.Context, accountID string, groups []string) annotations.Annotations {
+	l := ctxzap.Extract(ctx)
+
+	var annos annotations.Annotations
+	for _, group := range groups {
+		err := u.addToGroup(ctx, accountID, group)
+		if err != nil {
+			l.Warn("failed to add created user to group", zap.Error(err), zap.String("account_id", accountID), zap.String("group", group))
+			annos.Append(&pbjira.JiraGroupAssignmentFailed{
+				AccountId: accountID,
+				Group:     group,
+				Error:     err.Error(),
+			})
+		}
+	}
+
+	return annos
+}
+
+func (u *userResourceType) CreateAccount(
+	ctx context.Context,
+	accountInfo *v2.AccountInfo,
+	credentialOptions *v2.CredentialOptions,
+) (connectorbuilder.CreateAccountResponse, []*v2.PlaintextData, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+
+	body, err := getCreateInvitationBody(accountInfo)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	body.Notification = u.sendInvitation
+
+	user, err := u.apiClient.CreateUser(ctx, body)
+	if err != nil {
+		return nil, nil, nil, client.WrapError(err, "failed to create user")
+	}
+
+	// The create response can be a minimal user without an account type or active flag,
+	// so the full user is looked up. If that fails, the minimal user is still returned.
+	if user.AccountType == "" {
+		fullUser, err := u.session.getUser(ctx, u.apiClient, user.AccountID)
+		if err != nil {
+			l.Warn("failed to get created user", zap.Error(err), zap.String("account_id", user.AccountID))
+			user.AccountType = "atlassian"
+		} else {
+			user = fullUser
+		}
+	}
+
+	if user.EmailAddress == "" {
+		user.EmailAddress = body.EmailAddress
+	}
+
+	var opts []userResourceOption
+	if !user.Active {
+		opts = append(opts, withInvitationPending())
+	}
+
+	resource, err := userResource(ctx, user, opts...)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	annos := u.addToGroups(ctx, user.AccountID, profileStrings(accountInfo, "groups"))
+	if !user.Active {
+		annos.Update(&pbjira.JiraInvitationSent{
+			AccountId: user.AccountID,
+			Email:     body.EmailAddress,
+		})
+	}
+
+	return &v2.CreateAccountResponse_SuccessResult{
+		Resource: resource,
+	}, nil, annos, nil
+}
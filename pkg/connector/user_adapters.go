@@ -0,0 +1,78 @@
+package connector
+
+import (
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+// The adapters below turn the partial users Jira embeds in other responses into a
+// jira.User for userResource. Every field the source carries is mapped, so a field
+// userResource starts using only has to be added here.
+
+// userFromAccountID is a user known only by its account ID, e.g. a permission holder.
+func userFromAccountID(accountID string) *jira.User {
+	return &jira.User{
+		AccountID: accountID,
+	}
+}
+
+func userFromGroupMember(member *jira.GroupMember) *jira.User {
+	return &jira.User{
+		Self:         member.Self,
+		Name:         member.Name,
+		Key:          member.Key,
+		AccountID:    member.AccountID,
+		EmailAddress: member.EmailAddress,
+		DisplayName:  member.DisplayName,
+		Active:       member.Active,
+		TimeZone:     member.TimeZone,
+		AccountType:  member.AccountType,
+	}
+}
+
+// userFromActor maps a role actor. Callers check that it is a user actor first.
+func userFromActor(actor *jira.Actor) *jira.User {
+	return &jira.User{
+		Name:        actor.Name,
+		AccountID:   actor.ActorUser.AccountID,
+		DisplayName: actor.DisplayName,
+	}
+}
+
+func userFromLead(lead *jira.User) *jira.User {
+	return &jira.User{
+		Self:         lead.Self,
+		Name:         lead.Name,
+		Key:          lead.Key,
+		AccountID:    lead.AccountID,
+		EmailAddress: lead.EmailAddress,
+		DisplayName:  lead.DisplayName,
+		Active:       lead.Active,
+		TimeZone:     lead.TimeZone,
+		AccountType:  lead.AccountType,
+	}
+}
+
+// userFromCustomer maps a Jira Service Management customer, whose account type is always customer.
+func userFromCustomer(customer *client.Customer) *jira.User {
+	return &jira.User{
+		AccountID:    customer.AccountID,
+		EmailAddress: customer.EmailAddress,
+		DisplayName:  customer.DisplayName,
+		Active:       customer.Active,
+		TimeZone:     customer.TimeZone,
+		AccountType:  "customer",
+	}
+}
+
+// userFromOrgUser maps an org directory user. Only active users are mapped, see siteResourceType.Grants.
+func userFromOrgUser(orgUser *atlassianclient.User) *jira.User {
+	return &jira.User{
+		AccountID:    orgUser.AccountID,
+		AccountType:  orgUser.AccountType,
+		EmailAddress: orgUser.Email,
+		DisplayName:  orgUser.Name,
+		Active:       orgUser.AccountStatus == atlassianclient.AccountStatusActive,
+	}
+}
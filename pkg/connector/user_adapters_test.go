@@ -0,0 +1,170 @@
+package connector
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+func TestUserAdapters(t *testing.T) {
+	tests := []struct {
+		name string
+		got  *jira.User
+		want *jira.User
+	}{
+		{
+			name: "account ID",
+			got:  userFromAccountID("a-1"),
+			want: &jira.User{AccountID: "a-1"},
+		},
+		{
+			name: "group member",
+			got: userFromGroupMember(&jira.GroupMember{
+				Self:         "https://example.atlassian.net/rest/api/3/user?accountId=a-1",
+				Name:         "alice",
+				Key:          "JIRAUSER10000",
+				AccountID:    "a-1",
+				EmailAddress: "alice@example.com",
+				DisplayName:  "Alice",
+				Active:       true,
+				TimeZone:     "Europe/Paris",
+				AccountType:  "atlassian",
+			}),
+			want: &jira.User{
+				Self:         "https://example.atlassian.net/rest/api/3/user?accountId=a-1",
+				Name:         "alice",
+				Key:          "JIRAUSER10000",
+				AccountID:    "a-1",
+				EmailAddress: "alice@example.com",
+				DisplayName:  "Alice",
+				Active:       true,
+				TimeZone:     "Europe/Paris",
+				AccountType:  "atlassian",
+			},
+		},
+		{
+			name: "role actor",
+			got: userFromActor(&jira.Actor{
+				ID:          10100,
+				DisplayName: "Alice",
+				Type:        "atlassian-user-role-actor",
+				Name:        "alice",
+				ActorUser:   &jira.ActorUser{AccountID: "a-1"},
+			}),
+			want: &jira.User{Name: "alice", AccountID: "a-1", DisplayName: "Alice"},
+		},
+		{
+			name: "project lead",
+			got: userFromLead(&jira.User{
+				Self:         "https://example.atlassian.net/rest/api/3/user?accountId=a-1",
+				Name:         "alice",
+				Key:          "JIRAUSER10000",
+				AccountID:    "a-1",
+				EmailAddress: "alice@example.com",
+				DisplayName:  "Alice",
+				Active:       true,
+				TimeZone:     "Europe/Paris",
+				AccountType:  "atlassian",
+				Locale:       "fr_FR",
+			}),
+			want: &jira.User{
+				Self:         "https://example.atlassian.net/rest/api/3/user?accountId=a-1",
+				Name:         "alice",
+				Key:          "JIRAUSER10000",
+				AccountID:    "a-1",
+				EmailAddress: "alice@example.com",
+				DisplayName:  "Alice",
+				Active:       true,
+				TimeZone:     "Europe/Paris",
+				AccountType:  "atlassian",
+			},
+		},
+		{
+			name: "customer",
+			got: userFromCustomer(&client.Customer{
+				AccountID:    "qm:1",
+				EmailAddress: "bob@example.com",
+				DisplayName:  "Bob",
+				Active:       true,
+				TimeZone:     "UTC",
+			}),
+			want: &jira.User{
+				AccountID:    "qm:1",
+				EmailAddress: "bob@example.com",
+				DisplayName:  "Bob",
+				Active:       true,
+				TimeZone:     "UTC",
+				AccountType:  "customer",
+			},
+		},
+		{
+			name: "active org user",
+			got: userFromOrgUser(&atlassianclient.User{
+				AccountID:     "a-1",
+				AccountType:   "atlassian",
+				AccountStatus: atlassianclient.AccountStatusActive,
+				Name:          "Alice",
+				Email:         "alice@example.com",
+			}),
+			want: &jira.User{
+				AccountID:    "a-1",
+				AccountType:  "atlassian",
+				EmailAddress: "alice@example.com",
+				DisplayName:  "Alice",
+				Active:       true,
+			},
+		},
+		{
+			name: "inactive org user",
+			got: userFromOrgUser(&atlassianclient.User{
+				AccountID:     "a-2",
+				AccountType:   "app",
+				AccountStatus: "inactive",
+				Name:          "Bot",
+			}),
+			want: &jira.User{AccountID: "a-2", AccountType: "app", DisplayName: "Bot"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %+v, want %+v", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+// TestUserFromGroupMemberMapsEveryField checks every field of a group member is copied to
+// the field of the same name of the user, so a field go-jira adds is not dropped.
+func TestUserFromGroupMemberMapsEveryField(t *testing.T) {
+	member := jira.GroupMember{}
+	memberValue := reflect.ValueOf(&member).Elem()
+	for i := 0; i < memberValue.NumField(); i++ {
+		field := memberValue.Field(i)
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(memberValue.Type().Field(i).Name)
+		case reflect.Bool:
+			field.SetBool(true)
+		default:
+			t.Fatalf("group member field %s has unhandled kind %s", memberValue.Type().Field(i).Name, field.Kind())
+		}
+	}
+
+	user := reflect.ValueOf(userFromGroupMember(&member)).Elem()
+	for i := 0; i < memberValue.NumField(); i++ {
+		name := memberValue.Type().Field(i).Name
+		userField := user.FieldByName(name)
+		if !userField.IsValid() {
+			t.Errorf("jira.User has no field %s", name)
+			continue
+		}
+		if !reflect.DeepEqual(userField.Interface(), memberValue.Field(i).Interface()) {
+			t.Errorf("user %s = %v, want %v", name, userField.Interface(), memberValue.Field(i).Interface())
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	pbjira "github.com/conductorone/baton-jira/pb/c1/connector/v2"
+	"github.com/conductorone/baton-jira/pkg/client"
+	"github.com/conductorone/baton-jira/pkg/client/atlassianclient"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	grant "github.com/conductorone/baton-sdk/pkg/types/grant"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	jira "github.com/conductorone/go-jira/v2/cloud"
+)
+
+func TestUserResourceInvitationPending(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        *jira.User
+		opts        []userResourceOption
+		wantPending bool
+	}{
+		{name: "active user", user: &jira.User{AccountID: "a1", AccountType: "atlassian", Active: true}},
+		{name: "deactivated user", user: &jira.User{AccountID: "a2", AccountType: "atlassian"}},
+		{name: "invited user", user: &jira.User{AccountID: "a3", AccountType: "atlassian"}, opts: []userResourceOption{withInvitationPending()}, wantPending: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := userResource(context.Background(), tt.user, tt.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if pending := userProfileField(t, resource, "invitation_pending"); pending != tt.wantPending {
+				t.Errorf("invitation_pending = %v, want %v", pending, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestCreateAccountPendingState(t *testing.T) {
+	tests := []struct {
+		name           string
+		createResponse string
+		userResponse   string
+		userStatus     int
+		wantPending    bool
+		wantStatus     v2.UserTrait_Status_Status
+	}{
+		{
+			name:           "pending invitation",
+			createResponse: `{"accountId":"a1","accountType":"atlassian","active":false}`,
+			wantPending:    true,
+			wantStatus:     v2.UserTrait_Status_STATUS_DISABLED,
+		},
+		{
+			name:           "active at creation",
+			createResponse: `{"accountId":"a1","accountType":"atlassian","active":true}`,
+			wantStatus:     v2.UserTrait_Status_STATUS_ENABLED,
+		},
+		{
+			name:           "minimal response, looked up",
+			createResponse: `{"accountId":"a1"}`,
+			userResponse:   `{"accountId":"a1","accountType":"atlassian","active":false,"displayName":"New User"}`,
+			userStatus:     http.StatusOK,
+			wantPending:    true,
+			wantStatus:     v2.UserTrait_Status_STATUS_DISABLED,
+		},
+		{
+			name:           "minimal response, lookup failed",
+			createResponse: `{"accountId":"a1"}`,
+			userStatus:     http.StatusInternalServerError,
+			wantPending:    true,
+			wantStatus:     v2.UserTrait_Status_STATUS_DISABLED,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				switch {
+				case r.URL.Path == "/rest/api/3/user" && r.Method == http.MethodPost:
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(tt.createResponse))
+				case r.URL.Path == "/rest/api/3/user" && r.Method == http.MethodGet:
+					w.WriteHeader(tt.userStatus)
+					_, _ = w.Write([]byte(tt.userResponse))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			u := &userResourceType{resourceType: resourceTypeUser, client: j.client, apiClient: j.apiClient, session: j.session}
+
+			resp, _, annos, err := u.CreateAccount(context.Background(), &v2.AccountInfo{Login: "new@example.com"}, nil)
+			if err != nil {
+				t.Fatalf("CreateAccount() error = %v", err)
+			}
+
+			resource := resp.(*v2.CreateAccountResponse_SuccessResult).Resource
+			if pending := userProfileField(t, resource, "invitation_pending"); pending != tt.wantPending {
+				t.Errorf("invitation_pending = %v, want %v", pending, tt.wantPending)
+			}
+			if got := userTrait(t, resource).GetStatus().GetStatus(); got != tt.wantStatus {
+				t.Errorf("status = %s, want %s", got, tt.wantStatus)
+			}
+			if sent := annos.Contains(&pbjira.JiraInvitationSent{}); sent != tt.wantPending {
+				t.Errorf("JiraInvitationSent annotation = %v, want %v", sent, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestCreateAccountInvitation(t *testing.T) {
+	tests := []struct {
+		name             string
+		sendInvitation   bool
+		wantNotification bool
+	}{
+		{name: "invitation requested", sendInvitation: true, wantNotification: true},
+		{name: "invitation not requested"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var posts []string
+			var body client.CreateUserBody
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				if r.Method != http.MethodPost {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+
+				posts = append(posts, r.URL.Path)
+				if r.URL.Path == "/rest/api/3/user" {
+					if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+						t.Errorf("decoding create body: %v", err)
+					}
+				}
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"accountId":"a1","accountType":"atlassian","active":false}`))
+			}))
+			u := &userResourceType{resourceType: resourceTypeUser, client: j.client, apiClient: j.apiClient, session: j.session, sendInvitation: tt.sendInvitation}
+
+			if _, _, _, err := u.CreateAccount(context.Background(), &v2.AccountInfo{Login: "new@example.com"}, nil); err != nil {
+				t.Fatalf("CreateAccount() error = %v", err)
+			}
+
+			// The invitation is part of the create request, not a request of its own.
+			if len(posts) != 1 || posts[0] != "/rest/api/3/user" {
+				t.Errorf("POST requests = %v, want only /rest/api/3/user", posts)
+			}
+			if body.Notification != tt.wantNotification {
+				t.Errorf("notification = %v, want %v", body.Notification, tt.wantNotification)
+			}
+		})
+	}
+}
+
+// TestUserProvisioningCapabilities checks users only advertise account creation. The SDK
+// registers deletes through ResourceManager, whose Create users cannot implement.
+func TestUserProvisioningCapabilities(t *testing.T) {
+	ctx := context.Background()
+	users := &userResourceType{resourceType: resourceTypeUser}
+
+	tests := []struct {
+		name   string
+		syncer connectorbuilder.ResourceSyncer
+	}{
+		{name: "single site", syncer: users},
+		{name: "multiple sites", syncer: newMultiSiteSyncer(ctx, []siteSyncer{{syncer: users}, {syncer: users, siteID: "s-2", siteName: "second"}}, nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.syncer.(connectorbuilder.AccountManager); !ok {
+				t.Errorf("%T is not an AccountManager", tt.syncer)
+			}
+			if _, ok := tt.syncer.(connectorbuilder.ResourceManager); ok {
+				t.Errorf("%T is a ResourceManager, advertising a create users do not support", tt.syncer)
+			}
+		})
+	}
+}
+
+func userTrait(t *testing.T, resource *v2.Resource) *v2.UserTrait {
+	t.Helper()
+
+	trait, err := rs.GetUserTrait(resource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return trait
+}
+
+func userProfileField(t *testing.T, resource *v2.Resource, key string) bool {
+	t.Helper()
+
+	value, ok := userTrait(t, resource).GetProfile().GetFields()[key]
+	return ok && value.GetBoolValue()
+}
+
+// newTestClaimStatusFilter returns a filter of statuses over the given org users, which are
+// cached in the session so that the org admin API is not called.
+func newTestClaimStatusFilter(session *sessionStore, statuses []string, orgUsers map[string]atlassianclient.User) *claimStatusFilter {
+	session.orgUsers = orgUsers
+	session.orgUsersFetchedAt = time.Now()
+
+	return newClaimStatusFilter(statuses, &atlassianclient.AtlassianClient{}, session)
+}
+
+func TestClaimStatusFilter(t *testing.T) {
+	orgUsers := map[string]atlassianclient.User{
+		"verified":   {AccountID: "verified", ClaimStatus: "VERIFIED"},
+		"unverified": {AccountID: "unverified", ClaimStatus: "UNVERIFIED"},
+	}
+
+	tests := []struct {
+		name        string
+		statuses    []string
+		wantGranted []string
+	}{
+		{name: "no filter", wantGranted: []string{"verified", "unverified", "external", "group"}},
+		{name: "verified only", statuses: []string{"verified"}, wantGranted: []string{"verified", "group"}},
+		{name: "both statuses", statuses: []string{"VERIFIED", "UNVERIFIED"}, wantGranted: []string{"verified", "unverified", "group"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := newTestClaimStatusFilter(newSessionStore(0, time.Hour, nil), tt.statuses, orgUsers)
+			resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeGroup.Id, Resource: "g1"}}
+
+			var grants []*v2.Grant
+			for _, accountID := range []string{"verified", "unverified", "external"} {
+				grants = append(grants, grant.NewGrant(resource, memberEntitlement, &v2.ResourceId{ResourceType: resourceTypeUser.Id, Resource: accountID}))
+			}
+			grants = append(grants, grant.NewGrant(resource, memberEntitlement, &v2.ResourceId{ResourceType: resourceTypeGroup.Id, Resource: "group"}))
+
+			filtered, err := filter.filterGrants(context.Background(), grants)
+			if err != nil {
+				t.Fatalf("filterGrants() error = %v", err)
+			}
+
+			var granted []string
+			for _, g := range filtered {
+				granted = append(granted, g.GetPrincipal().GetId().GetResource())
+			}
+			if !slices.Equal(granted, tt.wantGranted) {
+				t.Errorf("granted = %v, want %v", granted, tt.wantGranted)
+			}
+		})
+	}
+}
+
+func TestRoleGrantsClaimStatusFilter(t *testing.T) {
+	tests := []struct {
+		name        string
+		statuses    []string
+		wantGranted []string
+	}{
+		{name: "no filter", wantGranted: []string{"verified", "unverified", "g1"}},
+		{name: "verified only", statuses: []string{"VERIFIED"}, wantGranted: []string{"verified", "g1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := newTestJira(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/rest/api/3/role/10002/actors" {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = w.Write([]byte(`{"actors":[` +
+					`{"id":1,"type":"atlassian-user-role-actor","actorUser":{"accountId":"verified"}},` +
+					`{"id":2,"type":"atlassian-user-role-actor","actorUser":{"accountId":"unverified"}},` +
+					`{"id":3,"type":"atlassian-group-role-actor","actorGroup":{"name":"one","groupId":"g1"}}]}`))
+			}))
+			filter := newTestClaimStatusFilter(j.session, tt.statuses, map[string]atlassianclient.User{
+				"verified":   {AccountID: "verified", ClaimStatus: "VERIFIED"},
+				"unverified": {AccountID: "unverified", ClaimStatus: "UNVERIFIED"},
+			})
+			r := roleBuilder(j.client, j.apiClient, filter)
+
+			resource := &v2.Resource{Id: &v2.ResourceId{ResourceType: resourceTypeRole.Id, Resource: "10002"}}
+			grants, _, _, err := r.Grants(context.Background(), resource, &pagination.Token{})
+			if err != nil {
+				t.Fatalf("Grants() error = %v", err)
+			}
+
+			var granted []string
+			for _, g := range grants {
+				granted = append(granted, g.GetPrincipal().GetId().GetResource())
+			}
+			if !slices.Equal(granted, tt.wantGranted) {
+				t.Errorf("granted = %v, want %v", granted, tt.wantGranted)
+			}
+		})
+	}
+}